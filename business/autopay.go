@@ -0,0 +1,11 @@
+package business
+
+import "context"
+
+// Autopay interface settles every pending charge on its own, as if the customer paid it,
+// for load and demo environments.
+type Autopay interface {
+	// Run settles or expires the pending charges that are due right away, then keeps
+	// checking periodically until ctx is canceled.
+	Run(ctx context.Context)
+}
@@ -0,0 +1,96 @@
+package autopay_service
+
+import (
+	"fmt"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/repository"
+)
+
+// Config configures the autopay service. Credit card charges go through the same delay
+// and expire share as every other charge, but the ones that don't expire pass 3-D Secure
+// with the correct OTP instead of being settled, so the card outcome still decides
+// whether they are captured, authorized or denied.
+type Config struct {
+	TransactionRepository repository.TransactionRepository
+	CreditCardRepository  repository.CreditCardRepository
+	TransactionService    business.Transaction
+	PaymentService        business.Payment
+	// MinimumDelay and MaximumDelay are how long a charge stays pending, picked at random
+	// within the range for each charge. Leave MaximumDelay empty for a fixed delay.
+	MinimumDelay time.Duration
+	MaximumDelay time.Duration
+	// ExpirePercent is the share of charges that expire instead of being settled, from 0 to 100.
+	ExpirePercent int
+	// Interval is how often pending charges are checked. Defaults to 1 second.
+	Interval time.Duration
+}
+
+type Dependency struct {
+	transactionRepository repository.TransactionRepository
+	creditCardRepository  repository.CreditCardRepository
+	transactionService    business.Transaction
+	paymentService        business.Payment
+	minimumDelay          time.Duration
+	maximumDelay          time.Duration
+	expirePercent         int
+	interval              time.Duration
+}
+
+// NewAutopayService validates input from Config and return an error if any of it is nil.
+// It implements business.Autopay interface.
+func NewAutopayService(config Config) (*Dependency, error) {
+	if config.TransactionRepository == nil {
+		return nil, fmt.Errorf("nil transaction repository")
+	}
+
+	if config.CreditCardRepository == nil {
+		return nil, fmt.Errorf("nil credit card repository")
+	}
+
+	if config.TransactionService == nil {
+		return nil, fmt.Errorf("nil transaction service")
+	}
+
+	if config.PaymentService == nil {
+		return nil, fmt.Errorf("nil payment service")
+	}
+
+	if config.MinimumDelay < 0 {
+		return nil, fmt.Errorf("minimum delay must not be negative")
+	}
+
+	maximumDelay := config.MaximumDelay
+	if maximumDelay == 0 {
+		maximumDelay = config.MinimumDelay
+	}
+
+	if maximumDelay < config.MinimumDelay {
+		return nil, fmt.Errorf("maximum delay must not be less than minimum delay")
+	}
+
+	if config.ExpirePercent < 0 || config.ExpirePercent > 100 {
+		return nil, fmt.Errorf("expire percent must be between 0 and 100")
+	}
+
+	if config.Interval < 0 {
+		return nil, fmt.Errorf("interval must not be negative")
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = time.Second
+	}
+
+	return &Dependency{
+		transactionRepository: config.TransactionRepository,
+		creditCardRepository:  config.CreditCardRepository,
+		transactionService:    config.TransactionService,
+		paymentService:        config.PaymentService,
+		minimumDelay:          config.MinimumDelay,
+		maximumDelay:          maximumDelay,
+		expirePercent:         config.ExpirePercent,
+		interval:              interval,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package autopay_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/business/poller"
+	"mock-payment-provider/primitive"
+)
+
+func (d *Dependency) Run(ctx context.Context) {
+	poller.Run(ctx, d.interval, d.completeDue)
+}
+
+// creditCardOTP is the OTP that passes the 3-D Secure authentication.
+const creditCardOTP = "112233"
+
+// completeDue settles or expires every pending charge whose delay has passed. A charge that
+// the customer or the merchant completed in the meantime is left as it is.
+func (d *Dependency) completeDue(ctx context.Context) {
+	log := zerolog.Ctx(ctx)
+
+	now := time.Now()
+	transactions, err := d.transactionRepository.ListPending(ctx, now.Add(-d.minimumDelay))
+	if err != nil {
+		log.Err(err).Msg("listing pending transactions")
+		return
+	}
+
+	for _, transaction := range transactions {
+		if transaction.TransactionTime.Add(d.delay(transaction.OrderId)).After(now) {
+			continue
+		}
+
+		if d.expires(transaction.OrderId) {
+			_, err = d.transactionService.Expire(ctx, transaction.OrderId)
+		} else if transaction.PaymentType == primitive.PaymentTypeCreditCard {
+			err = d.authenticateCard(ctx, transaction.OrderId)
+		} else {
+			err = d.paymentService.MarkAsPaid(ctx, transaction.OrderId, transaction.PaymentType)
+		}
+		if err != nil && !errors.Is(err, business.ErrCannotModifyStatus) {
+			log.Err(err).Str("orderId", transaction.OrderId).Msg("completing pending transaction")
+		}
+	}
+}
+
+// authenticateCard passes 3-D Secure for a pending card charge, just like the customer would
+// on the bank's page, since a card charge can't be settled on its own.
+func (d *Dependency) authenticateCard(ctx context.Context, orderId string) error {
+	creditCardCharge, err := d.creditCardRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return fmt.Errorf("acquiring credit card charge: %w", err)
+	}
+
+	_, err = d.paymentService.AuthenticateCard(ctx, creditCardCharge.Id, creditCardOTP)
+	return err
+}
+
+// delay picks how long the charge stays pending. It is always the same for the same order,
+// so the charge keeps its delay across scans and restarts.
+func (d *Dependency) delay(orderId string) time.Duration {
+	return d.minimumDelay + time.Duration(draw("delay", orderId)*float64(d.maximumDelay-d.minimumDelay))
+}
+
+// expires tells whether the charge expires instead of being settled, which is also always
+// the same for the same order.
+func (d *Dependency) expires(orderId string) bool {
+	return draw("expire", orderId)*100 < float64(d.expirePercent)
+}
+
+// draw returns a number in [0, 1) out of the order ID, spread evenly across orders.
+func draw(purpose string, orderId string) float64 {
+	sum := sha256.Sum256([]byte(purpose + ":" + orderId))
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+}
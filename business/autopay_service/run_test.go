@@ -0,0 +1,243 @@
+package autopay_service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/business/autopay_service"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+// transactionRepository only knows about the pending transactions it was given.
+type transactionRepository struct {
+	repository.TransactionRepository
+	pending []primitive.Transaction
+}
+
+func (r *transactionRepository) ListPending(ctx context.Context, createdBefore time.Time) ([]primitive.Transaction, error) {
+	var transactions []primitive.Transaction
+	for _, transaction := range r.pending {
+		if !transaction.TransactionTime.After(createdBefore) {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	return transactions, nil
+}
+
+// creditCardRepository finds a card charge for any order.
+type creditCardRepository struct {
+	repository.CreditCardRepository
+}
+
+func (r *creditCardRepository) GetByOrderId(ctx context.Context, orderId string) (primitive.CreditCardCharge, error) {
+	return primitive.CreditCardCharge{Id: "card-" + orderId, OrderId: orderId}, nil
+}
+
+// transactionService records the orders it expired.
+type transactionService struct {
+	business.Transaction
+	expired []string
+}
+
+func (s *transactionService) Expire(ctx context.Context, orderId string) (business.ExpireResponse, error) {
+	s.expired = append(s.expired, orderId)
+	return business.ExpireResponse{}, nil
+}
+
+// paymentService records the orders it settled and the card charges it authenticated.
+type paymentService struct {
+	business.Payment
+	paid          []string
+	authenticated []string
+}
+
+func (s *paymentService) MarkAsPaid(ctx context.Context, orderId string, paymentMethod primitive.PaymentType) error {
+	s.paid = append(s.paid, orderId)
+	return nil
+}
+
+func (s *paymentService) AuthenticateCard(ctx context.Context, creditCardId string, otp string) (primitive.TransactionStatus, error) {
+	if otp != "112233" {
+		return primitive.TransactionStatusDeny, nil
+	}
+
+	s.authenticated = append(s.authenticated, creditCardId)
+	return primitive.TransactionStatusCapture, nil
+}
+
+// pendingTransactions creates count pending transactions that were created age ago.
+func pendingTransactions(count int, paymentType primitive.PaymentType, age time.Duration) []primitive.Transaction {
+	transactions := make([]primitive.Transaction, count)
+	for i := range transactions {
+		transactions[i] = primitive.Transaction{
+			OrderId:           fmt.Sprintf("autopay-%d", i),
+			PaymentType:       paymentType,
+			TransactionStatus: primitive.TransactionStatusPending,
+			TransactionTime:   time.Now().Add(-age),
+		}
+	}
+
+	return transactions
+}
+
+// scanOnce runs a single scan, as Run always scans once before looking at ctx.
+func scanOnce(t *testing.T, config autopay_service.Config) {
+	t.Helper()
+
+	autopayService, err := autopay_service.NewAutopayService(config)
+	if err != nil {
+		t.Fatalf("creating autopay service: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	autopayService.Run(ctx)
+}
+
+func TestDependency_Run(t *testing.T) {
+	t.Run("Settle Everything", func(t *testing.T) {
+		transactions := &transactionService{}
+		payments := &paymentService{}
+		scanOnce(t, autopay_service.Config{
+			TransactionRepository: &transactionRepository{pending: pendingTransactions(100, primitive.PaymentTypeEMoneyGopay, time.Minute)},
+			CreditCardRepository:  &creditCardRepository{},
+			TransactionService:    transactions,
+			PaymentService:        payments,
+		})
+
+		if len(payments.paid) != 100 || len(transactions.expired) != 0 {
+			t.Errorf("expecting 100 settled and 0 expired, instead got %d and %d", len(payments.paid), len(transactions.expired))
+		}
+	})
+
+	t.Run("Expire Everything", func(t *testing.T) {
+		transactions := &transactionService{}
+		payments := &paymentService{}
+		scanOnce(t, autopay_service.Config{
+			TransactionRepository: &transactionRepository{pending: pendingTransactions(100, primitive.PaymentTypeEMoneyGopay, time.Minute)},
+			CreditCardRepository:  &creditCardRepository{},
+			TransactionService:    transactions,
+			PaymentService:        payments,
+			ExpirePercent:         100,
+		})
+
+		if len(payments.paid) != 0 || len(transactions.expired) != 100 {
+			t.Errorf("expecting 0 settled and 100 expired, instead got %d and %d", len(payments.paid), len(transactions.expired))
+		}
+	})
+
+	t.Run("Expire Split", func(t *testing.T) {
+		pending := &transactionRepository{pending: pendingTransactions(1000, primitive.PaymentTypeEMoneyGopay, time.Minute)}
+
+		first := &transactionService{}
+		scanOnce(t, autopay_service.Config{
+			TransactionRepository: pending,
+			CreditCardRepository:  &creditCardRepository{},
+			TransactionService:    first,
+			PaymentService:        &paymentService{},
+			ExpirePercent:         30,
+		})
+
+		if len(first.expired) < 250 || len(first.expired) > 350 {
+			t.Errorf("expecting about 300 of 1000 to expire, instead got %d", len(first.expired))
+		}
+
+		// An order keeps its outcome, for example after a restart
+		second := &transactionService{}
+		scanOnce(t, autopay_service.Config{
+			TransactionRepository: pending,
+			CreditCardRepository:  &creditCardRepository{},
+			TransactionService:    second,
+			PaymentService:        &paymentService{},
+			ExpirePercent:         30,
+		})
+
+		if fmt.Sprint(first.expired) != fmt.Sprint(second.expired) {
+			t.Errorf("expecting the same orders to expire on every scan")
+		}
+	})
+
+	t.Run("Fixed Delay", func(t *testing.T) {
+		for _, testCase := range []struct {
+			age      time.Duration
+			expected int
+		}{
+			{age: time.Minute * 4, expected: 0},
+			{age: time.Minute * 6, expected: 100},
+		} {
+			payments := &paymentService{}
+			scanOnce(t, autopay_service.Config{
+				TransactionRepository: &transactionRepository{pending: pendingTransactions(100, primitive.PaymentTypeEMoneyGopay, testCase.age)},
+				CreditCardRepository:  &creditCardRepository{},
+				TransactionService:    &transactionService{},
+				PaymentService:        payments,
+				MinimumDelay:          time.Minute * 5,
+			})
+
+			if len(payments.paid) != testCase.expected {
+				t.Errorf("expecting %d settled after %s, instead got %d", testCase.expected, testCase.age, len(payments.paid))
+			}
+		}
+	})
+
+	t.Run("Delay Range", func(t *testing.T) {
+		for _, testCase := range []struct {
+			age     time.Duration
+			minimum int
+			maximum int
+		}{
+			// Nothing is due before the minimum delay, everything is due after the maximum
+			{age: time.Minute * 9, minimum: 0, maximum: 0},
+			{age: time.Minute * 15, minimum: 400, maximum: 600},
+			{age: time.Minute * 21, minimum: 1000, maximum: 1000},
+		} {
+			payments := &paymentService{}
+			scanOnce(t, autopay_service.Config{
+				TransactionRepository: &transactionRepository{pending: pendingTransactions(1000, primitive.PaymentTypeEMoneyGopay, testCase.age)},
+				CreditCardRepository:  &creditCardRepository{},
+				TransactionService:    &transactionService{},
+				PaymentService:        payments,
+				MinimumDelay:          time.Minute * 10,
+				MaximumDelay:          time.Minute * 20,
+			})
+
+			if len(payments.paid) < testCase.minimum || len(payments.paid) > testCase.maximum {
+				t.Errorf("expecting %d to %d settled after %s, instead got %d", testCase.minimum, testCase.maximum, testCase.age, len(payments.paid))
+			}
+		}
+	})
+
+	t.Run("Credit Card", func(t *testing.T) {
+		transactions := &transactionService{}
+		payments := &paymentService{}
+		scanOnce(t, autopay_service.Config{
+			TransactionRepository: &transactionRepository{pending: pendingTransactions(100, primitive.PaymentTypeCreditCard, time.Minute)},
+			CreditCardRepository:  &creditCardRepository{},
+			TransactionService:    transactions,
+			PaymentService:        payments,
+			ExpirePercent:         50,
+		})
+
+		// Card charges that don't expire pass 3-D Secure instead of being settled
+		if len(payments.paid) != 0 {
+			t.Errorf("expecting no card charge to be settled, instead got %d", len(payments.paid))
+		}
+
+		if len(payments.authenticated)+len(transactions.expired) != 100 || len(transactions.expired) < 30 || len(transactions.expired) > 70 {
+			t.Errorf("expecting about half of 100 card charges to expire and the rest to be authenticated, instead got %d expired and %d authenticated", len(transactions.expired), len(payments.authenticated))
+		}
+
+		for _, creditCardId := range payments.authenticated {
+			if !strings.HasPrefix(creditCardId, "card-") {
+				t.Errorf("expecting the credit card charge ID, instead got %q", creditCardId)
+			}
+		}
+	})
+}
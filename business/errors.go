@@ -17,10 +17,40 @@ var ErrMismatchedTransactionAmount = errors.New("mismatched transaction amount")
 var ErrTransactionNotFound = errors.New("transaction not found")
 
 // ErrCannotModifyStatus should be returned for cases that overstep the status flow.
-// For example, if the current status is settled, we can't request the status to be changed to
-// canceled, and vice versa.
+// For example, if the current status is settlement, we can't request the status to be changed to
+// cancel, and vice versa. See primitive.TransactionStatus.CanTransitionTo for the flow.
 var ErrCannotModifyStatus = errors.New("cannot modify status")
 
+// ErrRefundNotSupported should be returned when the payment type of the transaction
+// can't be refunded, or can't be refunded through the requested refund method.
+var ErrRefundNotSupported = errors.New("refund is not supported")
+
+// ErrRefundAmountExceeded should be returned when the refund amount exceeds the amount
+// that is still refundable for the transaction.
+var ErrRefundAmountExceeded = errors.New("refund amount exceeded")
+
+// ErrDuplicateRefundKey should be returned if the refund key was already used for
+// the same transaction.
+var ErrDuplicateRefundKey = errors.New("duplicate refund key")
+
+// ErrInvalidCardToken should be returned when the card token doesn't exist, has expired,
+// or is a regular token that was already used for a charge.
+var ErrInvalidCardToken = errors.New("invalid card token")
+
+// ErrCaptureAmountExceeded should be returned when the capture amount exceeds the amount
+// that was authorized on the card.
+var ErrCaptureAmountExceeded = errors.New("capture amount exceeded")
+
+// ErrVirtualAccountNumberTaken should be returned when the virtual account number requested
+// by the merchant is held by another customer.
+var ErrVirtualAccountNumberTaken = errors.New("virtual account number is taken")
+
+// ErrWebhookAttemptNotFound should be returned when a webhook delivery attempt was not found.
+var ErrWebhookAttemptNotFound = errors.New("webhook attempt not found")
+
+// ErrRuleNotFound should be returned when a rule was not found.
+var ErrRuleNotFound = errors.New("rule not found")
+
 // RequestValidationCode provides a typed string for validation error codes.
 type RequestValidationCode string
 
@@ -0,0 +1,10 @@
+package business
+
+import "context"
+
+// Expiry interface expires the transactions that the customer didn't pay in time.
+type Expiry interface {
+	// Run expires overdue transactions right away, which covers the ones that went overdue
+	// while the service was down, then keeps checking periodically until ctx is canceled.
+	Run(ctx context.Context)
+}
@@ -0,0 +1,41 @@
+package expiry_service
+
+import (
+	"fmt"
+	"time"
+
+	"mock-payment-provider/business"
+)
+
+type Config struct {
+	TransactionService business.Transaction
+	// Interval is how often overdue transactions are checked. Defaults to 5 seconds.
+	Interval time.Duration
+}
+
+type Dependency struct {
+	transactionService business.Transaction
+	interval           time.Duration
+}
+
+// NewExpiryService validates input from Config and return an error if any of it is nil.
+// It implements business.Expiry interface.
+func NewExpiryService(config Config) (*Dependency, error) {
+	if config.TransactionService == nil {
+		return nil, fmt.Errorf("nil transaction service")
+	}
+
+	if config.Interval < 0 {
+		return nil, fmt.Errorf("interval must not be negative")
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = time.Second * 5
+	}
+
+	return &Dependency{
+		transactionService: config.TransactionService,
+		interval:           interval,
+	}, nil
+}
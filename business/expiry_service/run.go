@@ -0,0 +1,26 @@
+package expiry_service
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business/poller"
+)
+
+func (d *Dependency) Run(ctx context.Context) {
+	poller.Run(ctx, d.interval, d.expireOverdue)
+}
+
+// expireOverdue expires every transaction that went past its expiry time.
+func (d *Dependency) expireOverdue(ctx context.Context) {
+	log := zerolog.Ctx(ctx)
+
+	expired, err := d.transactionService.ExpireOverdue(ctx)
+	if err != nil {
+		log.Err(err).Msg("expiring overdue transactions")
+	}
+
+	if expired > 0 {
+		log.Info().Int("count", expired).Msg("expired overdue transactions")
+	}
+}
@@ -0,0 +1,10 @@
+package business
+
+import "context"
+
+// Outcome interface drives the charges that match a magic value to their scheduled outcome.
+type Outcome interface {
+	// Run applies the outcomes that are due right away, which covers the ones that came due
+	// while the service was down, then keeps checking periodically until ctx is canceled.
+	Run(ctx context.Context)
+}
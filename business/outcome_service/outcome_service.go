@@ -0,0 +1,56 @@
+package outcome_service
+
+import (
+	"fmt"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/repository"
+)
+
+type Config struct {
+	OutcomeRepository  repository.OutcomeRepository
+	TransactionService business.Transaction
+	PaymentService     business.Payment
+	// Interval is how often due outcomes are checked. Defaults to 1 second.
+	Interval time.Duration
+}
+
+type Dependency struct {
+	outcomeRepository  repository.OutcomeRepository
+	transactionService business.Transaction
+	paymentService     business.Payment
+	interval           time.Duration
+}
+
+// NewOutcomeService validates input from Config and return an error if any of it is nil.
+// It implements business.Outcome interface.
+func NewOutcomeService(config Config) (*Dependency, error) {
+	if config.OutcomeRepository == nil {
+		return nil, fmt.Errorf("nil outcome repository")
+	}
+
+	if config.TransactionService == nil {
+		return nil, fmt.Errorf("nil transaction service")
+	}
+
+	if config.PaymentService == nil {
+		return nil, fmt.Errorf("nil payment service")
+	}
+
+	if config.Interval < 0 {
+		return nil, fmt.Errorf("interval must not be negative")
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = time.Second
+	}
+
+	return &Dependency{
+		outcomeRepository:  config.OutcomeRepository,
+		transactionService: config.TransactionService,
+		paymentService:     config.PaymentService,
+		interval:           interval,
+	}, nil
+}
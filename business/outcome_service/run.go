@@ -0,0 +1,61 @@
+package outcome_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/business/poller"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) Run(ctx context.Context) {
+	poller.Run(ctx, d.interval, d.applyDue)
+}
+
+// applyDue applies every outcome that is due. An outcome that failed for any other reason
+// than the charge having moved on is kept, to be tried again on the next scan.
+func (d *Dependency) applyDue(ctx context.Context) {
+	log := zerolog.Ctx(ctx)
+
+	outcomes, err := d.outcomeRepository.ListDue(ctx)
+	if err != nil {
+		log.Err(err).Msg("listing due outcomes")
+		return
+	}
+
+	for _, outcome := range outcomes {
+		err := d.apply(ctx, outcome)
+		if err != nil && !errors.Is(err, business.ErrCannotModifyStatus) && !errors.Is(err, business.ErrTransactionNotFound) {
+			log.Err(err).Str("orderId", outcome.OrderId).Msg("applying outcome")
+			continue
+		}
+
+		err = d.outcomeRepository.Delete(ctx, outcome.OrderId)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			log.Err(err).Str("orderId", outcome.OrderId).Msg("deleting applied outcome")
+		}
+	}
+}
+
+// apply drives the charge to its outcome through the same path as the customer or the
+// merchant would, so the webhooks and the status history look the same.
+func (d *Dependency) apply(ctx context.Context, outcome primitive.ScheduledOutcome) error {
+	switch outcome.Outcome {
+	case primitive.TransactionStatusSettlement:
+		// The transaction's own payment type is used when it is unspecified
+		return d.paymentService.MarkAsPaid(ctx, outcome.OrderId, primitive.PaymentTypeUnspecified)
+	case primitive.TransactionStatusExpire:
+		_, err := d.transactionService.Expire(ctx, outcome.OrderId)
+		return err
+	case primitive.TransactionStatusDeny:
+		fallthrough
+	case primitive.TransactionStatusFailure:
+		return d.paymentService.Decline(ctx, outcome.OrderId, outcome.Outcome)
+	default:
+		return fmt.Errorf("invalid outcome %s", outcome.Outcome)
+	}
+}
@@ -0,0 +1,178 @@
+package outcome_service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"mock-payment-provider/business"
+	"mock-payment-provider/business/outcome_service"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/outcome"
+)
+
+// transactionService records the orders it expired, and fails the ones in errs.
+type transactionService struct {
+	business.Transaction
+	expired []string
+	errs    map[string]error
+}
+
+func (s *transactionService) Expire(ctx context.Context, orderId string) (business.ExpireResponse, error) {
+	s.expired = append(s.expired, orderId)
+	return business.ExpireResponse{}, s.errs[orderId]
+}
+
+// paymentService records the orders it settled or declined, and fails the ones in errs.
+type paymentService struct {
+	business.Payment
+	paid     []string
+	declined []string
+	errs     map[string]error
+}
+
+func (s *paymentService) MarkAsPaid(ctx context.Context, orderId string, paymentMethod primitive.PaymentType) error {
+	s.paid = append(s.paid, orderId)
+	return s.errs[orderId]
+}
+
+func (s *paymentService) Decline(ctx context.Context, orderId string, transactionStatus primitive.TransactionStatus) error {
+	s.declined = append(s.declined, orderId+":"+transactionStatus.String())
+	return s.errs[orderId]
+}
+
+func TestDependency_Run(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:?_txlock=exclusive&_foreign_keys=1&")
+	if err != nil {
+		t.Fatalf("opening sql database: %s", err.Error())
+	}
+	defer db.Close()
+
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	outcomeRepository, err := outcome.NewOutcomeRepository(db)
+	if err != nil {
+		t.Fatalf("creating outcome repository: %s", err.Error())
+	}
+
+	err = outcomeRepository.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("migrating database: %s", err.Error())
+	}
+
+	// scanOnce applies the due outcomes once, as Run always scans once before looking at ctx
+	scanOnce := func(t *testing.T, transactions *transactionService, payments *paymentService) {
+		t.Helper()
+
+		outcomeService, err := outcome_service.NewOutcomeService(outcome_service.Config{
+			OutcomeRepository:  outcomeRepository,
+			TransactionService: transactions,
+			PaymentService:     payments,
+		})
+		if err != nil {
+			t.Fatalf("creating outcome service: %s", err.Error())
+		}
+
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		outcomeService.Run(canceledCtx)
+	}
+
+	schedule := func(t *testing.T, status primitive.TransactionStatus, dueAt time.Time) string {
+		t.Helper()
+
+		orderId := uuid.NewString()
+		err := outcomeRepository.Schedule(ctx, primitive.ScheduledOutcome{
+			OrderId: orderId,
+			Outcome: status,
+			DueAt:   dueAt,
+		})
+		if err != nil {
+			t.Fatalf("scheduling outcome: %s", err.Error())
+		}
+
+		return orderId
+	}
+
+	t.Run("Outcomes", func(t *testing.T) {
+		settled := schedule(t, primitive.TransactionStatusSettlement, time.Now().Add(-time.Second))
+		expired := schedule(t, primitive.TransactionStatusExpire, time.Now().Add(-time.Second))
+		denied := schedule(t, primitive.TransactionStatusDeny, time.Now().Add(-time.Second))
+		failed := schedule(t, primitive.TransactionStatusFailure, time.Now().Add(-time.Second))
+		notDue := schedule(t, primitive.TransactionStatusSettlement, time.Now().Add(time.Hour))
+
+		transactions := &transactionService{}
+		payments := &paymentService{}
+		scanOnce(t, transactions, payments)
+
+		if fmt.Sprint(payments.paid) != fmt.Sprint([]string{settled}) {
+			t.Errorf("expecting %s to be marked as paid, instead got %v", settled, payments.paid)
+		}
+
+		if fmt.Sprint(transactions.expired) != fmt.Sprint([]string{expired}) {
+			t.Errorf("expecting %s to be expired, instead got %v", expired, transactions.expired)
+		}
+
+		if len(payments.declined) != 2 ||
+			payments.declined[0] != denied+":deny" ||
+			payments.declined[1] != failed+":failure" {
+			t.Errorf("expecting %s to be denied and %s to fail, instead got %v", denied, failed, payments.declined)
+		}
+
+		// The applied outcomes are removed, the one that is not due yet stays
+		outcomes, err := outcomeRepository.ListDue(ctx)
+		if err != nil {
+			t.Fatalf("listing due outcomes: %s", err.Error())
+		}
+
+		if len(outcomes) != 0 {
+			t.Errorf("expecting no due outcome, instead got %d", len(outcomes))
+		}
+
+		err = outcomeRepository.Delete(ctx, notDue)
+		if err != nil {
+			t.Errorf("expecting the outcome that is not due to be kept, instead got %v", err)
+		}
+	})
+
+	t.Run("Charge Moved On", func(t *testing.T) {
+		orderId := schedule(t, primitive.TransactionStatusSettlement, time.Now().Add(-time.Second))
+
+		payments := &paymentService{errs: map[string]error{orderId: business.ErrCannotModifyStatus}}
+		scanOnce(t, &transactionService{}, payments)
+
+		if len(payments.paid) != 1 {
+			t.Errorf("expecting 1 attempt, instead got %d", len(payments.paid))
+		}
+
+		// The outcome can never be applied, so it is not tried again
+		payments = &paymentService{}
+		scanOnce(t, &transactionService{}, payments)
+
+		if len(payments.paid) != 0 {
+			t.Errorf("expecting the outcome to be removed, instead got %d attempts", len(payments.paid))
+		}
+	})
+
+	t.Run("Retry On Failure", func(t *testing.T) {
+		orderId := schedule(t, primitive.TransactionStatusExpire, time.Now().Add(-time.Second))
+
+		transactions := &transactionService{errs: map[string]error{orderId: fmt.Errorf("database is locked")}}
+		scanOnce(t, transactions, &paymentService{})
+
+		transactions = &transactionService{}
+		scanOnce(t, transactions, &paymentService{})
+
+		if fmt.Sprint(transactions.expired) != fmt.Sprint([]string{orderId}) {
+			t.Errorf("expecting %s to be expired on the next scan, instead got %v", orderId, transactions.expired)
+		}
+	})
+}
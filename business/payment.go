@@ -9,13 +9,45 @@ import (
 // Payment interface handles anything to do with the completion of a payment,
 // from the customer's standpoint.
 type Payment interface {
-	// GetDetail GetDetails will acquire a payment detail from an ID coming from e-money or
-	// virtual account payment.
+	// GetDetail GetDetails will acquire a payment detail from an ID coming from e-money,
+	// virtual account, convenience store or credit card payment. A QRIS payload string
+	// (the result of scanning the QR code) is also accepted as the ID.
 	GetDetail(ctx context.Context, id string) (PaymentDetailsResponse, error)
 	// MarkAsPaid will mark an order ID as paid. This one function must only be called
 	// from the presentation that handles payment confirmation from the customer's
 	// standpoint.
 	MarkAsPaid(ctx context.Context, orderId string, paymentMethod primitive.PaymentType) error
+	// Cancel will cancel a pending e-money payment from its e-money ID. Just like MarkAsPaid,
+	// this must only be called from the customer's standpoint (for example, the customer
+	// pressing the cancel button on their e-wallet app).
+	Cancel(ctx context.Context, eMoneyId string) error
+	// AuthenticateCard completes the 3-D Secure authentication of a pending credit card
+	// charge. The card will be captured if the OTP is correct, and denied otherwise.
+	AuthenticateCard(ctx context.Context, creditCardId string, otp string) (primitive.TransactionStatus, error)
+	// CompletePaylater approves or rejects a pending paylater charge from its ID, just like
+	// the customer would on the paylater provider's page. An approved charge is settled,
+	// and a rejected one is denied.
+	CompletePaylater(ctx context.Context, paylaterId string, approved bool) (primitive.TransactionStatus, error)
+	// Decline ends a pending charge as either denied or failed, as if the bank or the
+	// payment provider turned the payment down.
+	Decline(ctx context.Context, orderId string, transactionStatus primitive.TransactionStatus) error
+	// CreateCardToken tokenizes the card details entered by the customer, so that the card
+	// number never reaches the merchant's backend. The returned token can be used for
+	// a single charge.
+	CreateCardToken(ctx context.Context, request CardTokenRequest) (primitive.CardToken, error)
+	// RegisterCard saves the card details entered by the customer as a saved token, which
+	// can be used for charging the card over and over again.
+	RegisterCard(ctx context.Context, request CardTokenRequest) (primitive.CardToken, error)
+}
+
+type CardTokenRequest struct {
+	CardNumber      string
+	CardExpiryMonth string
+	CardExpiryYear  string
+	CardCVV         string
+	// SavedTokenId creates a new token from a saved token instead of the card details,
+	// which is how two-click payment works. Only the CVV is required along with it.
+	SavedTokenId string
 }
 
 type PaymentDetailsResponse struct {
@@ -25,4 +57,14 @@ type PaymentDetailsResponse struct {
 	PaymentMethod        primitive.PaymentType
 	VirtualAccountNumber string
 	EMoneyID             string
+	PaymentCode          string
+	PaylaterID           string
+	QRString             string
+	CreditCardID         string
+	MaskedCard           string
+	// RecipientName and FreeText are what the bank shows to the customer on the inquiry
+	// of a virtual account, if the merchant gave them on the charge.
+	RecipientName string
+	FreeText      primitive.FreeText
+	StatusHistory []primitive.TransactionStatusChange
 }
@@ -0,0 +1,188 @@
+package payment_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+// creditCardOTP is the only OTP that passes the 3-D Secure authentication, the same
+// one that Midtrans uses on their sandbox environment.
+const creditCardOTP = "112233"
+
+func (d *Dependency) AuthenticateCard(ctx context.Context, creditCardId string, otp string) (primitive.TransactionStatus, error) {
+	creditCardCharge, err := d.creditCardRepository.GetByID(ctx, creditCardId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return primitive.TransactionStatusUnspecified, business.ErrTransactionNotFound
+		}
+
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("acquiring credit card charge: %w", err)
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, creditCardCharge.OrderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return primitive.TransactionStatusUnspecified, business.ErrTransactionNotFound
+		}
+
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("acquiring transaction: %w", err)
+	}
+
+	// The card can only be authenticated once, and only before the transaction expires
+	if transaction.Expired() || transaction.TransactionStatus != primitive.TransactionStatusPending {
+		return primitive.TransactionStatusUnspecified, business.ErrCannotModifyStatus
+	}
+
+	// A correct OTP only means the customer passed 3-D Secure, the card outcome decides
+	// whether the bank and the fraud detection system let the charge through
+	transactionStatus := primitive.TransactionStatusDeny
+	if otp == creditCardOTP {
+		switch creditCardCharge.Outcome {
+		case primitive.CardOutcomeDenyByBank, primitive.CardOutcomeDenyByFraud:
+			transactionStatus = primitive.TransactionStatusDeny
+		default:
+			transactionStatus = primitive.TransactionStatusCapture
+			if creditCardCharge.TransactionType == primitive.CardTransactionTypeAuthorize {
+				transactionStatus = primitive.TransactionStatusAuthorize
+			}
+		}
+	}
+
+	err = d.transactionRepository.UpdateStatus(ctx, creditCardCharge.OrderId, transactionStatus, primitive.StatusChangeSourceCharge, primitive.StatusChangeActorCustomer)
+	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return primitive.TransactionStatusUnspecified, business.ErrCannotModifyStatus
+		}
+
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("updating transaction status: %w", err)
+	}
+
+	payload, err := d.buildCreditCardMessage(creditCardMessageParameters{
+		TransactionStatus: transactionStatus,
+		Authenticated:     otp == creditCardOTP,
+		OrderId:           transaction.OrderId,
+		TransactionTime:   transaction.TransactionTime,
+		GrossAmount:       transaction.TransactionAmount,
+		CreditCardCharge:  creditCardCharge,
+	})
+	if err != nil {
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("building credit card webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, transaction.OrderId, payload, time.Now())
+
+	return transactionStatus, nil
+}
+
+type creditCardMessageParameters struct {
+	TransactionStatus primitive.TransactionStatus
+	// Authenticated is true if the customer passed 3-D Secure
+	Authenticated    bool
+	OrderId          string
+	TransactionTime  time.Time
+	GrossAmount      int64
+	CreditCardCharge primitive.CreditCardCharge
+}
+
+func (d *Dependency) buildCreditCardMessage(parameters creditCardMessageParameters) ([]byte, error) {
+	switch parameters.TransactionStatus {
+	case primitive.TransactionStatusCapture:
+		var savedTokenIdExpiredAt string
+		if parameters.CreditCardCharge.SavedTokenId != "" {
+			savedTokenIdExpiredAt = parameters.CreditCardCharge.SavedTokenIdExpiresAt.Format(time.DateTime)
+		}
+
+		return json.Marshal(schema.CreditCardChargeCaptureResponse{
+			TransactionTime:        parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus:      parameters.TransactionStatus.String(),
+			TransactionId:          parameters.OrderId,
+			StatusMessage:          "midtrans payment notification",
+			StatusCode:             "200",
+			SignatureKey:           signature.Generate(parameters.OrderId, 200, parameters.GrossAmount, d.serverKey),
+			PaymentType:            primitive.PaymentTypeCreditCard.ToPaymentMethod(),
+			OrderId:                parameters.OrderId,
+			MerchantId:             "MOCK",
+			MaskedCard:             parameters.CreditCardCharge.MaskedCard,
+			GrossAmount:            strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:            parameters.CreditCardCharge.Outcome.FraudStatus().String(),
+			Eci:                    "05",
+			Currency:               "IDR",
+			ChannelResponseMessage: "Approved",
+			ChannelResponseCode:    "00",
+			CardType:               parameters.CreditCardCharge.CardType,
+			Bank:                   parameters.CreditCardCharge.Bank,
+			ApprovalCode:           parameters.CreditCardCharge.ApprovalCode,
+			SavedTokenId:           parameters.CreditCardCharge.SavedTokenId,
+			SavedTokenIdExpiredAt:  savedTokenIdExpiredAt,
+		})
+	case primitive.TransactionStatusAuthorize:
+		return json.Marshal(schema.CreditCardChargeAuthorizeResponse{
+			TransactionTime:        parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus:      parameters.TransactionStatus.String(),
+			TransactionId:          parameters.OrderId,
+			StatusMessage:          "midtrans payment notification",
+			StatusCode:             "200",
+			SignatureKey:           signature.Generate(parameters.OrderId, 200, parameters.GrossAmount, d.serverKey),
+			PaymentType:            primitive.PaymentTypeCreditCard.ToPaymentMethod(),
+			OrderId:                parameters.OrderId,
+			MerchantId:             "MOCK",
+			MaskedCard:             parameters.CreditCardCharge.MaskedCard,
+			GrossAmount:            strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:            parameters.CreditCardCharge.Outcome.FraudStatus().String(),
+			Eci:                    "05",
+			Currency:               "IDR",
+			ChannelResponseMessage: "Approved",
+			ChannelResponseCode:    "00",
+			CardType:               parameters.CreditCardCharge.CardType,
+			Bank:                   parameters.CreditCardCharge.Bank,
+			ApprovalCode:           parameters.CreditCardCharge.ApprovalCode,
+		})
+	case primitive.TransactionStatusDeny:
+		// Failing 3-D Secure denies the charge before it reaches the bank
+		fraudStatus := primitive.FraudStatusAccept
+		eci := "07"
+		channelResponseMessage := "3D Secure authentication failed"
+		if parameters.Authenticated {
+			fraudStatus = parameters.CreditCardCharge.Outcome.FraudStatus()
+			eci = "05"
+			channelResponseMessage = "Do not honour"
+			if fraudStatus == primitive.FraudStatusDeny {
+				channelResponseMessage = "Denied by fraud detection system"
+			}
+		}
+
+		return json.Marshal(schema.CreditCardChargeDenyResponse{
+			TransactionTime:        parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus:      parameters.TransactionStatus.String(),
+			TransactionId:          parameters.OrderId,
+			StatusMessage:          "midtrans payment notification",
+			StatusCode:             "202",
+			SignatureKey:           signature.Generate(parameters.OrderId, 202, parameters.GrossAmount, d.serverKey),
+			PaymentType:            primitive.PaymentTypeCreditCard.ToPaymentMethod(),
+			OrderId:                parameters.OrderId,
+			MerchantId:             "MOCK",
+			MaskedCard:             parameters.CreditCardCharge.MaskedCard,
+			GrossAmount:            strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:            fraudStatus.String(),
+			Eci:                    eci,
+			Currency:               "IDR",
+			ChannelResponseMessage: channelResponseMessage,
+			ChannelResponseCode:    "05",
+			CardType:               parameters.CreditCardCharge.CardType,
+			Bank:                   parameters.CreditCardCharge.Bank,
+		})
+	default:
+		return nil, fmt.Errorf("invalid transaction status")
+	}
+}
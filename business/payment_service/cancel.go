@@ -0,0 +1,142 @@
+package payment_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+func (d *Dependency) Cancel(ctx context.Context, eMoneyId string) error {
+	if eMoneyId == "" {
+		return fmt.Errorf("empty e-money id")
+	}
+
+	entry, err := d.eMoneyRepository.GetByID(ctx, eMoneyId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrExpired) {
+			return business.ErrTransactionNotFound
+		}
+
+		return fmt.Errorf("acquiring e-money entry: %w", err)
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, entry.OrderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return business.ErrTransactionNotFound
+		}
+
+		return fmt.Errorf("acquiring transaction: %w", err)
+	}
+
+	// The ID might belong to a paylater charge, which the customer rejects instead
+	if transaction.PaymentType.Paylater() {
+		return business.ErrTransactionNotFound
+	}
+
+	// Customer can only cancel a payment that is still waiting to be paid
+	if transaction.Expired() || transaction.TransactionStatus != primitive.TransactionStatusPending {
+		return business.ErrCannotModifyStatus
+	}
+
+	err = d.transactionRepository.UpdateStatus(ctx, entry.OrderId, primitive.TransactionStatusCancel, primitive.StatusChangeSourceCancel, primitive.StatusChangeActorCustomer)
+	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return business.ErrCannotModifyStatus
+		}
+
+		return fmt.Errorf("updating transaction status: %w", err)
+	}
+
+	// The charge is only released once the transaction is canceled, otherwise a payment
+	// that settled in the meantime would be left with a released charge
+	err = d.eMoneyRepository.CancelCharge(ctx, entry.OrderId)
+	if err != nil {
+		return fmt.Errorf("canceling e-money charge: %w", err)
+	}
+
+	payload, err := d.buildCanceledMessage(canceledMessageParameters{
+		PaymentType:     transaction.PaymentType,
+		OrderId:         transaction.OrderId,
+		TransactionTime: transaction.TransactionTime,
+		GrossAmount:     transaction.TransactionAmount,
+	})
+	if err != nil {
+		return fmt.Errorf("building canceled webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, transaction.OrderId, payload, time.Now())
+
+	return nil
+}
+
+type canceledMessageParameters struct {
+	PaymentType     primitive.PaymentType
+	OrderId         string
+	TransactionTime time.Time
+	GrossAmount     int64
+}
+
+// buildCanceledMessage builds the notification of an e-money charge that the customer
+// canceled, which is the same as the merchant canceling it.
+func (d *Dependency) buildCanceledMessage(parameters canceledMessageParameters) ([]byte, error) {
+	signatureKey := signature.Generate(parameters.OrderId, 200, parameters.GrossAmount, d.serverKey)
+
+	switch parameters.PaymentType {
+	case primitive.PaymentTypeEMoneyQRIS:
+		return json.Marshal(schema.QRISChargeCanceledResponse{
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			TransactionId:     parameters.OrderId,
+			StatusMessage:     "midtrans payment notification",
+			StatusCode:        "200",
+			SignatureKey:      signatureKey,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:       "accept",
+			Currency:          "IDR",
+			Acquirer:          "nobu",
+		})
+	case primitive.PaymentTypeEMoneyGopay:
+		return json.Marshal(schema.GopayChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeEMoneyShopeePay:
+		return json.Marshal(schema.ShopeePayChargeCanceledResponse{
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			TransactionId:     parameters.OrderId,
+			StatusMessage:     "midtrans payment notification",
+			StatusCode:        "200",
+			SignatureKey:      signatureKey,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:       "accept",
+			Currency:          "IDR",
+		})
+	default:
+		return nil, fmt.Errorf("invalid payment type")
+	}
+}
@@ -0,0 +1,99 @@
+package payment_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+func (d *Dependency) CompletePaylater(ctx context.Context, paylaterId string, approved bool) (primitive.TransactionStatus, error) {
+	if paylaterId == "" {
+		return primitive.TransactionStatusUnspecified, business.ErrTransactionNotFound
+	}
+
+	entry, err := d.eMoneyRepository.GetByID(ctx, paylaterId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrExpired) {
+			return primitive.TransactionStatusUnspecified, business.ErrTransactionNotFound
+		}
+
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("acquiring paylater entry: %w", err)
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, entry.OrderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return primitive.TransactionStatusUnspecified, business.ErrTransactionNotFound
+		}
+
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("acquiring transaction: %w", err)
+	}
+
+	// The ID might belong to an e-money charge
+	if !transaction.PaymentType.Paylater() {
+		return primitive.TransactionStatusUnspecified, business.ErrTransactionNotFound
+	}
+
+	if approved {
+		err = d.MarkAsPaid(ctx, transaction.OrderId, transaction.PaymentType)
+		if err != nil {
+			return primitive.TransactionStatusUnspecified, err
+		}
+
+		return primitive.TransactionStatusSettlement, nil
+	}
+
+	// The customer can only be rejected while the charge is still waiting for approval
+	if transaction.Expired() || transaction.TransactionStatus != primitive.TransactionStatusPending {
+		return primitive.TransactionStatusUnspecified, business.ErrCannotModifyStatus
+	}
+
+	// The customer rejecting the charge on the hosted page is recorded like the customer
+	// canceling an e-money charge
+	err = d.transactionRepository.UpdateStatus(ctx, transaction.OrderId, primitive.TransactionStatusDeny, primitive.StatusChangeSourceCancel, primitive.StatusChangeActorCustomer)
+	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return primitive.TransactionStatusUnspecified, business.ErrCannotModifyStatus
+		}
+
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("updating transaction status: %w", err)
+	}
+
+	// A rejected charge can't be approved later on
+	err = d.eMoneyRepository.CancelCharge(ctx, transaction.OrderId)
+	if err != nil {
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("canceling paylater charge: %w", err)
+	}
+
+	payload, err := json.Marshal(schema.PaylaterChargeDenyResponse{
+		StatusCode:        "202",
+		StatusMessage:     "midtrans payment notification",
+		TransactionId:     transaction.OrderId,
+		OrderId:           transaction.OrderId,
+		MerchantId:        "MOCK",
+		GrossAmount:       strconv.FormatInt(transaction.TransactionAmount, 10),
+		Currency:          "IDR",
+		PaymentType:       transaction.PaymentType.ToPaymentMethod(),
+		TransactionTime:   transaction.TransactionTime.Format(time.DateTime),
+		TransactionStatus: primitive.TransactionStatusDeny.String(),
+		FraudStatus:       "accept",
+		SignatureKey:      signature.Generate(transaction.OrderId, 202, transaction.TransactionAmount, d.serverKey),
+	})
+	if err != nil {
+		return primitive.TransactionStatusUnspecified, fmt.Errorf("building deny webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, transaction.OrderId, payload, time.Now())
+
+	return primitive.TransactionStatusDeny, nil
+}
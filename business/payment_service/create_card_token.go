@@ -0,0 +1,177 @@
+package payment_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+// cardTokenDuration is how long a regular card token can be used for charging.
+const cardTokenDuration = time.Minute * 10
+
+func (d *Dependency) CreateCardToken(ctx context.Context, request business.CardTokenRequest) (primitive.CardToken, error) {
+	// Two-click payment, the card details are acquired from the saved token
+	if request.SavedTokenId != "" {
+		if err := validateCardCVV(request.CardCVV, true); err != nil {
+			return primitive.CardToken{}, err
+		}
+
+		savedToken, err := d.cardTokenRepository.GetByTokenId(ctx, request.SavedTokenId)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrExpired) {
+				return primitive.CardToken{}, business.ErrInvalidCardToken
+			}
+
+			return primitive.CardToken{}, fmt.Errorf("acquiring saved card token: %w", err)
+		}
+
+		if !savedToken.Saved {
+			return primitive.CardToken{}, business.ErrInvalidCardToken
+		}
+
+		cardToken, err := d.cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    savedToken.MaskedCard,
+			Outcome:       savedToken.Outcome,
+			Saved:         false,
+			CardExpiresAt: savedToken.CardExpiresAt,
+			ExpiresAt:     time.Now().Add(cardTokenDuration),
+		})
+		if err != nil {
+			return primitive.CardToken{}, fmt.Errorf("creating card token: %w", err)
+		}
+
+		return cardToken, nil
+	}
+
+	cardExpiresAt, err := validateCardTokenRequest(request, true)
+	if err != nil {
+		return primitive.CardToken{}, err
+	}
+
+	cardToken, err := d.cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+		MaskedCard:    primitive.MaskCardNumber(request.CardNumber),
+		Outcome:       primitive.SandboxCardOutcome(request.CardNumber),
+		Saved:         false,
+		CardExpiresAt: cardExpiresAt,
+		ExpiresAt:     time.Now().Add(cardTokenDuration),
+	})
+	if err != nil {
+		return primitive.CardToken{}, fmt.Errorf("creating card token: %w", err)
+	}
+
+	return cardToken, nil
+}
+
+// validateCardTokenRequest validates the card details, and returns the end of the
+// card's expiry month.
+func validateCardTokenRequest(request business.CardTokenRequest, cvvRequired bool) (time.Time, error) {
+	var issues []business.RequestValidationIssue
+
+	if request.CardNumber == "" {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeRequired,
+			Field:   "card_number",
+			Message: "must not be empty",
+		})
+	} else if !primitive.ValidCardNumber(request.CardNumber) {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "card_number",
+			Message: "must be a valid card number",
+		})
+	}
+
+	expiryMonth, err := strconv.Atoi(request.CardExpiryMonth)
+	if err != nil || expiryMonth < 1 || expiryMonth > 12 {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "card_exp_month",
+			Message: "must be between 01 and 12",
+		})
+	}
+
+	expiryYear, err := strconv.Atoi(request.CardExpiryYear)
+	if err != nil || expiryYear < 0 {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "card_exp_year",
+			Message: "must be a valid year",
+		})
+	}
+
+	// Two digit years are within the current century
+	if expiryYear < 100 {
+		expiryYear += 2000
+	}
+
+	cardExpiresAt := time.Date(expiryYear, time.Month(expiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	if len(issues) == 0 && cardExpiresAt.Before(time.Now()) {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "card_exp_year",
+			Message: "card has expired",
+		})
+	}
+
+	if err := validateCardCVV(request.CardCVV, cvvRequired); err != nil {
+		issues = append(issues, err.Issues...)
+	}
+
+	if len(issues) > 0 {
+		return time.Time{}, &business.RequestValidationError{Issues: issues}
+	}
+
+	return cardExpiresAt, nil
+}
+
+func validateCardCVV(cvv string, required bool) *business.RequestValidationError {
+	if cvv == "" {
+		if !required {
+			return nil
+		}
+
+		return &business.RequestValidationError{
+			Issues: []business.RequestValidationIssue{
+				{
+					Code:    business.RequestValidationCodeRequired,
+					Field:   "card_cvv",
+					Message: "must not be empty",
+				},
+			},
+		}
+	}
+
+	if len(cvv) < 3 || len(cvv) > 4 {
+		return &business.RequestValidationError{
+			Issues: []business.RequestValidationIssue{
+				{
+					Code:    business.RequestValidationCodeInvalidValue,
+					Field:   "card_cvv",
+					Message: "must be 3 or 4 digits",
+				},
+			},
+		}
+	}
+
+	for _, c := range cvv {
+		if c < '0' || c > '9' {
+			return &business.RequestValidationError{
+				Issues: []business.RequestValidationIssue{
+					{
+						Code:    business.RequestValidationCodeInvalidValue,
+						Field:   "card_cvv",
+						Message: "must be numeric",
+					},
+				},
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package payment_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+func (d *Dependency) Decline(ctx context.Context, orderId string, transactionStatus primitive.TransactionStatus) error {
+	if transactionStatus != primitive.TransactionStatusDeny && transactionStatus != primitive.TransactionStatusFailure {
+		return fmt.Errorf("invalid transaction status %s", transactionStatus)
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return business.ErrTransactionNotFound
+		}
+
+		return fmt.Errorf("acquiring transaction: %w", err)
+	}
+
+	// Only a charge that is still waiting for the customer can be declined
+	if transaction.Expired() || transaction.TransactionStatus != primitive.TransactionStatusPending {
+		return business.ErrCannotModifyStatus
+	}
+
+	err = d.transactionRepository.UpdateStatus(ctx, orderId, transactionStatus, primitive.StatusChangeSourceCharge, primitive.StatusChangeActorSystem)
+	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return business.ErrCannotModifyStatus
+		}
+
+		return fmt.Errorf("updating transaction status: %w", err)
+	}
+
+	// A declined charge can't be paid later on
+	switch transaction.PaymentType {
+	case primitive.PaymentTypeVirtualAccountBCA:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBNI:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBRI:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountPermata:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBSI:
+		fallthrough
+	case primitive.PaymentTypeMandiriBill:
+		err = d.virtualAccountRepository.ReleaseCharge(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("releasing virtual account charge: %w", err)
+		}
+	case primitive.PaymentTypeEMoneyQRIS:
+		fallthrough
+	case primitive.PaymentTypeEMoneyGopay:
+		fallthrough
+	case primitive.PaymentTypeEMoneyShopeePay:
+		fallthrough
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		err = d.eMoneyRepository.CancelCharge(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("canceling e-money charge: %w", err)
+		}
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		err = d.cstoreRepository.CancelCharge(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("canceling cstore charge: %w", err)
+		}
+	}
+
+	payload, err := json.Marshal(schema.ChargeDenyResponse{
+		StatusCode:        "202",
+		StatusMessage:     "midtrans payment notification",
+		TransactionId:     orderId,
+		OrderId:           orderId,
+		MerchantId:        "MOCK",
+		GrossAmount:       strconv.FormatInt(transaction.TransactionAmount, 10),
+		Currency:          "IDR",
+		PaymentType:       transaction.PaymentType.ToPaymentMethod(),
+		TransactionTime:   transaction.TransactionTime.Format(time.DateTime),
+		TransactionStatus: transactionStatus.String(),
+		FraudStatus:       "accept",
+		SignatureKey:      signature.Generate(orderId, 202, transaction.TransactionAmount, d.serverKey),
+	})
+	if err != nil {
+		return fmt.Errorf("building %s webhook message: %w", transactionStatus, err)
+	}
+
+	d.notifier.Send(ctx, orderId, payload, time.Now())
+
+	return nil
+}
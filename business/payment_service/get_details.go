@@ -4,14 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
 	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/qris"
 )
 
 func (d *Dependency) GetDetail(ctx context.Context, id string) (business.PaymentDetailsResponse, error) {
+	// A scanned QR code gives us the QRIS payload instead of the ID. Resolve the e-money ID
+	// from it, and make sure that the amount on the payload is the one that we charged.
+	var qrAmount int64
+	if strings.HasPrefix(id, "000201") {
+		payload, err := qris.Parse(id)
+		if err != nil {
+			return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+		}
+
+		id = payload.EMoneyID
+		qrAmount = payload.Amount
+	}
+
 	// Set up an entry
 	var entry repository.Entry
+	var creditCardCharge primitive.CreditCardCharge
 	var err error = nil
 
 	// Try virtual account
@@ -24,14 +41,40 @@ func (d *Dependency) GetDetail(ctx context.Context, id string) (business.Payment
 		// Try e-money
 		entry, err = d.eMoneyRepository.GetByID(ctx, id)
 		if err != nil {
-			if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrExpired) {
-				return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+			if !errors.Is(err, repository.ErrNotFound) && !errors.Is(err, repository.ErrExpired) {
+				return business.PaymentDetailsResponse{}, fmt.Errorf("acquiring from emoney store: %w", err)
 			}
 
-			return business.PaymentDetailsResponse{}, fmt.Errorf("acquiring from emoney store: %w", err)
+			// Try convenience store payment code
+			entry, err = d.cstoreRepository.GetByPaymentCode(ctx, id)
+			if err != nil {
+				if !errors.Is(err, repository.ErrNotFound) {
+					return business.PaymentDetailsResponse{}, fmt.Errorf("acquiring from cstore store: %w", err)
+				}
+
+				// Try credit card
+				creditCardCharge, err = d.creditCardRepository.GetByID(ctx, id)
+				if err != nil {
+					if errors.Is(err, repository.ErrNotFound) {
+						return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+					}
+
+					return business.PaymentDetailsResponse{}, fmt.Errorf("acquiring from credit card store: %w", err)
+				}
+
+				entry = repository.Entry{
+					OrderId:       creditCardCharge.OrderId,
+					ChargedAmount: creditCardCharge.Amount,
+					ExpiresAt:     creditCardCharge.ExpiresAt,
+				}
+			}
 		}
 	}
 
+	if qrAmount != 0 && qrAmount != entry.ChargedAmount {
+		return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+	}
+
 	// Acquire more data from transaction repository
 	transaction, err := d.transactionRepository.GetByOrderId(ctx, entry.OrderId)
 	if err != nil {
@@ -42,6 +85,34 @@ func (d *Dependency) GetDetail(ctx context.Context, id string) (business.Payment
 		return business.PaymentDetailsResponse{}, fmt.Errorf("acquiring additional transaction information: %w", err)
 	}
 
+	var qrString string
+	if transaction.PaymentType == primitive.PaymentTypeEMoneyQRIS || transaction.PaymentType == primitive.PaymentTypeEMoneyGopay {
+		qrString, err = qris.Generate(qris.Payload{EMoneyID: entry.EMoneyID, Amount: entry.ChargedAmount})
+		if err != nil {
+			return business.PaymentDetailsResponse{}, fmt.Errorf("generating qris payload: %w", err)
+		}
+	}
+
+	// Paylater charges share the e-money entry, but they can't be paid as e-money
+	var paylaterId string
+	if transaction.PaymentType.Paylater() {
+		paylaterId = entry.EMoneyID
+		entry.EMoneyID = ""
+	}
+
+	var virtualAccountDetail primitive.VirtualAccountDetail
+	if transaction.PaymentType.ToBank() != "" {
+		virtualAccountDetail, err = d.virtualAccountRepository.GetDetail(ctx, entry.OrderId)
+		if err != nil {
+			return business.PaymentDetailsResponse{}, fmt.Errorf("acquiring virtual account detail: %w", err)
+		}
+	}
+
+	statusHistory, err := d.transactionRepository.GetStatusHistory(ctx, entry.OrderId)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return business.PaymentDetailsResponse{}, fmt.Errorf("acquiring status history: %w", err)
+	}
+
 	return business.PaymentDetailsResponse{
 		OrderId:              entry.OrderId,
 		ChargedAmount:        transaction.TransactionAmount,
@@ -49,5 +120,13 @@ func (d *Dependency) GetDetail(ctx context.Context, id string) (business.Payment
 		PaymentMethod:        transaction.PaymentType,
 		VirtualAccountNumber: entry.VirtualAccountNumber,
 		EMoneyID:             entry.EMoneyID,
+		PaymentCode:          entry.PaymentCode,
+		PaylaterID:           paylaterId,
+		QRString:             qrString,
+		CreditCardID:         creditCardCharge.Id,
+		MaskedCard:           creditCardCharge.MaskedCard,
+		RecipientName:        virtualAccountDetail.RecipientName,
+		FreeText:             virtualAccountDetail.FreeText,
+		StatusHistory:        statusHistory,
 	}, nil
 }
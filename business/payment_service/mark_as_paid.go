@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/rs/zerolog"
 	"mock-payment-provider/business"
 	"mock-payment-provider/presentation/schema"
 	"mock-payment-provider/primitive"
@@ -32,23 +31,48 @@ func (d *Dependency) MarkAsPaid(ctx context.Context, orderId string, paymentMeth
 		return business.ErrCannotModifyStatus
 	}
 
-	// Check previous transaction status. We can only change it to settled only
-	// if the previous status is pending.
+	// Check previous transaction status. The customer can only pay for a charge
+	// that is still pending.
 	if transaction.TransactionStatus != primitive.TransactionStatusPending {
 		return business.ErrCannotModifyStatus
 	}
 
+	if paymentMethod == primitive.PaymentTypeUnspecified {
+		paymentMethod = transaction.PaymentType
+	}
+
+	// The charge can only be paid with the payment type it was created with, and must be
+	// checked before it is settled, there is no going back afterwards
+	if paymentMethod != transaction.PaymentType {
+		return &business.RequestValidationError{
+			Issues: []business.RequestValidationIssue{
+				{
+					Code:    business.RequestValidationCodeInvalidValue,
+					Field:   "payment_method",
+					Message: fmt.Sprintf("must be %s, the payment type of the transaction", transaction.PaymentType),
+				},
+			},
+		}
+	}
+
+	// Cards are paid through 3-D Secure instead
+	if paymentMethod == primitive.PaymentTypeCreditCard {
+		return business.ErrCannotModifyStatus
+	}
+
 	// Mark as settled
-	err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusSettled)
+	err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusSettlement, primitive.StatusChangeSourceInternalMarkAsPaid, primitive.StatusChangeActorCustomer)
 	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return business.ErrCannotModifyStatus
+		}
+
 		return fmt.Errorf("updating transaction status: %w", err)
 	}
 
 	var virtualAccountNumber = ""
-
-	if paymentMethod == primitive.PaymentTypeUnspecified {
-		paymentMethod = transaction.PaymentType
-	}
+	var paymentCode = ""
 
 	switch paymentMethod {
 	case primitive.PaymentTypeVirtualAccountBCA:
@@ -58,6 +82,12 @@ func (d *Dependency) MarkAsPaid(ctx context.Context, orderId string, paymentMeth
 	case primitive.PaymentTypeVirtualAccountBRI:
 		fallthrough
 	case primitive.PaymentTypeVirtualAccountPermata:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBSI:
+		fallthrough
+	case primitive.PaymentTypeMandiriBill:
 		// Acquire virtual account number
 		virtualAccountEntry, err := d.virtualAccountRepository.GetByOrderId(ctx, orderId)
 		if err != nil {
@@ -75,39 +105,45 @@ func (d *Dependency) MarkAsPaid(ctx context.Context, orderId string, paymentMeth
 	case primitive.PaymentTypeEMoneyGopay:
 		fallthrough
 	case primitive.PaymentTypeEMoneyShopeePay:
+		fallthrough
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
 		err := d.eMoneyRepository.DeductCharge(ctx, orderId)
 		if err != nil {
 			return fmt.Errorf("deducting emoney charge: %w", err)
 		}
-	default:
-		return fmt.Errorf("invalid payment type")
-	}
-
-	go func() {
-		log := zerolog.Ctx(ctx)
-
-		payload, err := d.buildSettlementMessage(settlementMessageParameters{
-			PaymentType:          paymentMethod,
-			OrderId:              orderId,
-			TransactionTime:      transaction.TransactionTime,
-			GrossAmount:          transaction.TransactionAmount,
-			VirtualAccountNumber: virtualAccountNumber,
-		})
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		cstoreEntry, err := d.cstoreRepository.GetByOrderId(ctx, orderId)
 		if err != nil {
-			log.Err(err).Msg("Encountered an error during marshaling json")
-			return
+			return fmt.Errorf("acquiring cstore entry from order id: %w", err)
 		}
 
-		ctx := context.Background()
+		paymentCode = cstoreEntry.PaymentCode
 
-		err = d.webhookClient.Send(ctx, payload)
+		err = d.cstoreRepository.DeductCharge(ctx, orderId)
 		if err != nil {
-			log.Err(err).Msg("Encountered an error during sending webhook")
-			return
+			return fmt.Errorf("deducting cstore charge: %w", err)
 		}
+	default:
+		return fmt.Errorf("invalid payment type")
+	}
 
-		log.Info().Bytes("payload", payload).Msg("Sent a webhook")
-	}()
+	payload, err := d.buildSettlementMessage(settlementMessageParameters{
+		PaymentType:          paymentMethod,
+		OrderId:              orderId,
+		TransactionTime:      transaction.TransactionTime,
+		GrossAmount:          transaction.TransactionAmount,
+		VirtualAccountNumber: virtualAccountNumber,
+		PaymentCode:          paymentCode,
+	})
+	if err != nil {
+		return fmt.Errorf("building settlement webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, orderId, payload, time.Now())
 
 	return nil
 }
@@ -118,6 +154,7 @@ type settlementMessageParameters struct {
 	TransactionTime      time.Time
 	GrossAmount          int64
 	VirtualAccountNumber string
+	PaymentCode          string
 }
 
 func (d *Dependency) buildSettlementMessage(parameters settlementMessageParameters) ([]byte, error) {
@@ -142,7 +179,7 @@ func (d *Dependency) buildSettlementMessage(parameters settlementMessageParamete
 			SignatureKey:      signatureKey,
 			StatusCode:        "200",
 			TransactionId:     parameters.OrderId,
-			TransactionStatus: primitive.TransactionStatusSettled.String(),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
 			FraudStatus:       "accept",
 			StatusMessage:     "midtrans payment notification",
 		})
@@ -164,7 +201,7 @@ func (d *Dependency) buildSettlementMessage(parameters settlementMessageParamete
 			SignatureKey:      signatureKey,
 			StatusCode:        "200",
 			TransactionId:     parameters.OrderId,
-			TransactionStatus: primitive.TransactionStatusSettled.String(),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
 			FraudStatus:       "accept",
 			StatusMessage:     "midtrans payment notification",
 		})
@@ -195,10 +232,110 @@ func (d *Dependency) buildSettlementMessage(parameters settlementMessageParamete
 			SignatureKey:      signatureKey,
 			StatusCode:        "200",
 			TransactionId:     parameters.OrderId,
-			TransactionStatus: primitive.TransactionStatusSettled.String(),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
 			FraudStatus:       "accept",
 			StatusMessage:     "midtrans payment notification",
 		})
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		return json.Marshal(schema.CIMBVirtualAccountChargeSettlementResponse{
+			VaNumbers: []struct {
+				Bank     string `json:"bank"`
+				VaNumber string `json:"va_number"`
+			}{
+				{
+					Bank:     "cimb",
+					VaNumber: parameters.VirtualAccountNumber,
+				},
+			},
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			OrderId:           parameters.OrderId,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			SignatureKey:      signatureKey,
+			StatusCode:        "200",
+			TransactionId:     parameters.OrderId,
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
+			FraudStatus:       "accept",
+			StatusMessage:     "midtrans payment notification",
+		})
+	case primitive.PaymentTypeVirtualAccountBSI:
+		return json.Marshal(schema.BSIVirtualAccountChargeSettlementResponse{
+			VaNumbers: []struct {
+				Bank     string `json:"bank"`
+				VaNumber string `json:"va_number"`
+			}{
+				{
+					Bank:     "bsi",
+					VaNumber: parameters.VirtualAccountNumber,
+				},
+			},
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			OrderId:           parameters.OrderId,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			SignatureKey:      signatureKey,
+			StatusCode:        "200",
+			TransactionId:     parameters.OrderId,
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
+			FraudStatus:       "accept",
+			StatusMessage:     "midtrans payment notification",
+		})
+	case primitive.PaymentTypeMandiriBill:
+		return json.Marshal(schema.MandiriBillChargeSettlementResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
+			SettlementTime:    time.Now().Format(time.DateTime),
+			FraudStatus:       "accept",
+			BillKey:           parameters.VirtualAccountNumber,
+			BillerCode:        primitive.MandiriBillerCode,
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		return json.Marshal(schema.CStoreChargeSettlementResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
+			SettlementTime:    time.Now().Format(time.DateTime),
+			FraudStatus:       "accept",
+			PaymentCode:       parameters.PaymentCode,
+			Store:             parameters.PaymentType.ToStore(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		return json.Marshal(schema.PaylaterChargeSettlementResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
+			SettlementTime:    time.Now().Format(time.DateTime),
+			FraudStatus:       "accept",
+			SignatureKey:      signatureKey,
+		})
 	case primitive.PaymentTypeVirtualAccountPermata:
 		return json.Marshal(schema.PermataVirtualAccountChargeSettlementResponse{
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
@@ -208,7 +345,7 @@ func (d *Dependency) buildSettlementMessage(parameters settlementMessageParamete
 			SignatureKey:      signatureKey,
 			StatusCode:        "200",
 			TransactionId:     parameters.OrderId,
-			TransactionStatus: primitive.TransactionStatusSettled.String(),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
 			FraudStatus:       "accept",
 			StatusMessage:     "midtrans payment notification",
 			PermataVaNumber:   parameters.VirtualAccountNumber,
@@ -217,7 +354,7 @@ func (d *Dependency) buildSettlementMessage(parameters settlementMessageParamete
 		return json.Marshal(schema.QRISChargeSettlementResponse{
 			TransactionType:          "on-us",
 			TransactionTime:          parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus:        primitive.TransactionStatusSettled.String(),
+			TransactionStatus:        primitive.TransactionStatusSettlement.String(),
 			TransactionId:            parameters.OrderId,
 			StatusMessage:            "midtrans payment notification",
 			StatusCode:               "200",
@@ -243,13 +380,13 @@ func (d *Dependency) buildSettlementMessage(parameters settlementMessageParamete
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
 			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusSettled.String(),
+			TransactionStatus: primitive.TransactionStatusSettlement.String(),
 			SignatureKey:      signatureKey,
 		})
 	case primitive.PaymentTypeEMoneyShopeePay:
 		return json.Marshal(schema.ShopeePayChargeSettlementResponse{
 			TransactionTime:          parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus:        primitive.TransactionStatusSettled.String(),
+			TransactionStatus:        primitive.TransactionStatusSettlement.String(),
 			TransactionId:            parameters.OrderId,
 			StatusMessage:            "midtrans payment notification",
 			StatusCode:               "200",
@@ -3,23 +3,33 @@ package payment_service
 import (
 	"fmt"
 
+	"mock-payment-provider/business"
+	"mock-payment-provider/business/webhook_service"
 	"mock-payment-provider/repository"
 )
 
 type Config struct {
 	ServerKey                string
 	TransactionRepository    repository.TransactionRepository
-	WebhookClient            repository.WebhookClient
+	WebhookOutboxRepository  repository.WebhookOutboxRepository
 	EMoneyRepository         repository.EMoneyRepository
 	VirtualAccountRepository repository.VirtualAccountRepository
+	CStoreRepository         repository.CStoreRepository
+	CreditCardRepository     repository.CreditCardRepository
+	CardTokenRepository      repository.CardTokenRepository
+	// RuleService can force webhooks to be dropped.
+	RuleService business.Rule
 }
 
 type Dependency struct {
 	serverKey                string
 	transactionRepository    repository.TransactionRepository
-	webhookClient            repository.WebhookClient
 	eMoneyRepository         repository.EMoneyRepository
 	virtualAccountRepository repository.VirtualAccountRepository
+	cstoreRepository         repository.CStoreRepository
+	creditCardRepository     repository.CreditCardRepository
+	cardTokenRepository      repository.CardTokenRepository
+	notifier                 *webhook_service.Notifier
 }
 
 func NewPaymentService(config Config) (*Dependency, error) {
@@ -27,8 +37,8 @@ func NewPaymentService(config Config) (*Dependency, error) {
 		return nil, fmt.Errorf("nil transaction repository")
 	}
 
-	if config.WebhookClient == nil {
-		return nil, fmt.Errorf("nil webhook client")
+	if config.WebhookOutboxRepository == nil {
+		return nil, fmt.Errorf("nil webhook outbox repository")
 	}
 
 	if config.EMoneyRepository == nil {
@@ -39,11 +49,39 @@ func NewPaymentService(config Config) (*Dependency, error) {
 		return nil, fmt.Errorf("nil virtual account repository")
 	}
 
+	if config.CStoreRepository == nil {
+		return nil, fmt.Errorf("nil cstore repository")
+	}
+
+	if config.CreditCardRepository == nil {
+		return nil, fmt.Errorf("nil credit card repository")
+	}
+
+	if config.CardTokenRepository == nil {
+		return nil, fmt.Errorf("nil card token repository")
+	}
+
+	if config.RuleService == nil {
+		return nil, fmt.Errorf("nil rule service")
+	}
+
+	notifier, err := webhook_service.NewNotifier(webhook_service.NotifierConfig{
+		TransactionRepository:   config.TransactionRepository,
+		WebhookOutboxRepository: config.WebhookOutboxRepository,
+		RuleService:             config.RuleService,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating notifier: %w", err)
+	}
+
 	return &Dependency{
 		serverKey:                config.ServerKey,
 		transactionRepository:    config.TransactionRepository,
-		webhookClient:            config.WebhookClient,
 		eMoneyRepository:         config.EMoneyRepository,
 		virtualAccountRepository: config.VirtualAccountRepository,
+		cstoreRepository:         config.CStoreRepository,
+		creditCardRepository:     config.CreditCardRepository,
+		cardTokenRepository:      config.CardTokenRepository,
+		notifier:                 notifier,
 	}, nil
 }
@@ -0,0 +1,31 @@
+package payment_service
+
+import (
+	"context"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) RegisterCard(ctx context.Context, request business.CardTokenRequest) (primitive.CardToken, error) {
+	cardExpiresAt, err := validateCardTokenRequest(request, false)
+	if err != nil {
+		return primitive.CardToken{}, err
+	}
+
+	// A saved token lives as long as the card itself
+	cardToken, err := d.cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+		MaskedCard:    primitive.MaskCardNumber(request.CardNumber),
+		Outcome:       primitive.SandboxCardOutcome(request.CardNumber),
+		Saved:         true,
+		CardExpiresAt: cardExpiresAt,
+		ExpiresAt:     cardExpiresAt,
+	})
+	if err != nil {
+		return primitive.CardToken{}, fmt.Errorf("creating saved card token: %w", err)
+	}
+
+	return cardToken, nil
+}
@@ -0,0 +1,35 @@
+// Package poller runs the background scans of the services that drive transactions
+// forward on their own, such as expiring overdue transactions.
+package poller
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// scanTimeout bounds a single scan, as it no longer follows the ctx of Run.
+const scanTimeout = time.Minute
+
+// Run calls scan right away, then once every interval until ctx is canceled. A scan is
+// never cut short by ctx, otherwise a transaction could be moved on without its charge
+// being released or its webhook being stored. The scan gets the logger of ctx instead.
+func Run(ctx context.Context, interval time.Duration, scan func(ctx context.Context)) {
+	log := zerolog.Ctx(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		scanCtx, cancel := context.WithTimeout(log.WithContext(context.Background()), scanTimeout)
+		scan(scanCtx)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
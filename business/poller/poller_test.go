@@ -0,0 +1,48 @@
+package poller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/business/poller"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("Scans Until Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		scans := 0
+		poller.Run(ctx, time.Millisecond, func(ctx context.Context) {
+			scans++
+			if scans == 3 {
+				cancel()
+			}
+		})
+
+		if scans != 3 {
+			t.Errorf("expecting 3 scans, instead got %d", scans)
+		}
+	})
+
+	t.Run("Scan Outlives Canceled Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		scans := 0
+		poller.Run(ctx, time.Hour, func(scanCtx context.Context) {
+			scans++
+			if scanCtx.Err() != nil {
+				t.Errorf("expecting the scan context to be alive, instead got %s", scanCtx.Err().Error())
+			}
+
+			if _, ok := scanCtx.Deadline(); !ok {
+				t.Errorf("expecting the scan context to have a deadline")
+			}
+		})
+
+		if scans != 1 {
+			t.Errorf("expecting 1 scan, instead got %d", scans)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package business
+
+import (
+	"context"
+
+	"mock-payment-provider/primitive"
+)
+
+// Rule interface manages the rules that make this service misbehave on demand, and applies
+// them to the requests, charges and notifications that they match.
+type Rule interface {
+	// List returns every rule, from the oldest one.
+	List(ctx context.Context) ([]primitive.Rule, error)
+	// Create validates and stores a new rule. It returns RequestValidationError if the rule
+	// is invalid.
+	Create(ctx context.Context, rule primitive.Rule) (primitive.Rule, error)
+	// Replace validates the rules, then removes every rule and stores the given ones instead.
+	// It returns RequestValidationError if any of them is invalid, leaving the rules as they were.
+	Replace(ctx context.Context, rules []primitive.Rule) ([]primitive.Rule, error)
+	// Delete removes a rule. It returns ErrRuleNotFound if the rule doesn't exist.
+	Delete(ctx context.Context, id string) error
+	// Apply finds the oldest rule of the action that matches the subject, and removes it if
+	// it is a one-shot rule. The returned bool is false if no rule matches.
+	Apply(ctx context.Context, action primitive.RuleAction, subject primitive.RuleSubject) (primitive.Rule, bool, error)
+}
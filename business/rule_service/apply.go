@@ -0,0 +1,39 @@
+package rule_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) Apply(ctx context.Context, action primitive.RuleAction, subject primitive.RuleSubject) (primitive.Rule, bool, error) {
+	rules, err := d.ruleRepository.List(ctx)
+	if err != nil {
+		return primitive.Rule{}, false, fmt.Errorf("listing rules: %w", err)
+	}
+
+	for _, rule := range rules {
+		if rule.Action != action || !rule.Matches(subject) {
+			continue
+		}
+
+		if rule.OneShot {
+			err := d.ruleRepository.Delete(ctx, rule.Id)
+			if err != nil {
+				// Another request has just applied it, look for the next one
+				if errors.Is(err, repository.ErrNotFound) {
+					continue
+				}
+
+				return primitive.Rule{}, false, fmt.Errorf("removing one-shot rule: %w", err)
+			}
+		}
+
+		return rule, true, nil
+	}
+
+	return primitive.Rule{}, false, nil
+}
@@ -0,0 +1,131 @@
+package rule_service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+)
+
+// maximumLatency keeps an injected latency below the write timeout of the HTTP server.
+const maximumLatency = time.Second * 30
+
+func (d *Dependency) Create(ctx context.Context, rule primitive.Rule) (primitive.Rule, error) {
+	if err := ValidateRule(rule); err != nil {
+		return primitive.Rule{}, err
+	}
+
+	created, err := d.ruleRepository.Create(ctx, rule)
+	if err != nil {
+		return primitive.Rule{}, fmt.Errorf("creating rule: %w", err)
+	}
+
+	return created, nil
+}
+
+func ValidateRule(rule primitive.Rule) *business.RequestValidationError {
+	var issues []business.RequestValidationIssue
+
+	// validate match.path
+	if rule.Path != "" {
+		if _, err := path.Match(rule.Path, "/"); err != nil || rule.Path[0] != '/' {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "match.path",
+				Message: "must be a valid path pattern",
+			})
+		}
+	}
+
+	// validate match.order_id
+	if rule.OrderIdPattern != "" {
+		if _, err := regexp.Compile(rule.OrderIdPattern); err != nil {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "match.order_id",
+				Message: "must be a valid regular expression",
+			})
+		}
+	}
+
+	// validate match.min_amount and match.max_amount
+	if rule.MinimumAmount < 0 {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "match.min_amount",
+			Message: "must not be negative",
+		})
+	}
+
+	if rule.MaximumAmount < 0 {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "match.max_amount",
+			Message: "must not be negative",
+		})
+	}
+
+	if rule.MinimumAmount > 0 && rule.MaximumAmount > 0 && rule.MinimumAmount > rule.MaximumAmount {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "match.min_amount",
+			Message: "must not be greater than match.max_amount",
+		})
+	}
+
+	// validate action and its parameters
+	switch rule.Action {
+	case primitive.RuleActionLatency:
+		if rule.Latency <= 0 {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "latency_ms",
+				Message: "must be greater than 0",
+			})
+		}
+
+		if rule.Latency > maximumLatency {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "latency_ms",
+				Message: fmt.Sprintf("maximum of %d milliseconds", maximumLatency.Milliseconds()),
+			})
+		}
+	case primitive.RuleActionRespond:
+		if rule.HTTPStatus < 200 || rule.HTTPStatus > 599 {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "http_status",
+				Message: "must be between 200 and 599",
+			})
+		}
+
+		if rule.StatusCode < 0 {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "status_code",
+				Message: "must not be negative",
+			})
+		}
+	case primitive.RuleActionDeny:
+		fallthrough
+	case primitive.RuleActionDropWebhook:
+		// Neither of them has any parameter
+	default:
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeInvalidValue,
+			Field:   "action",
+			Message: "must be one of latency, respond, deny, or drop_webhook",
+		})
+	}
+
+	if len(issues) > 0 {
+		return &business.RequestValidationError{Issues: issues}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package rule_service_test
+
+import (
+	"testing"
+	"time"
+
+	"mock-payment-provider/business/rule_service"
+	"mock-payment-provider/primitive"
+)
+
+func TestValidateRule(t *testing.T) {
+	t.Run("positive test case", func(t *testing.T) {
+		rules := []primitive.Rule{
+			{Path: "/*/status", Action: primitive.RuleActionLatency, Latency: time.Second},
+			{OrderIdPattern: "^fail-", Action: primitive.RuleActionRespond, HTTPStatus: 500, StatusCode: 500},
+			{PaymentType: primitive.PaymentTypeCreditCard, MinimumAmount: 1, MaximumAmount: 10, Action: primitive.RuleActionDeny},
+			{Action: primitive.RuleActionDropWebhook, OneShot: true},
+		}
+
+		for _, rule := range rules {
+			if err := rule_service.ValidateRule(rule); err != nil {
+				t.Errorf("expect error nil for %v, but got %v instead", rule, err)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			rule  primitive.Rule
+			field string
+		}{
+			{
+				name:  "unspecified action",
+				rule:  primitive.Rule{},
+				field: "action",
+			},
+			{
+				name:  "relative path",
+				rule:  primitive.Rule{Path: "charge", Action: primitive.RuleActionDeny},
+				field: "match.path",
+			},
+			{
+				name:  "invalid path pattern",
+				rule:  primitive.Rule{Path: "/[", Action: primitive.RuleActionDeny},
+				field: "match.path",
+			},
+			{
+				name:  "invalid order id pattern",
+				rule:  primitive.Rule{OrderIdPattern: "(", Action: primitive.RuleActionDeny},
+				field: "match.order_id",
+			},
+			{
+				name:  "negative minimum amount",
+				rule:  primitive.Rule{MinimumAmount: -1, Action: primitive.RuleActionDeny},
+				field: "match.min_amount",
+			},
+			{
+				name:  "minimum amount greater than maximum amount",
+				rule:  primitive.Rule{MinimumAmount: 10, MaximumAmount: 1, Action: primitive.RuleActionDeny},
+				field: "match.min_amount",
+			},
+			{
+				name:  "no latency",
+				rule:  primitive.Rule{Action: primitive.RuleActionLatency},
+				field: "latency_ms",
+			},
+			{
+				name:  "latency too long",
+				rule:  primitive.Rule{Action: primitive.RuleActionLatency, Latency: time.Minute},
+				field: "latency_ms",
+			},
+			{
+				name:  "invalid http status",
+				rule:  primitive.Rule{Action: primitive.RuleActionRespond, HTTPStatus: 99},
+				field: "http_status",
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				err := rule_service.ValidateRule(testCase.rule)
+				if err == nil {
+					t.Fatalf("expect an error, but got nil instead")
+				}
+
+				if len(err.Issues) != 1 || err.Issues[0].Field != testCase.field {
+					t.Errorf("expect a single issue for %s, instead got %v", testCase.field, err.Issues)
+				}
+			})
+		}
+	})
+}
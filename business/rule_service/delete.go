@@ -0,0 +1,27 @@
+package rule_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return business.ErrRuleNotFound
+	}
+
+	err := d.ruleRepository.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return business.ErrRuleNotFound
+		}
+
+		return fmt.Errorf("deleting rule: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package rule_service
+
+import (
+	"context"
+	"fmt"
+
+	"mock-payment-provider/primitive"
+)
+
+func (d *Dependency) List(ctx context.Context) ([]primitive.Rule, error) {
+	rules, err := d.ruleRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing rules: %w", err)
+	}
+
+	return rules, nil
+}
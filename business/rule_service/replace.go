@@ -0,0 +1,32 @@
+package rule_service
+
+import (
+	"context"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+)
+
+func (d *Dependency) Replace(ctx context.Context, rules []primitive.Rule) ([]primitive.Rule, error) {
+	var issues []business.RequestValidationIssue
+	for i, rule := range rules {
+		if err := ValidateRule(rule); err != nil {
+			for _, issue := range err.Issues {
+				issue.Field = fmt.Sprintf("[%d].%s", i, issue.Field)
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	if len(issues) > 0 {
+		return nil, &business.RequestValidationError{Issues: issues}
+	}
+
+	replaced, err := d.ruleRepository.Replace(ctx, rules)
+	if err != nil {
+		return nil, fmt.Errorf("replacing rules: %w", err)
+	}
+
+	return replaced, nil
+}
@@ -0,0 +1,27 @@
+package rule_service
+
+import (
+	"fmt"
+
+	"mock-payment-provider/repository"
+)
+
+type Config struct {
+	RuleRepository repository.RuleRepository
+}
+
+type Dependency struct {
+	ruleRepository repository.RuleRepository
+}
+
+// NewRuleService validates input from Config and return an error if any of it is nil.
+// It implements business.Rule interface.
+func NewRuleService(config Config) (*Dependency, error) {
+	if config.RuleRepository == nil {
+		return nil, fmt.Errorf("nil rule repository")
+	}
+
+	return &Dependency{
+		ruleRepository: config.RuleRepository,
+	}, nil
+}
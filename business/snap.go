@@ -0,0 +1,70 @@
+package business
+
+import (
+	"context"
+
+	"mock-payment-provider/primitive"
+)
+
+// Snap interface handles the Snap checkout flow, where the merchant only creates
+// a checkout session and the customer picks the payment type on a hosted page.
+type Snap interface {
+	// CreateTransaction creates a new checkout session for the order. The order is not
+	// charged until the customer picks a payment type on the checkout page.
+	CreateTransaction(ctx context.Context, request SnapRequest) (SnapResponse, error)
+	// GetTransaction acquires the checkout session of the token, along with the
+	// transaction status once the order has been charged.
+	GetTransaction(ctx context.Context, token string) (SnapTransactionResponse, error)
+	// Pay charges the order with the payment type that the customer picked, and completes
+	// the payment right away, as if the customer paid it through their bank or e-wallet.
+	Pay(ctx context.Context, token string, request SnapPayRequest) (SnapTransactionResponse, error)
+}
+
+type SnapCallbacks struct {
+	// Finish is where the customer is redirected to after completing the payment.
+	Finish string
+	// Unfinish is where the customer is redirected to after leaving the checkout page
+	// without completing the payment.
+	Unfinish string
+	// Error is where the customer is redirected to after the payment failed.
+	Error string
+}
+
+type SnapRequest struct {
+	OrderId             string
+	TransactionAmount   int64
+	TransactionCurrency primitive.Currency
+	Customer            CustomerInformation
+	Seller              SellerInformation
+	ProductItems        []ProductItem
+	Callbacks           SnapCallbacks
+}
+
+type SnapResponse struct {
+	Token       string
+	RedirectURL string
+}
+
+type SnapPayRequest struct {
+	PaymentType primitive.PaymentType
+	// Card details are only required for credit card payments. OTP is the one entered
+	// on the 3-D Secure page.
+	CardNumber      string
+	CardExpiryMonth string
+	CardExpiryYear  string
+	CardCVV         string
+	OTP             string
+	// Pending charges the order without completing the payment, as if the customer
+	// left the checkout page right after picking a payment type.
+	Pending bool
+}
+
+type SnapTransactionResponse struct {
+	Token             string
+	OrderId           string
+	TransactionAmount int64
+	// PaymentType and TransactionStatus are unspecified until the order is charged.
+	PaymentType       primitive.PaymentType
+	TransactionStatus primitive.TransactionStatus
+	Callbacks         SnapCallbacks
+}
@@ -0,0 +1,111 @@
+package snap_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/business/transaction_service"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+// snapTransactionDuration is how long the customer can use the checkout page, which
+// is the default expiry of a Snap token on Midtrans.
+const snapTransactionDuration = time.Hour * 24
+
+// snapDetails holds the part of the Snap request that is only needed once the order
+// is charged.
+type snapDetails struct {
+	Currency     primitive.Currency
+	Customer     business.CustomerInformation
+	Seller       business.SellerInformation
+	ProductItems []business.ProductItem
+}
+
+func (d *Dependency) CreateTransaction(ctx context.Context, request business.SnapRequest) (business.SnapResponse, error) {
+	// Item details are optional on Snap, the whole order becomes a single item if
+	// none was given
+	productItems := request.ProductItems
+	if len(productItems) == 0 {
+		productItems = []business.ProductItem{
+			{
+				ID:       request.OrderId,
+				Price:    request.TransactionAmount,
+				Quantity: 1,
+				Name:     "Order " + request.OrderId,
+				Category: "order",
+			},
+		}
+	}
+
+	// Validate the request the same way the order will be charged, so the merchant finds
+	// out about invalid requests right away instead of on the checkout page. The payment
+	// type is left out, since the customer hasn't picked it yet.
+	chargeRequest := business.ChargeRequest{
+		OrderId:             request.OrderId,
+		TransactionAmount:   request.TransactionAmount,
+		TransactionCurrency: request.TransactionCurrency,
+		Customer:            request.Customer,
+		Seller:              request.Seller,
+		ProductItems:        productItems,
+	}
+	if err := transaction_service.ValidateChargeRequest(chargeRequest); err != nil {
+		var issues []business.RequestValidationIssue
+		for _, issue := range err.Issues {
+			if issue.Field == "payment_type" {
+				continue
+			}
+
+			issues = append(issues, issue)
+		}
+
+		if len(issues) > 0 {
+			return business.SnapResponse{}, &business.RequestValidationError{Issues: issues}
+		}
+	}
+
+	var totalAmount int64
+	for _, item := range productItems {
+		totalAmount += item.Price * item.Quantity
+	}
+
+	if totalAmount != request.TransactionAmount {
+		return business.SnapResponse{}, business.ErrMismatchedTransactionAmount
+	}
+
+	details, err := json.Marshal(snapDetails{
+		Currency:     request.TransactionCurrency,
+		Customer:     request.Customer,
+		Seller:       request.Seller,
+		ProductItems: productItems,
+	})
+	if err != nil {
+		return business.SnapResponse{}, fmt.Errorf("marshaling snap details: %w", err)
+	}
+
+	snapTransaction, err := d.snapRepository.Create(ctx, repository.CreateSnapTransactionParam{
+		OrderId:     request.OrderId,
+		Amount:      request.TransactionAmount,
+		Details:     details,
+		FinishURL:   request.Callbacks.Finish,
+		UnfinishURL: request.Callbacks.Unfinish,
+		ErrorURL:    request.Callbacks.Error,
+		ExpiresAt:   time.Now().Add(snapTransactionDuration),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return business.SnapResponse{}, business.ErrDuplicateOrderId
+		}
+
+		return business.SnapResponse{}, fmt.Errorf("creating snap transaction: %w", err)
+	}
+
+	return business.SnapResponse{
+		Token:       snapTransaction.Token,
+		RedirectURL: d.publicBaseURL.JoinPath("snap", "v4", "redirection", snapTransaction.Token).String(),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package snap_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) GetTransaction(ctx context.Context, token string) (business.SnapTransactionResponse, error) {
+	snapTransaction, err := d.snapRepository.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrExpired) {
+			return business.SnapTransactionResponse{}, business.ErrTransactionNotFound
+		}
+
+		return business.SnapTransactionResponse{}, fmt.Errorf("acquiring snap transaction: %w", err)
+	}
+
+	return d.buildSnapTransactionResponse(ctx, snapTransaction)
+}
+
+// buildSnapTransactionResponse fills the response with the transaction status, which is
+// only available once the customer picked a payment type.
+func (d *Dependency) buildSnapTransactionResponse(ctx context.Context, snapTransaction primitive.SnapTransaction) (business.SnapTransactionResponse, error) {
+	response := business.SnapTransactionResponse{
+		Token:             snapTransaction.Token,
+		OrderId:           snapTransaction.OrderId,
+		TransactionAmount: snapTransaction.Amount,
+		Callbacks: business.SnapCallbacks{
+			Finish:   snapTransaction.FinishURL,
+			Unfinish: snapTransaction.UnfinishURL,
+			Error:    snapTransaction.ErrorURL,
+		},
+	}
+
+	status, err := d.transactionService.GetStatus(ctx, snapTransaction.OrderId)
+	if err != nil {
+		if errors.Is(err, business.ErrTransactionNotFound) {
+			return response, nil
+		}
+
+		return business.SnapTransactionResponse{}, fmt.Errorf("acquiring transaction status: %w", err)
+	}
+
+	response.PaymentType = status.PaymentType
+	response.TransactionStatus = status.TransactionStatus
+
+	return response, nil
+}
+
+// charged tells whether the order of the checkout session has been charged.
+func charged(response business.SnapTransactionResponse) bool {
+	return response.TransactionStatus != primitive.TransactionStatusUnspecified
+}
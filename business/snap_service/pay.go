@@ -0,0 +1,100 @@
+package snap_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) Pay(ctx context.Context, token string, request business.SnapPayRequest) (business.SnapTransactionResponse, error) {
+	rawSnapTransaction, err := d.snapRepository.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrExpired) {
+			return business.SnapTransactionResponse{}, business.ErrTransactionNotFound
+		}
+
+		return business.SnapTransactionResponse{}, fmt.Errorf("acquiring snap transaction: %w", err)
+	}
+
+	snapTransaction, err := d.buildSnapTransactionResponse(ctx, rawSnapTransaction)
+	if err != nil {
+		return business.SnapTransactionResponse{}, err
+	}
+
+	// The order can only be charged once
+	if charged(snapTransaction) {
+		return business.SnapTransactionResponse{}, business.ErrCannotModifyStatus
+	}
+
+	if request.PaymentType == primitive.PaymentTypeUnspecified {
+		return business.SnapTransactionResponse{}, &business.RequestValidationError{
+			Issues: []business.RequestValidationIssue{
+				{
+					Code:    business.RequestValidationCodeRequired,
+					Field:   "payment_type",
+					Message: "can not be empty",
+				},
+			},
+		}
+	}
+
+	var details snapDetails
+	err = json.Unmarshal(rawSnapTransaction.Details, &details)
+	if err != nil {
+		return business.SnapTransactionResponse{}, fmt.Errorf("unmarshaling snap details: %w", err)
+	}
+
+	chargeRequest := business.ChargeRequest{
+		PaymentType:         request.PaymentType,
+		OrderId:             snapTransaction.OrderId,
+		TransactionAmount:   snapTransaction.TransactionAmount,
+		TransactionCurrency: details.Currency,
+		Customer:            details.Customer,
+		Seller:              details.Seller,
+		ProductItems:        details.ProductItems,
+	}
+
+	// Cards are tokenized first, just like what snap.js does on the customer's browser
+	if request.PaymentType == primitive.PaymentTypeCreditCard {
+		cardToken, err := d.paymentService.CreateCardToken(ctx, business.CardTokenRequest{
+			CardNumber:      request.CardNumber,
+			CardExpiryMonth: request.CardExpiryMonth,
+			CardExpiryYear:  request.CardExpiryYear,
+			CardCVV:         request.CardCVV,
+		})
+		if err != nil {
+			return business.SnapTransactionResponse{}, fmt.Errorf("creating card token: %w", err)
+		}
+
+		chargeRequest.CreditCardOptions.TokenId = cardToken.TokenId
+	}
+
+	chargeResponse, err := d.transactionService.Charge(ctx, chargeRequest)
+	if err != nil {
+		return business.SnapTransactionResponse{}, fmt.Errorf("charging transaction: %w", err)
+	}
+
+	switch {
+	case request.Pending:
+		// Leave the payment for the customer to complete later
+	case chargeResponse.TransactionStatus == primitive.TransactionStatusDeny:
+		// There is nothing left to pay once a rule has denied the charge
+	case request.PaymentType == primitive.PaymentTypeCreditCard:
+		_, err = d.paymentService.AuthenticateCard(ctx, chargeResponse.CreditCardAction.Id, request.OTP)
+		if err != nil {
+			return business.SnapTransactionResponse{}, fmt.Errorf("authenticating card: %w", err)
+		}
+	default:
+		err = d.paymentService.MarkAsPaid(ctx, snapTransaction.OrderId, request.PaymentType)
+		if err != nil {
+			return business.SnapTransactionResponse{}, fmt.Errorf("marking transaction as paid: %w", err)
+		}
+	}
+
+	return d.buildSnapTransactionResponse(ctx, rawSnapTransaction)
+}
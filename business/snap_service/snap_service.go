@@ -0,0 +1,60 @@
+package snap_service
+
+import (
+	"fmt"
+	"net/url"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/repository"
+)
+
+type Config struct {
+	SnapRepository repository.SnapRepository
+	// TransactionService and PaymentService are used for charging and paying the order
+	// once the customer picks a payment type, so Snap goes through the exact same flow
+	// as the Core API.
+	TransactionService business.Transaction
+	PaymentService     business.Payment
+	// PublicBaseURL is the base URL that the customer uses to reach this service. It is
+	// used for building the checkout page URL.
+	PublicBaseURL string
+}
+
+type Dependency struct {
+	snapRepository     repository.SnapRepository
+	transactionService business.Transaction
+	paymentService     business.Payment
+	publicBaseURL      *url.URL
+}
+
+// NewSnapService validates input from Config and return an error if any of it is nil.
+// It implements business.Snap interface.
+func NewSnapService(config Config) (*Dependency, error) {
+	if config.SnapRepository == nil {
+		return nil, fmt.Errorf("nil snap repository")
+	}
+
+	if config.TransactionService == nil {
+		return nil, fmt.Errorf("nil transaction service")
+	}
+
+	if config.PaymentService == nil {
+		return nil, fmt.Errorf("nil payment service")
+	}
+
+	publicBaseURL, err := url.Parse(config.PublicBaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public base url: %w", err)
+	}
+
+	if publicBaseURL.Scheme == "" || publicBaseURL.Host == "" {
+		return nil, fmt.Errorf("public base url must be absolute")
+	}
+
+	return &Dependency{
+		snapRepository:     config.SnapRepository,
+		transactionService: config.TransactionService,
+		paymentService:     config.PaymentService,
+		publicBaseURL:      publicBaseURL,
+	}, nil
+}
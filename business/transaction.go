@@ -12,6 +12,18 @@ type Transaction interface {
 	Cancel(ctx context.Context, orderId string) (CancelResponse, error)
 	GetStatus(ctx context.Context, orderId string) (GetStatusResponse, error)
 	Expire(ctx context.Context, orderId string) (ExpireResponse, error)
+	// ExpireOverdue expires every pending transaction that is past its expiry time, and
+	// sends their EXPIRED webhooks. It returns the number of expired transactions.
+	ExpireOverdue(ctx context.Context) (int, error)
+	Refund(ctx context.Context, orderId string, request RefundRequest) (RefundResponse, error)
+	// Capture charges an authorized card transaction, either fully or partially.
+	Capture(ctx context.Context, orderId string, request CaptureRequest) (CaptureResponse, error)
+	// Approve resolves the fraud challenge of a card transaction by accepting it.
+	Approve(ctx context.Context, orderId string) (FraudReviewResponse, error)
+	// Deny resolves the fraud challenge of a card transaction by denying it.
+	Deny(ctx context.Context, orderId string) (FraudReviewResponse, error)
+	// GetStatusHistory lists every status change of a transaction, from its creation.
+	GetStatusHistory(ctx context.Context, orderId string) (GetStatusHistoryResponse, error)
 }
 
 type ProductItem struct {
@@ -54,6 +66,21 @@ type VirtualAccountAction struct {
 	VirtualAccountNumber string
 }
 
+// CStoreAction is how the customer pays for a convenience store charge, by showing
+// the payment code to the cashier of the store.
+type CStoreAction struct {
+	Store       string
+	PaymentCode string
+}
+
+// PaylaterAction is how the customer pays for a paylater charge, by getting approved on
+// the paylater provider's page.
+type PaylaterAction struct {
+	// Id identifies the charge on the approval page.
+	Id          string
+	RedirectURL string
+}
+
 type EMoneyActionType uint8
 
 const (
@@ -88,14 +115,68 @@ type EMoneyAction struct {
 }
 
 type BankTransferOptions struct {
+	// VirtualAccountNumber is the merchant's own number for the customer. A number is
+	// generated for the customer if it is empty.
 	VirtualAccountNumber string
-	RecipientName        string
+	// RecipientName replaces the merchant name that the bank shows to the customer.
+	RecipientName string
+	// SubCompanyCode prefixes the virtual account number, only for BCA.
+	SubCompanyCode string
+	FreeText       primitive.FreeText
 }
 
 type EMoneyOptions struct {
 	CallbackURL string `json:"callback_url"`
 }
 
+type CreditCardOptions struct {
+	// TokenId is the card token acquired by the merchant's frontend, the card number
+	// itself should never reach the merchant's backend.
+	TokenId string
+	// Bank is the acquiring bank of the transaction. It defaults to "bni".
+	Bank string
+	// SaveTokenId asks for the card to be saved, so it can be charged again later with
+	// the saved token ID without the customer entering their card details.
+	SaveTokenId bool
+	// Type decides whether the charge is captured right after 3-D Secure, or only
+	// authorized to be captured later. It defaults to authorize_capture.
+	Type primitive.CardTransactionType
+}
+
+type CreditCardAction struct {
+	// Id identifies the charge on the 3-D Secure page.
+	Id string
+	// RedirectURL points to the 3-D Secure page, where the customer must enter the OTP
+	// to authenticate the charge.
+	RedirectURL string
+	MaskedCard  string
+	Bank        string
+	CardType    string
+	// SavedTokenId is only available if the request asked for the card to be saved.
+	SavedTokenId          string
+	SavedTokenIdExpiresAt time.Time
+}
+
+// CustomExpiry overrides the default expiry of a charge, which is counted from the order
+// time rather than from the time of the charge.
+type CustomExpiry struct {
+	// OrderTime defaults to the time of the charge if it is zero.
+	OrderTime      time.Time
+	ExpiryDuration int64
+	Unit           primitive.ExpiryUnit
+}
+
+// ExpiresAt returns the time the charge expires, counted from chargedAt if the order
+// time was not given.
+func (c CustomExpiry) ExpiresAt(chargedAt time.Time) time.Time {
+	orderTime := c.OrderTime
+	if orderTime.IsZero() {
+		orderTime = chargedAt
+	}
+
+	return orderTime.Add(time.Duration(c.ExpiryDuration) * c.Unit.Duration())
+}
+
 type ChargeRequest struct {
 	PaymentType         primitive.PaymentType
 	OrderId             string
@@ -106,6 +187,12 @@ type ChargeRequest struct {
 	ProductItems        []ProductItem
 	BankTransferOptions BankTransferOptions
 	EMoneyOptions       EMoneyOptions
+	CreditCardOptions   CreditCardOptions
+	// CustomExpiry is nil if the default expiry of the payment type should be used.
+	CustomExpiry *CustomExpiry
+	// NotificationURLs comes from the X-Override-Notification and X-Append-Notification
+	// headers, every webhook of the transaction is sent to them.
+	NotificationURLs primitive.NotificationURLs
 }
 
 type ChargeResponse struct {
@@ -114,8 +201,15 @@ type ChargeResponse struct {
 	PaymentType          primitive.PaymentType
 	TransactionStatus    primitive.TransactionStatus
 	TransactionTime      time.Time
+	ExpiresAt            time.Time
 	EMoneyAction         []EMoneyAction
 	VirtualAccountAction VirtualAccountAction
+	CreditCardAction     CreditCardAction
+	CStoreAction         CStoreAction
+	PaylaterAction       PaylaterAction
+	// QRString is the QRIS payload string, only available for payment types that
+	// can be paid by scanning a QR code.
+	QRString string
 }
 
 type CancelResponse struct {
@@ -132,6 +226,27 @@ type GetStatusResponse struct {
 	TransactionAmount int64
 	PaymentType       primitive.PaymentType
 	TransactionTime   time.Time
+	ExpiresAt         time.Time
+	// RefundAmount is the accumulated amount of every refund on this transaction.
+	RefundAmount int64
+	Refunds      []primitive.Refund
+	// MaskedCard, Bank, CardType and ApprovalCode are only available for credit card
+	// transactions. ApprovalCode is only given once the card has been charged.
+	MaskedCard   string
+	Bank         string
+	CardType     string
+	ApprovalCode string
+	FraudStatus  primitive.FraudStatus
+	// RecipientName and FreeText are only available for virtual account transactions,
+	// if the merchant gave them on the charge.
+	RecipientName string
+	FreeText      primitive.FreeText
+}
+
+type GetStatusHistoryResponse struct {
+	OrderId           string
+	TransactionStatus primitive.TransactionStatus
+	History           []primitive.TransactionStatusChange
 }
 
 type ExpireResponse struct {
@@ -141,3 +256,59 @@ type ExpireResponse struct {
 	TransactionStatus primitive.TransactionStatus
 	TransactionTime   time.Time
 }
+
+type RefundRequest struct {
+	// RefundKey is the merchant's unique reference of the refund. If it is empty,
+	// a random one will be generated.
+	RefundKey string
+	// Amount to be refunded. If it is zero, the remaining refundable amount will be refunded.
+	Amount int64
+	Reason string
+	// Direct tells whether the refund was requested through the direct (online) refund API,
+	// which is only supported by e-money payment types.
+	Direct bool
+}
+
+type RefundResponse struct {
+	OrderId            string
+	TransactionAmount  int64
+	PaymentType        primitive.PaymentType
+	TransactionStatus  primitive.TransactionStatus
+	TransactionTime    time.Time
+	RefundChargebackId int64
+	RefundAmount       int64
+	RefundKey          string
+}
+
+type CaptureRequest struct {
+	// Amount to be captured. If it is zero, the whole authorized amount will be captured.
+	Amount int64
+}
+
+type CaptureResponse struct {
+	OrderId           string
+	TransactionAmount int64
+	CapturedAmount    int64
+	PaymentType       primitive.PaymentType
+	TransactionStatus primitive.TransactionStatus
+	TransactionTime   time.Time
+	MaskedCard        string
+	Bank              string
+	CardType          string
+	ApprovalCode      string
+	FraudStatus       primitive.FraudStatus
+}
+
+// FraudReviewResponse is the card transaction after the merchant approved or denied its
+// fraud challenge.
+type FraudReviewResponse struct {
+	OrderId           string
+	TransactionAmount int64
+	PaymentType       primitive.PaymentType
+	TransactionStatus primitive.TransactionStatus
+	TransactionTime   time.Time
+	MaskedCard        string
+	Bank              string
+	ApprovalCode      string
+	FraudStatus       primitive.FraudStatus
+}
@@ -0,0 +1,56 @@
+package transaction_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) Approve(ctx context.Context, orderId string) (business.FraudReviewResponse, error) {
+	transaction, creditCardCharge, err := d.acquireChallengedTransaction(ctx, orderId)
+	if err != nil {
+		return business.FraudReviewResponse{}, err
+	}
+
+	// The transaction keeps its status, only the fraud status changes. The challenge may
+	// have been resolved by another request since it was acquired.
+	err = d.creditCardRepository.ApproveChallenge(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return business.FraudReviewResponse{}, business.ErrCannotModifyStatus
+		}
+
+		return business.FraudReviewResponse{}, fmt.Errorf("approving credit card challenge: %w", err)
+	}
+	creditCardCharge.Outcome = primitive.CardOutcomeAccept
+
+	payload, err := d.buildFraudReviewWebhookMessage(fraudReviewWebhookParameters{
+		TransactionTime:   transaction.TransactionTime,
+		TransactionStatus: transaction.TransactionStatus,
+		GrossAmount:       transaction.TransactionAmount,
+		OrderId:           orderId,
+		CreditCardCharge:  creditCardCharge,
+	})
+	if err != nil {
+		return business.FraudReviewResponse{}, fmt.Errorf("building fraud review webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, orderId, payload, time.Now())
+
+	return business.FraudReviewResponse{
+		OrderId:           orderId,
+		TransactionAmount: transaction.TransactionAmount,
+		PaymentType:       transaction.PaymentType,
+		TransactionStatus: transaction.TransactionStatus,
+		TransactionTime:   transaction.TransactionTime,
+		MaskedCard:        creditCardCharge.MaskedCard,
+		Bank:              creditCardCharge.Bank,
+		ApprovalCode:      creditCardCharge.ApprovalCode,
+		FraudStatus:       primitive.FraudStatusAccept,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package transaction_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+)
+
+func TestDependency_Approve(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := transactionService.Approve(ctx, "approve-not-found")
+		if !errors.Is(err, business.ErrTransactionNotFound) {
+			t.Errorf("expecting ErrTransactionNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Not Challenged", func(t *testing.T) {
+		orderId := createCardTransaction(t, ctx, primitive.TransactionStatusCapture, primitive.CardOutcomeAccept)
+
+		_, err := transactionService.Approve(ctx, orderId)
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+
+		if notifications := claimNotifications(t, ctx, orderId); len(notifications) != 0 {
+			t.Errorf("expecting no notification, instead got %d", len(notifications))
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		orderId := createCardTransaction(t, ctx, primitive.TransactionStatusCapture, primitive.CardOutcomeChallenge)
+
+		response, err := transactionService.Approve(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if response.TransactionStatus != primitive.TransactionStatusCapture {
+			t.Errorf("expecting status to stay %s, instead got %s", primitive.TransactionStatusCapture, response.TransactionStatus)
+		}
+
+		if response.FraudStatus != primitive.FraudStatusAccept {
+			t.Errorf("expecting fraud status to be %s, instead got %s", primitive.FraudStatusAccept, response.FraudStatus)
+		}
+
+		status, err := transactionService.GetStatus(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring status: %s", err.Error())
+		}
+
+		if status.FraudStatus != primitive.FraudStatusAccept {
+			t.Errorf("expecting the stored fraud status to be %s, instead got %s", primitive.FraudStatusAccept, status.FraudStatus)
+		}
+
+		notifications := claimNotifications(t, ctx, orderId)
+		if len(notifications) != 1 {
+			t.Fatalf("expecting 1 notification, instead got %d", len(notifications))
+		}
+
+		if notifications[0].TransactionStatus != "capture" || notifications[0].FraudStatus != "accept" {
+			t.Errorf("expecting a capture notification with an accepted fraud status, instead got %+v", notifications[0])
+		}
+
+		// The challenge is already resolved, nothing is notified again
+		_, err = transactionService.Approve(ctx, orderId)
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+
+		_, err = transactionService.Deny(ctx, orderId)
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+
+		if notifications := claimNotifications(t, ctx, orderId); len(notifications) != 0 {
+			t.Errorf("expecting no other notification, instead got %d", len(notifications))
+		}
+	})
+}
@@ -2,12 +2,17 @@ package transaction_service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
 	"mock-payment-provider/primitive"
 	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
 )
 
 func (d *Dependency) Cancel(ctx context.Context, orderId string) (business.CancelResponse, error) {
@@ -25,24 +30,280 @@ func (d *Dependency) Cancel(ctx context.Context, orderId string) (business.Cance
 		return business.CancelResponse{}, fmt.Errorf("acquiring transaction status: %w", err)
 	}
 
-	// We can't cancel any transaction that has been canceled, settled, or expired
-	if transactionStatus.TransactionStatus == primitive.TransactionStatusExpired ||
-		transactionStatus.TransactionStatus == primitive.TransactionStatusSettled ||
-		transactionStatus.TransactionStatus == primitive.TransactionStatusCanceled {
+	// We can only cancel transactions that haven't been settled or finalized
+	if !transactionStatus.TransactionStatus.CanTransitionTo(primitive.TransactionStatusCancel) {
 		return business.CancelResponse{}, business.ErrCannotModifyStatus
 	}
 
 	// Cancel the transaction
-	err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusCanceled)
+	err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusCancel, primitive.StatusChangeSourceCancel, primitive.StatusChangeActorMerchant)
 	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return business.CancelResponse{}, business.ErrCannotModifyStatus
+		}
+
 		return business.CancelResponse{}, fmt.Errorf("modifying the transaction status to canceled: %w", err)
 	}
 
+	// The bill key is the virtual account number, which is gone once the charge is released
+	billKey, err := d.mandiriBillKey(ctx, orderId, transactionStatus.PaymentType)
+	if err != nil {
+		return business.CancelResponse{}, err
+	}
+
+	// Free the virtual account number or the e-money charge, so the customer can no
+	// longer pay for the order
+	err = d.releaseCharge(ctx, orderId, transactionStatus.PaymentType)
+	if err != nil {
+		return business.CancelResponse{}, fmt.Errorf("releasing charge: %w", err)
+	}
+
+	// Canceling an authorized card transaction voids the authorization, which releases
+	// the reserved amount on the card. The webhook needs the card details.
+	var creditCardCharge primitive.CreditCardCharge
+	if transactionStatus.PaymentType == primitive.PaymentTypeCreditCard {
+		creditCardCharge, err = d.creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			return business.CancelResponse{}, fmt.Errorf("acquiring credit card charge: %w", err)
+		}
+	}
+
+	// The payment code is kept after the charge is released, the webhook needs it
+	var cstoreEntry repository.Entry
+	if transactionStatus.PaymentType.ToStore() != "" {
+		cstoreEntry, err = d.cstoreRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			return business.CancelResponse{}, fmt.Errorf("acquiring cstore entry: %w", err)
+		}
+	}
+
+	// Send a CANCEL webhook
+	payload, err := d.buildCanceledWebhookMessage(canceledWebhookParameters{
+		TransactionTime: transactionStatus.TransactionTime,
+		GrossAmount:     transactionStatus.TransactionAmount,
+		OrderId:         orderId,
+		PaymentType:     transactionStatus.PaymentType,
+		BillKey:         billKey,
+		PaymentCode:     cstoreEntry.PaymentCode,
+		MaskedCard:      creditCardCharge.MaskedCard,
+		Bank:            creditCardCharge.Bank,
+		CardType:        creditCardCharge.CardType,
+		ApprovalCode:    creditCardCharge.ApprovalCode,
+		FraudStatus:     creditCardCharge.Outcome.FraudStatus(),
+	})
+	if err != nil {
+		return business.CancelResponse{}, fmt.Errorf("building canceled webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, orderId, payload, time.Now())
+
 	return business.CancelResponse{
 		OrderId:           orderId,
 		TransactionAmount: transactionStatus.TransactionAmount,
 		PaymentType:       transactionStatus.PaymentType,
-		TransactionStatus: primitive.TransactionStatusCanceled,
+		TransactionStatus: primitive.TransactionStatusCancel,
 		TransactionTime:   transactionStatus.TransactionTime,
 	}, nil
 }
+
+type canceledWebhookParameters struct {
+	TransactionTime time.Time
+	GrossAmount     int64
+	OrderId         string
+	PaymentType     primitive.PaymentType
+	BillKey         string
+	PaymentCode     string
+	MaskedCard      string
+	Bank            string
+	CardType        string
+	ApprovalCode    string
+	FraudStatus     primitive.FraudStatus
+}
+
+func (d *Dependency) buildCanceledWebhookMessage(parameters canceledWebhookParameters) ([]byte, error) {
+	signatureKey := signature.Generate(parameters.OrderId, 200, parameters.GrossAmount, d.serverKey)
+
+	switch parameters.PaymentType {
+	case primitive.PaymentTypeVirtualAccountBCA:
+		return json.Marshal(schema.BCAVirtualAccountChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeVirtualAccountBRI:
+		return json.Marshal(schema.BRIVirtualAccountChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeVirtualAccountBNI:
+		return json.Marshal(schema.BNIVirtualAccountChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		return json.Marshal(schema.CIMBVirtualAccountChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeVirtualAccountBSI:
+		return json.Marshal(schema.BSIVirtualAccountChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeMandiriBill:
+		return json.Marshal(schema.MandiriBillChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			BillKey:           parameters.BillKey,
+			BillerCode:        primitive.MandiriBillerCode,
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		return json.Marshal(schema.CStoreChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			PaymentCode:       parameters.PaymentCode,
+			Store:             parameters.PaymentType.ToStore(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		return json.Marshal(schema.PaylaterChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeVirtualAccountPermata:
+		return json.Marshal(schema.PermataVirtualAccountChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeEMoneyQRIS:
+		return json.Marshal(schema.QRISChargeCanceledResponse{
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			TransactionId:     parameters.OrderId,
+			StatusMessage:     "midtrans payment notification",
+			StatusCode:        "200",
+			SignatureKey:      signatureKey,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:       "accept",
+			Currency:          "IDR",
+			Acquirer:          "nobu",
+		})
+	case primitive.PaymentTypeEMoneyGopay:
+		return json.Marshal(schema.GopayChargeCanceledResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeEMoneyShopeePay:
+		return json.Marshal(schema.ShopeePayChargeCanceledResponse{
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			TransactionId:     parameters.OrderId,
+			StatusMessage:     "midtrans payment notification",
+			StatusCode:        "200",
+			SignatureKey:      signatureKey,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:       "accept",
+			Currency:          "IDR",
+		})
+	case primitive.PaymentTypeCreditCard:
+		return json.Marshal(schema.CreditCardChargeCanceledResponse{
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusCancel.String(),
+			TransactionId:     parameters.OrderId,
+			StatusMessage:     "midtrans payment notification",
+			StatusCode:        "200",
+			SignatureKey:      signatureKey,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			MaskedCard:        parameters.MaskedCard,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:       parameters.FraudStatus.String(),
+			Currency:          "IDR",
+			CardType:          parameters.CardType,
+			Bank:              parameters.Bank,
+			ApprovalCode:      parameters.ApprovalCode,
+		})
+	default:
+		return nil, fmt.Errorf("unknown payment type")
+	}
+}
@@ -0,0 +1,154 @@
+package transaction_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+func (d *Dependency) Capture(ctx context.Context, orderId string, request business.CaptureRequest) (business.CaptureResponse, error) {
+	if orderId == "" {
+		return business.CaptureResponse{}, fmt.Errorf("empty order id")
+	}
+
+	if request.Amount < 0 {
+		return business.CaptureResponse{}, &business.RequestValidationError{
+			Issues: []business.RequestValidationIssue{
+				{
+					Code:    business.RequestValidationCodeInvalidValue,
+					Field:   "gross_amount",
+					Message: "must not be negative",
+				},
+			},
+		}
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return business.CaptureResponse{}, business.ErrTransactionNotFound
+		}
+
+		return business.CaptureResponse{}, fmt.Errorf("acquiring transaction: %w", err)
+	}
+
+	// Only authorized card transactions can be captured
+	if transaction.PaymentType != primitive.PaymentTypeCreditCard ||
+		transaction.TransactionStatus != primitive.TransactionStatusAuthorize {
+		return business.CaptureResponse{}, business.ErrCannotModifyStatus
+	}
+
+	creditCardCharge, err := d.creditCardRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return business.CaptureResponse{}, fmt.Errorf("acquiring credit card charge: %w", err)
+	}
+
+	// A challenged charge has to be approved before it is captured
+	if creditCardCharge.Outcome == primitive.CardOutcomeChallenge {
+		return business.CaptureResponse{}, business.ErrCannotModifyStatus
+	}
+
+	amount := request.Amount
+	if amount == 0 {
+		amount = transaction.TransactionAmount
+	}
+
+	if amount > transaction.TransactionAmount {
+		return business.CaptureResponse{}, business.ErrCaptureAmountExceeded
+	}
+
+	// The captured amount is only written along with the status change, so a concurrent
+	// capture can neither overwrite it nor leave its own amount behind
+	err = d.creditCardRepository.Capture(ctx, orderId, amount, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.Is(err, repository.ErrNotFound) || errors.As(err, &invalidTransitionError) {
+			return business.CaptureResponse{}, business.ErrCannotModifyStatus
+		}
+
+		return business.CaptureResponse{}, fmt.Errorf("capturing credit card charge: %w", err)
+	}
+
+	// Send a CAPTURE webhook
+	payload, err := d.buildCaptureWebhookMessage(captureWebhookParameters{
+		TransactionTime: transaction.TransactionTime,
+		GrossAmount:     amount,
+		OrderId:         orderId,
+		PaymentType:     transaction.PaymentType,
+		MaskedCard:      creditCardCharge.MaskedCard,
+		Bank:            creditCardCharge.Bank,
+		CardType:        creditCardCharge.CardType,
+		ApprovalCode:    creditCardCharge.ApprovalCode,
+		FraudStatus:     creditCardCharge.Outcome.FraudStatus(),
+	})
+	if err != nil {
+		return business.CaptureResponse{}, fmt.Errorf("building capture webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, orderId, payload, time.Now())
+
+	return business.CaptureResponse{
+		OrderId:           orderId,
+		TransactionAmount: transaction.TransactionAmount,
+		CapturedAmount:    amount,
+		PaymentType:       transaction.PaymentType,
+		TransactionStatus: primitive.TransactionStatusCapture,
+		TransactionTime:   transaction.TransactionTime,
+		MaskedCard:        creditCardCharge.MaskedCard,
+		Bank:              creditCardCharge.Bank,
+		CardType:          creditCardCharge.CardType,
+		ApprovalCode:      creditCardCharge.ApprovalCode,
+		FraudStatus:       creditCardCharge.Outcome.FraudStatus(),
+	}, nil
+}
+
+type captureWebhookParameters struct {
+	TransactionTime time.Time
+	// GrossAmount is the captured amount, which may be lower than the authorized amount.
+	GrossAmount  int64
+	OrderId      string
+	PaymentType  primitive.PaymentType
+	MaskedCard   string
+	Bank         string
+	CardType     string
+	ApprovalCode string
+	FraudStatus  primitive.FraudStatus
+}
+
+func (d *Dependency) buildCaptureWebhookMessage(parameters captureWebhookParameters) ([]byte, error) {
+	switch parameters.PaymentType {
+	case primitive.PaymentTypeCreditCard:
+		return json.Marshal(schema.CreditCardChargeCaptureResponse{
+			TransactionTime:        parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus:      primitive.TransactionStatusCapture.String(),
+			TransactionId:          parameters.OrderId,
+			StatusMessage:          "midtrans payment notification",
+			StatusCode:             "200",
+			SignatureKey:           signature.Generate(parameters.OrderId, 200, parameters.GrossAmount, d.serverKey),
+			PaymentType:            parameters.PaymentType.ToPaymentMethod(),
+			OrderId:                parameters.OrderId,
+			MerchantId:             "MOCK",
+			MaskedCard:             parameters.MaskedCard,
+			GrossAmount:            strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:            parameters.FraudStatus.String(),
+			Eci:                    "05",
+			Currency:               "IDR",
+			ChannelResponseMessage: "Approved",
+			ChannelResponseCode:    "00",
+			CardType:               parameters.CardType,
+			Bank:                   parameters.Bank,
+			ApprovalCode:           parameters.ApprovalCode,
+		})
+	default:
+		return nil, fmt.Errorf("unknown payment type")
+	}
+}
@@ -0,0 +1,61 @@
+package transaction_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+)
+
+func TestDependency_Capture(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Not Authorized", func(t *testing.T) {
+		orderId := createCardTransaction(t, ctx, primitive.TransactionStatusPending, primitive.CardOutcomeAccept)
+
+		_, err := transactionService.Capture(ctx, orderId, business.CaptureRequest{})
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+	})
+
+	t.Run("Captured Twice", func(t *testing.T) {
+		orderId := createCardTransaction(t, ctx, primitive.TransactionStatusAuthorize, primitive.CardOutcomeAccept)
+
+		response, err := transactionService.Capture(ctx, orderId, business.CaptureRequest{Amount: 30000})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if response.TransactionStatus != primitive.TransactionStatusCapture {
+			t.Errorf("expecting status to be %s, instead got %s", primitive.TransactionStatusCapture, response.TransactionStatus)
+		}
+
+		if notifications := claimNotifications(t, ctx, orderId); len(notifications) != 1 {
+			t.Errorf("expecting 1 notification, instead got %d", len(notifications))
+		}
+
+		_, err = transactionService.Capture(ctx, orderId, business.CaptureRequest{Amount: 50000})
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+
+		// The amount of the first capture is the one that refunds are checked against
+		charge, err := creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring charge: %s", err.Error())
+		}
+
+		if charge.CapturedAmount != 30000 {
+			t.Errorf("expecting captured amount to stay 30000, instead got %d", charge.CapturedAmount)
+		}
+
+		if notifications := claimNotifications(t, ctx, orderId); len(notifications) != 0 {
+			t.Errorf("expecting no extra notification, instead got %d", len(notifications))
+		}
+	})
+}
@@ -5,11 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
-	"github.com/rs/zerolog"
 	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/repository/qris"
 	"mock-payment-provider/repository/signature"
 
 	"mock-payment-provider/business"
@@ -17,7 +18,25 @@ import (
 	"mock-payment-provider/repository"
 )
 
+// pendingWebhookDelay holds back the pending webhook of a new charge, to make sure the
+// merchant has received the charge response before the notification arrives.
+const pendingWebhookDelay = time.Second * 10
+
 func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest) (business.ChargeResponse, error) {
+	response, err := d.charge(ctx, request)
+	if err != nil {
+		return business.ChargeResponse{}, err
+	}
+
+	// Only a charge that is still waiting for the customer can be driven to an outcome
+	if response.TransactionStatus == primitive.TransactionStatusPending {
+		d.scheduleMagicOutcome(ctx, request)
+	}
+
+	return response, nil
+}
+
+func (d *Dependency) charge(ctx context.Context, request business.ChargeRequest) (business.ChargeResponse, error) {
 	// Validate the request payload
 	if err := ValidateChargeRequest(request); err != nil {
 		return business.ChargeResponse{}, err
@@ -34,6 +53,21 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 	}
 
 	transactionTime := time.Now()
+
+	// A deny rule takes over before any payment entry is created
+	_, deny, err := d.ruleService.Apply(ctx, primitive.RuleActionDeny, primitive.RuleSubject{
+		OrderId:     request.OrderId,
+		PaymentType: request.PaymentType,
+		Amount:      request.TransactionAmount,
+	})
+	if err != nil {
+		return business.ChargeResponse{}, fmt.Errorf("applying deny rules: %w", err)
+	}
+
+	if deny {
+		return d.denyCharge(ctx, request, transactionTime)
+	}
+
 	switch request.PaymentType {
 	case primitive.PaymentTypeVirtualAccountBCA:
 		fallthrough
@@ -42,16 +76,32 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 	case primitive.PaymentTypeVirtualAccountBRI:
 		fallthrough
 	case primitive.PaymentTypeVirtualAccountBNI:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBSI:
+		fallthrough
+	case primitive.PaymentTypeMandiriBill:
+		// Acquire virtual account number before creating the transaction, since the one given
+		// by the merchant might be held by another customer
+		virtualAccountNumber, err := d.acquireVirtualAccountNumber(ctx, request)
+		if err != nil {
+			return business.ChargeResponse{}, err
+		}
+
 		// Create new transaction
-		expiredAt := time.Now().Add(time.Hour * 24)
-		err := d.transactionRepository.Create(
+		expiredAt := chargeExpiresAt(request, transactionTime, time.Hour*24)
+		err = d.transactionRepository.Create(
 			ctx,
 			repository.CreateTransactionParam{
-				OrderID:     request.OrderId,
-				Amount:      request.TransactionAmount,
-				PaymentType: request.PaymentType,
-				Status:      primitive.TransactionStatusPending,
-				ExpiredAt:   expiredAt,
+				OrderID:          request.OrderId,
+				Amount:           request.TransactionAmount,
+				PaymentType:      request.PaymentType,
+				Status:           primitive.TransactionStatusPending,
+				ExpiredAt:        expiredAt,
+				Source:           primitive.StatusChangeSourceCharge,
+				Actor:            primitive.StatusChangeActorMerchant,
+				NotificationURLs: request.NotificationURLs,
 			},
 		)
 		if err != nil {
@@ -62,12 +112,6 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 			return business.ChargeResponse{}, fmt.Errorf("creating new transaction: %w", err)
 		}
 
-		// Acquire virtual account number from customer email
-		virtualAccountNumber, err := d.virtualAccountRepository.CreateOrGetVirtualAccountNumber(ctx, request.Customer.Email)
-		if err != nil {
-			return business.ChargeResponse{}, fmt.Errorf("acquiring virtual account number for %s: %w", request.Customer.Email, err)
-		}
-
 		// Create a virtual account entry
 		_, err = d.virtualAccountRepository.CreateCharge(
 			ctx,
@@ -80,84 +124,194 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 			return business.ChargeResponse{}, fmt.Errorf("creating virtual account entry: %w", err)
 		}
 
-		go func() {
-			// Send a PENDING webhook
-			log := zerolog.Ctx(ctx)
-
-			payload, err := d.buildPendingWebhookMessage(pendingWebhookParameters{
-				TransactionTime:      transactionTime,
-				GrossAmount:          totalAmount,
-				OrderId:              request.OrderId,
-				PaymentType:          request.PaymentType,
-				VirtualAccountNumber: virtualAccountNumber,
+		if request.BankTransferOptions.RecipientName != "" || !request.BankTransferOptions.FreeText.Empty() {
+			err = d.virtualAccountRepository.SaveDetail(ctx, request.OrderId, primitive.VirtualAccountDetail{
+				RecipientName: request.BankTransferOptions.RecipientName,
+				FreeText:      request.BankTransferOptions.FreeText,
 			})
 			if err != nil {
-				log.Err(err).Msg("building pending webhook message")
-				return
+				return business.ChargeResponse{}, fmt.Errorf("saving virtual account detail: %w", err)
 			}
+		}
 
-			// Sleep for 10 seconds to make sure client has received the response
-			time.Sleep(time.Second * 10)
+		// Send a PENDING webhook
+		payload, err := d.buildPendingWebhookMessage(pendingWebhookParameters{
+			TransactionTime:      transactionTime,
+			GrossAmount:          totalAmount,
+			OrderId:              request.OrderId,
+			PaymentType:          request.PaymentType,
+			VirtualAccountNumber: virtualAccountNumber,
+		})
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("building pending webhook message: %w", err)
+		}
 
-			ctx := context.Background()
+		d.notifier.Send(ctx, request.OrderId, payload, time.Now().Add(pendingWebhookDelay))
 
-			err = d.webhookClient.Send(ctx, payload)
-			if err != nil {
-				log.Err(err).Msg("sending webhook")
-				return
+		return business.ChargeResponse{
+			OrderId:           request.OrderId,
+			TransactionAmount: request.TransactionAmount,
+			PaymentType:       request.PaymentType,
+			TransactionStatus: primitive.TransactionStatusPending,
+			TransactionTime:   time.Now(),
+			ExpiresAt:         expiredAt,
+			EMoneyAction:      []business.EMoneyAction{},
+			VirtualAccountAction: business.VirtualAccountAction{
+				Bank:                 request.PaymentType.ToBank(),
+				VirtualAccountNumber: virtualAccountNumber,
+			},
+		}, nil
+	case primitive.PaymentTypeEMoneyQRIS:
+		fallthrough
+	case primitive.PaymentTypeEMoneyGopay:
+		fallthrough
+	case primitive.PaymentTypeEMoneyShopeePay:
+		// Create new transaction
+		expiredAt := chargeExpiresAt(request, transactionTime, time.Hour*3)
+		err := d.transactionRepository.Create(
+			ctx,
+			repository.CreateTransactionParam{
+				OrderID:          request.OrderId,
+				Amount:           request.TransactionAmount,
+				PaymentType:      request.PaymentType,
+				Status:           primitive.TransactionStatusPending,
+				ExpiredAt:        expiredAt,
+				Source:           primitive.StatusChangeSourceCharge,
+				Actor:            primitive.StatusChangeActorMerchant,
+				NotificationURLs: request.NotificationURLs,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, repository.ErrDuplicate) {
+				return business.ChargeResponse{}, business.ErrDuplicateOrderId
 			}
 
-			log.Info().Bytes("payload", payload).Msg("sent a webhook")
-		}()
-
-		go func(expiresAt time.Time, orderId string) {
-			// Send a EXPIRED webhook
-			log := zerolog.Ctx(ctx)
+			return business.ChargeResponse{}, fmt.Errorf("creating new transaction: %w", err)
+		}
 
-			time.Sleep(time.Until(expiredAt))
-			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-			defer cancel()
+		// Create e-money entry
+		id, err := d.emoneyRepository.CreateCharge(
+			ctx,
+			request.OrderId,
+			request.TransactionAmount,
+			expiredAt,
+		)
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("creating e-money entry: %w", err)
+		}
 
-			transaction, err := d.transactionRepository.GetByOrderId(ctx, orderId)
-			if err != nil {
-				log.Err(err).Str("orderId", orderId).Msg("acquiring transaction by order id")
-				return
-			}
+		// Send a PENDING webhook
+		payload, err := d.buildPendingWebhookMessage(pendingWebhookParameters{
+			TransactionTime:      transactionTime,
+			GrossAmount:          totalAmount,
+			OrderId:              request.OrderId,
+			PaymentType:          request.PaymentType,
+			VirtualAccountNumber: "",
+		})
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("building pending webhook message: %w", err)
+		}
 
-			if transaction.TransactionStatus != primitive.TransactionStatusPending {
-				// Do nothing
-				return
-			}
+		d.notifier.Send(ctx, request.OrderId, payload, time.Now().Add(pendingWebhookDelay))
 
-			// Update transaction status to expired
-			err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusExpired)
+		var qrString string
+		var actions []business.EMoneyAction
+		if request.PaymentType == primitive.PaymentTypeEMoneyQRIS || request.PaymentType == primitive.PaymentTypeEMoneyGopay {
+			qrString, err = qris.Generate(qris.Payload{EMoneyID: id, Amount: request.TransactionAmount})
 			if err != nil {
-				log.Err(err).Str("orderId", orderId).Msg("updating transaction status to expired")
-				return
+				return business.ChargeResponse{}, fmt.Errorf("generating qris payload: %w", err)
 			}
 
-			// Send webhook
-			ctx = context.Background()
+			actions = append(actions, business.EMoneyAction{
+				EMoneyActionType: business.EMoneyActionTypeGenerateQRCode,
+				Method:           "GET",
+				URL:              d.publicBaseURL.JoinPath("e-money", id, "qr-code").String(),
+			})
+		}
 
-			payload, err := d.buildExpiredWebhookMessage(expiredWebhookParameters{
-				TransactionTime: transactionTime,
-				GrossAmount:     totalAmount,
-				OrderId:         request.OrderId,
-				PaymentType:     request.PaymentType,
+		if request.PaymentType == primitive.PaymentTypeEMoneyGopay || request.PaymentType == primitive.PaymentTypeEMoneyShopeePay {
+			actions = append(actions, business.EMoneyAction{
+				EMoneyActionType: business.EMoneyActionTypeDeeplinkRedirect,
+				Method:           "GET",
+				URL:              d.publicBaseURL.JoinPath("e-money", id, "pay").String(),
 			})
-			if err != nil {
-				log.Err(err).Msg("building expired webhook message")
-				return
-			}
+		}
 
-			err = d.webhookClient.Send(ctx, payload)
-			if err != nil {
-				log.Err(err).Msg("building expired webhook message")
-				return
+		actions = append(
+			actions,
+			business.EMoneyAction{
+				EMoneyActionType: business.EMoneyActionTypeStatus,
+				Method:           "GET",
+				URL:              d.publicBaseURL.JoinPath("e-money", id, "status").String(),
+			},
+			business.EMoneyAction{
+				EMoneyActionType: business.EMoneyActionTypeCancel,
+				Method:           "POST",
+				URL:              d.publicBaseURL.JoinPath("e-money", id, "cancel").String(),
+			},
+		)
+
+		return business.ChargeResponse{
+			OrderId:              request.OrderId,
+			TransactionAmount:    request.TransactionAmount,
+			PaymentType:          request.PaymentType,
+			TransactionStatus:    primitive.TransactionStatusPending,
+			TransactionTime:      time.Now(),
+			ExpiresAt:            expiredAt,
+			EMoneyAction:         actions,
+			VirtualAccountAction: business.VirtualAccountAction{},
+			QRString:             qrString,
+		}, nil
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		// Create new transaction
+		expiredAt := chargeExpiresAt(request, transactionTime, time.Hour*24)
+		err := d.transactionRepository.Create(
+			ctx,
+			repository.CreateTransactionParam{
+				OrderID:          request.OrderId,
+				Amount:           request.TransactionAmount,
+				PaymentType:      request.PaymentType,
+				Status:           primitive.TransactionStatusPending,
+				ExpiredAt:        expiredAt,
+				Source:           primitive.StatusChangeSourceCharge,
+				Actor:            primitive.StatusChangeActorMerchant,
+				NotificationURLs: request.NotificationURLs,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, repository.ErrDuplicate) {
+				return business.ChargeResponse{}, business.ErrDuplicateOrderId
 			}
 
-			log.Info().Bytes("payload", payload).Msg("sent a webhook")
-		}(expiredAt, request.OrderId)
+			return business.ChargeResponse{}, fmt.Errorf("creating new transaction: %w", err)
+		}
+
+		// Create a convenience store entry
+		paymentCode, err := d.cstoreRepository.CreateCharge(
+			ctx,
+			request.OrderId,
+			request.TransactionAmount,
+			expiredAt,
+		)
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("creating cstore entry: %w", err)
+		}
+
+		// Send a PENDING webhook
+		payload, err := d.buildPendingWebhookMessage(pendingWebhookParameters{
+			TransactionTime: transactionTime,
+			GrossAmount:     totalAmount,
+			OrderId:         request.OrderId,
+			PaymentType:     request.PaymentType,
+			PaymentCode:     paymentCode,
+		})
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("building pending webhook message: %w", err)
+		}
+
+		d.notifier.Send(ctx, request.OrderId, payload, time.Now().Add(pendingWebhookDelay))
 
 		return business.ChargeResponse{
 			OrderId:           request.OrderId,
@@ -165,27 +319,29 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 			PaymentType:       request.PaymentType,
 			TransactionStatus: primitive.TransactionStatusPending,
 			TransactionTime:   time.Now(),
+			ExpiresAt:         expiredAt,
 			EMoneyAction:      []business.EMoneyAction{},
-			VirtualAccountAction: business.VirtualAccountAction{
-				Bank:                 request.PaymentType.String(),
-				VirtualAccountNumber: virtualAccountNumber,
+			CStoreAction: business.CStoreAction{
+				Store:       request.PaymentType.ToStore(),
+				PaymentCode: paymentCode,
 			},
 		}, nil
-	case primitive.PaymentTypeEMoneyQRIS:
+	case primitive.PaymentTypePaylaterAkulaku:
 		fallthrough
-	case primitive.PaymentTypeEMoneyGopay:
-		fallthrough
-	case primitive.PaymentTypeEMoneyShopeePay:
+	case primitive.PaymentTypePaylaterKredivo:
 		// Create new transaction
-		expiredAt := time.Now().Add(time.Hour * 3)
+		expiredAt := chargeExpiresAt(request, transactionTime, time.Hour*24)
 		err := d.transactionRepository.Create(
 			ctx,
 			repository.CreateTransactionParam{
-				OrderID:     request.OrderId,
-				Amount:      request.TransactionAmount,
-				PaymentType: request.PaymentType,
-				Status:      primitive.TransactionStatusPending,
-				ExpiredAt:   expiredAt,
+				OrderID:          request.OrderId,
+				Amount:           request.TransactionAmount,
+				PaymentType:      request.PaymentType,
+				Status:           primitive.TransactionStatusPending,
+				ExpiredAt:        expiredAt,
+				Source:           primitive.StatusChangeSourceCharge,
+				Actor:            primitive.StatusChangeActorMerchant,
+				NotificationURLs: request.NotificationURLs,
 			},
 		)
 		if err != nil {
@@ -196,7 +352,8 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 			return business.ChargeResponse{}, fmt.Errorf("creating new transaction: %w", err)
 		}
 
-		// Create e-money entry
+		// Paylater is paid through a redirect just like e-money, so it shares the e-money
+		// entry for its ID and expiry
 		id, err := d.emoneyRepository.CreateCharge(
 			ctx,
 			request.OrderId,
@@ -204,87 +361,162 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 			expiredAt,
 		)
 		if err != nil {
-			return business.ChargeResponse{}, fmt.Errorf("creating e-money entry: %w", err)
+			return business.ChargeResponse{}, fmt.Errorf("creating paylater entry: %w", err)
 		}
 
-		go func() {
-			// Send a PENDING webhook
-			log := zerolog.Ctx(ctx)
+		// Send a PENDING webhook
+		payload, err := d.buildPendingWebhookMessage(pendingWebhookParameters{
+			TransactionTime: transactionTime,
+			GrossAmount:     totalAmount,
+			OrderId:         request.OrderId,
+			PaymentType:     request.PaymentType,
+		})
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("building pending webhook message: %w", err)
+		}
 
-			payload, err := d.buildPendingWebhookMessage(pendingWebhookParameters{
-				TransactionTime:      transactionTime,
-				GrossAmount:          totalAmount,
-				OrderId:              request.OrderId,
-				PaymentType:          request.PaymentType,
-				VirtualAccountNumber: "",
-			})
-			if err != nil {
-				log.Err(err).Msg("building pending webhook message")
-				return
+		d.notifier.Send(ctx, request.OrderId, payload, time.Now().Add(pendingWebhookDelay))
+
+		return business.ChargeResponse{
+			OrderId:           request.OrderId,
+			TransactionAmount: request.TransactionAmount,
+			PaymentType:       request.PaymentType,
+			TransactionStatus: primitive.TransactionStatusPending,
+			TransactionTime:   time.Now(),
+			ExpiresAt:         expiredAt,
+			EMoneyAction:      []business.EMoneyAction{},
+			PaylaterAction: business.PaylaterAction{
+				Id:          id,
+				RedirectURL: d.publicBaseURL.JoinPath("paylater", id).String(),
+			},
+		}, nil
+	case primitive.PaymentTypeCreditCard:
+		cardToken, err := d.cardTokenRepository.GetByTokenId(ctx, request.CreditCardOptions.TokenId)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrExpired) {
+				return business.ChargeResponse{}, business.ErrInvalidCardToken
 			}
 
-			// Sleep for 10 seconds to make sure client has received the response
-			time.Sleep(time.Second * 10)
+			return business.ChargeResponse{}, fmt.Errorf("acquiring card token: %w", err)
+		}
 
-			ctx := context.Background()
+		// A regular token is only good for a single charge
+		if !cardToken.Saved && cardToken.Used {
+			return business.ChargeResponse{}, business.ErrInvalidCardToken
+		}
 
-			err = d.webhookClient.Send(ctx, payload)
-			if err != nil {
-				log.Err(err).Msg("sending webhook")
-				return
+		// Save the card if requested, a saved token can simply be reused
+		savedToken := primitive.CardToken{}
+		if request.CreditCardOptions.SaveTokenId {
+			savedToken = cardToken
+			if !cardToken.Saved {
+				savedToken, err = d.cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+					MaskedCard:    cardToken.MaskedCard,
+					Outcome:       cardToken.Outcome,
+					Saved:         true,
+					CardExpiresAt: cardToken.CardExpiresAt,
+					ExpiresAt:     cardToken.CardExpiresAt,
+				})
+				if err != nil {
+					return business.ChargeResponse{}, fmt.Errorf("creating saved card token: %w", err)
+				}
 			}
+		}
 
-			log.Info().Bytes("payload", payload).Msg("sent a webhook")
-		}()
+		// The merchant has to review the charge before it goes through, on top of 3-D Secure
+		outcome := cardToken.Outcome
+		if outcome == primitive.CardOutcomeAccept && d.fraudChallenge.Matches(request.Customer.Email, request.TransactionAmount) {
+			outcome = primitive.CardOutcomeChallenge
+		}
 
-		go func(expiresAt time.Time, orderId string) {
-			// Send a EXPIRED webhook
-			log := zerolog.Ctx(ctx)
+		bank := request.CreditCardOptions.Bank
+		if bank == "" {
+			bank = "bni"
+		}
 
-			time.Sleep(time.Until(expiredAt))
-			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-			defer cancel()
+		transactionType := request.CreditCardOptions.Type
+		if transactionType == primitive.CardTransactionTypeUnspecified {
+			transactionType = primitive.CardTransactionTypeAuthorizeCapture
+		}
 
-			transaction, err := d.transactionRepository.GetByOrderId(ctx, orderId)
-			if err != nil {
-				log.Err(err).Str("orderId", orderId).Msg("acquiring transaction by order id")
-				return
+		// Create new transaction, the customer has got 15 minutes to go through 3-D Secure
+		expiredAt := chargeExpiresAt(request, transactionTime, time.Minute*15)
+		err = d.transactionRepository.Create(
+			ctx,
+			repository.CreateTransactionParam{
+				OrderID:          request.OrderId,
+				Amount:           request.TransactionAmount,
+				PaymentType:      request.PaymentType,
+				Status:           primitive.TransactionStatusPending,
+				ExpiredAt:        expiredAt,
+				Source:           primitive.StatusChangeSourceCharge,
+				Actor:            primitive.StatusChangeActorMerchant,
+				NotificationURLs: request.NotificationURLs,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, repository.ErrDuplicate) {
+				return business.ChargeResponse{}, business.ErrDuplicateOrderId
 			}
 
-			if transaction.TransactionStatus != primitive.TransactionStatusPending {
-				// Do nothing
-				return
-			}
+			return business.ChargeResponse{}, fmt.Errorf("creating new transaction: %w", err)
+		}
 
-			// Update transaction status to expired
-			err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusExpired)
+		// The token is only used up once the transaction exists, so a charge that is rejected
+		// (for example for a duplicate order ID) can be retried with the same token
+		if !cardToken.Saved {
+			err = d.cardTokenRepository.MarkUsed(ctx, cardToken.TokenId)
 			if err != nil {
-				log.Err(err).Str("orderId", orderId).Msg("updating transaction status to expired")
-				return
-			}
+				if !errors.Is(err, repository.ErrNotFound) {
+					return business.ChargeResponse{}, fmt.Errorf("marking card token as used: %w", err)
+				}
 
-			// Send webhook
-			ctx = context.Background()
+				// Another charge used the token in the meantime, this one can't go through
+				err = d.transactionRepository.UpdateStatus(ctx, request.OrderId, primitive.TransactionStatusFailure, primitive.StatusChangeSourceCharge, primitive.StatusChangeActorSystem)
+				if err != nil {
+					return business.ChargeResponse{}, fmt.Errorf("updating transaction status: %w", err)
+				}
 
-			payload, err := d.buildExpiredWebhookMessage(expiredWebhookParameters{
-				TransactionTime: transactionTime,
-				GrossAmount:     totalAmount,
-				OrderId:         request.OrderId,
-				PaymentType:     request.PaymentType,
-			})
-			if err != nil {
-				log.Err(err).Msg("building expired webhook message")
-				return
+				return business.ChargeResponse{}, business.ErrInvalidCardToken
 			}
+		}
 
-			err = d.webhookClient.Send(ctx, payload)
-			if err != nil {
-				log.Err(err).Msg("building expired webhook message")
-				return
-			}
+		// Create credit card entry
+		creditCardCharge, err := d.creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{
+			OrderId:      request.OrderId,
+			TokenId:      cardToken.TokenId,
+			MaskedCard:   cardToken.MaskedCard,
+			Bank:         bank,
+			CardType:     "credit",
+			ApprovalCode: strconv.FormatInt(transactionTime.UnixMilli(), 10),
+			Outcome:      outcome,
+			Amount:       request.TransactionAmount,
+			ExpiresAt:    expiredAt,
+			// Authorized charges must be captured later by the merchant
+			TransactionType: transactionType,
+			// Saved token is empty if the card was not requested to be saved
+			SavedTokenId:          savedToken.TokenId,
+			SavedTokenIdExpiresAt: savedToken.ExpiresAt,
+		})
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("creating credit card entry: %w", err)
+		}
+
+		// Send a PENDING webhook
+		payload, err := d.buildPendingWebhookMessage(pendingWebhookParameters{
+			TransactionTime: transactionTime,
+			GrossAmount:     totalAmount,
+			OrderId:         request.OrderId,
+			PaymentType:     request.PaymentType,
+			MaskedCard:      creditCardCharge.MaskedCard,
+			Bank:            creditCardCharge.Bank,
+			CardType:        creditCardCharge.CardType,
+		})
+		if err != nil {
+			return business.ChargeResponse{}, fmt.Errorf("building pending webhook message: %w", err)
+		}
 
-			log.Info().Bytes("payload", payload).Msg("sent a webhook")
-		}(expiredAt, request.OrderId)
+		d.notifier.Send(ctx, request.OrderId, payload, time.Now().Add(pendingWebhookDelay))
 
 		return business.ChargeResponse{
 			OrderId:           request.OrderId,
@@ -292,24 +524,18 @@ func (d *Dependency) Charge(ctx context.Context, request business.ChargeRequest)
 			PaymentType:       request.PaymentType,
 			TransactionStatus: primitive.TransactionStatusPending,
 			TransactionTime:   time.Now(),
-			EMoneyAction: []business.EMoneyAction{
-				{
-					EMoneyActionType: business.EMoneyActionTypeGenerateQRCode,
-					Method:           "GET",
-					URL:              "/e-money/" + id + "/pay",
-				},
-				{
-					EMoneyActionType: business.EMoneyActionTypeStatus,
-					Method:           "GET",
-					URL:              "/e-money/" + id + "/status",
-				},
-				{
-					EMoneyActionType: business.EMoneyActionTypeCancel,
-					Method:           "POST",
-					URL:              "/e-money/" + id + "/cancel",
-				},
+			ExpiresAt:         expiredAt,
+			EMoneyAction:      []business.EMoneyAction{},
+			CreditCardAction: business.CreditCardAction{
+				Id:          creditCardCharge.Id,
+				RedirectURL: d.publicBaseURL.JoinPath("3ds", creditCardCharge.Id).String(),
+				MaskedCard:  creditCardCharge.MaskedCard,
+				Bank:        creditCardCharge.Bank,
+				CardType:    creditCardCharge.CardType,
+				// Saved token
+				SavedTokenId:          creditCardCharge.SavedTokenId,
+				SavedTokenIdExpiresAt: creditCardCharge.SavedTokenIdExpiresAt,
 			},
-			VirtualAccountAction: business.VirtualAccountAction{},
 		}, nil
 	case primitive.PaymentTypeUnspecified:
 		fallthrough
@@ -330,6 +556,15 @@ func ValidateChargeRequest(request business.ChargeRequest) *business.RequestVali
 		})
 	}
 
+	// validate credit_card.token_id
+	if request.PaymentType == primitive.PaymentTypeCreditCard && request.CreditCardOptions.TokenId == "" {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeRequired,
+			Field:   "credit_card.token_id",
+			Message: "can not be empty",
+		})
+	}
+
 	// validate transaction.order_id
 	if request.OrderId == "" {
 		issues = append(issues, business.RequestValidationIssue{
@@ -759,6 +994,90 @@ func ValidateChargeRequest(request business.ChargeRequest) *business.RequestVali
 		}
 	}
 
+	// validate custom_expiry
+	if request.CustomExpiry != nil {
+		if request.CustomExpiry.ExpiryDuration <= 0 {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "custom_expiry.expiry_duration",
+				Message: "must be greater than 0",
+			})
+		}
+
+		if request.CustomExpiry.Unit == primitive.ExpiryUnitUnspecified {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "custom_expiry.unit",
+				Message: "must be one of second, minute, hour, or day",
+			})
+		}
+
+		if request.CustomExpiry.ExpiryDuration > 0 &&
+			request.CustomExpiry.Unit != primitive.ExpiryUnitUnspecified &&
+			!request.CustomExpiry.ExpiresAt(time.Now()).After(time.Now()) {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "custom_expiry.order_time",
+				Message: "must not be expired already",
+			})
+		}
+	}
+
+	// validate bank_transfer.va_number
+	if maximumLength, ok := virtualAccountNumberLengths[request.PaymentType]; ok && request.BankTransferOptions.VirtualAccountNumber != "" {
+		if !isNumeric(request.BankTransferOptions.VirtualAccountNumber) {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "bank_transfer.va_number",
+				Message: "must be numeric",
+			})
+		}
+
+		if len(request.BankTransferOptions.VirtualAccountNumber) > maximumLength {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeTooLong,
+				Field:   "bank_transfer.va_number",
+				Message: fmt.Sprintf("maximum of %d digits", maximumLength),
+			})
+		}
+	}
+
+	// validate bca.sub_company_code
+	if request.PaymentType == primitive.PaymentTypeVirtualAccountBCA && request.BankTransferOptions.SubCompanyCode != "" {
+		if !isNumeric(request.BankTransferOptions.SubCompanyCode) {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "bca.sub_company_code",
+				Message: "must be numeric",
+			})
+		}
+
+		if len(request.BankTransferOptions.SubCompanyCode) > 5 {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeTooLong,
+				Field:   "bca.sub_company_code",
+				Message: "maximum of 5 digits",
+			})
+		}
+	}
+
+	// validate bank_transfer.permata.recipient_name
+	if len(request.BankTransferOptions.RecipientName) > 20 {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeTooLong,
+			Field:   "bank_transfer.permata.recipient_name",
+			Message: "maximum of 20 characters length",
+		})
+	}
+
+	// validate bank_transfer.free_text
+	issues = append(issues, validateFreeText("bank_transfer.free_text.inquiry", request.BankTransferOptions.FreeText.Inquiry)...)
+	issues = append(issues, validateFreeText("bank_transfer.free_text.payment", request.BankTransferOptions.FreeText.Payment)...)
+
+	// validate X-Override-Notification and X-Append-Notification
+	issues = append(issues, validateNotificationURLs("X-Override-Notification", request.NotificationURLs.Override)...)
+	issues = append(issues, validateNotificationURLs("X-Append-Notification", request.NotificationURLs.Append)...)
+
 	if len(issues) > 0 {
 		return &business.RequestValidationError{Issues: issues}
 	}
@@ -766,12 +1085,103 @@ func ValidateChargeRequest(request business.ChargeRequest) *business.RequestVali
 	return nil
 }
 
+func validateNotificationURLs(header string, urls []string) []business.RequestValidationIssue {
+	var issues []business.RequestValidationIssue
+	if len(urls) > primitive.MaximumNotificationURLs {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeTooLong,
+			Field:   header,
+			Message: fmt.Sprintf("maximum of %d urls", primitive.MaximumNotificationURLs),
+		})
+	}
+
+	for _, notificationUrl := range urls {
+		parsedUrl, err := url.Parse(notificationUrl)
+		if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   header,
+				Message: "must be a valid http or https url",
+			})
+		}
+	}
+
+	return issues
+}
+
+// virtualAccountNumberLengths is the most digits a merchant can use for their own virtual
+// account number, for each bank.
+var virtualAccountNumberLengths = map[primitive.PaymentType]int{
+	primitive.PaymentTypeVirtualAccountBCA:     11,
+	primitive.PaymentTypeVirtualAccountPermata: 10,
+	primitive.PaymentTypeVirtualAccountBRI:     13,
+	primitive.PaymentTypeVirtualAccountBNI:     12,
+	primitive.PaymentTypeVirtualAccountCIMB:    16,
+	primitive.PaymentTypeVirtualAccountBSI:     16,
+	primitive.PaymentTypeMandiriBill:           12,
+}
+
+func isNumeric(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func validateFreeText(field string, lines []primitive.FreeTextLine) []business.RequestValidationIssue {
+	var issues []business.RequestValidationIssue
+	if len(lines) > primitive.MaximumFreeTextLines {
+		issues = append(issues, business.RequestValidationIssue{
+			Code:    business.RequestValidationCodeTooLong,
+			Field:   field,
+			Message: fmt.Sprintf("maximum of %d lines", primitive.MaximumFreeTextLines),
+		})
+	}
+
+	for _, line := range lines {
+		if line.Indonesian == "" || line.English == "" {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeRequired,
+				Field:   field,
+				Message: "must have both id and english text",
+			})
+		}
+
+		if len(line.Indonesian) > primitive.MaximumFreeTextLength || len(line.English) > primitive.MaximumFreeTextLength {
+			issues = append(issues, business.RequestValidationIssue{
+				Code:    business.RequestValidationCodeTooLong,
+				Field:   field,
+				Message: fmt.Sprintf("maximum of %d characters length", primitive.MaximumFreeTextLength),
+			})
+		}
+	}
+
+	return issues
+}
+
+// chargeExpiresAt returns the custom expiry of the request, or defaultExpiry of the
+// payment type counted from the time of the charge.
+func chargeExpiresAt(request business.ChargeRequest, transactionTime time.Time, defaultExpiry time.Duration) time.Time {
+	if request.CustomExpiry != nil {
+		return request.CustomExpiry.ExpiresAt(transactionTime)
+	}
+
+	return transactionTime.Add(defaultExpiry)
+}
+
 type pendingWebhookParameters struct {
 	TransactionTime      time.Time
 	GrossAmount          int64
 	OrderId              string
 	PaymentType          primitive.PaymentType
 	VirtualAccountNumber string
+	PaymentCode          string
+	MaskedCard           string
+	Bank                 string
+	CardType             string
 }
 
 func (d *Dependency) buildPendingWebhookMessage(parameters pendingWebhookParameters) ([]byte, error) {
@@ -843,6 +1253,103 @@ func (d *Dependency) buildPendingWebhookMessage(parameters pendingWebhookParamet
 			FraudStatus:       "accept",
 			StatusMessage:     "midtrans payment notification",
 		})
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		return json.Marshal(schema.CIMBVirtualAccountChargePendingResponse{
+			VaNumbers: []struct {
+				Bank     string `json:"bank"`
+				VaNumber string `json:"va_number"`
+			}{
+				{
+					Bank:     "cimb",
+					VaNumber: parameters.VirtualAccountNumber,
+				},
+			},
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			OrderId:           parameters.OrderId,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			SignatureKey:      signatureKey,
+			StatusCode:        "200",
+			TransactionId:     parameters.OrderId,
+			TransactionStatus: primitive.TransactionStatusPending.String(),
+			FraudStatus:       "accept",
+			StatusMessage:     "midtrans payment notification",
+		})
+	case primitive.PaymentTypeVirtualAccountBSI:
+		return json.Marshal(schema.BSIVirtualAccountChargePendingResponse{
+			VaNumbers: []struct {
+				Bank     string `json:"bank"`
+				VaNumber string `json:"va_number"`
+			}{
+				{
+					Bank:     "bsi",
+					VaNumber: parameters.VirtualAccountNumber,
+				},
+			},
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			OrderId:           parameters.OrderId,
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			SignatureKey:      signatureKey,
+			StatusCode:        "200",
+			TransactionId:     parameters.OrderId,
+			TransactionStatus: primitive.TransactionStatusPending.String(),
+			FraudStatus:       "accept",
+			StatusMessage:     "midtrans payment notification",
+		})
+	case primitive.PaymentTypeMandiriBill:
+		return json.Marshal(schema.MandiriBillChargePendingResponse{
+			StatusCode:        "201",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusPending.String(),
+			FraudStatus:       "accept",
+			BillKey:           parameters.VirtualAccountNumber,
+			BillerCode:        primitive.MandiriBillerCode,
+			SignatureKey:      signature.Generate(parameters.OrderId, 201, parameters.GrossAmount, d.serverKey),
+		})
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		return json.Marshal(schema.CStoreChargePendingResponse{
+			StatusCode:        "201",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusPending.String(),
+			FraudStatus:       "accept",
+			PaymentCode:       parameters.PaymentCode,
+			Store:             parameters.PaymentType.ToStore(),
+			SignatureKey:      signature.Generate(parameters.OrderId, 201, parameters.GrossAmount, d.serverKey),
+		})
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		return json.Marshal(schema.PaylaterChargePendingResponse{
+			StatusCode:        "201",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusPending.String(),
+			FraudStatus:       "accept",
+			SignatureKey:      signature.Generate(parameters.OrderId, 201, parameters.GrossAmount, d.serverKey),
+		})
 	case primitive.PaymentTypeVirtualAccountPermata:
 		return json.Marshal(schema.PermataVirtualAccountChargePendingResponse{
 			StatusCode:        "200",
@@ -900,6 +1407,24 @@ func (d *Dependency) buildPendingWebhookMessage(parameters pendingWebhookParamet
 			FraudStatus:       "accept",
 			Currency:          "IDR",
 		})
+	case primitive.PaymentTypeCreditCard:
+		return json.Marshal(schema.CreditCardChargePendingResponse{
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusPending.String(),
+			TransactionId:     parameters.OrderId,
+			StatusMessage:     "midtrans payment notification",
+			StatusCode:        "201",
+			SignatureKey:      signature.Generate(parameters.OrderId, 201, parameters.GrossAmount, d.serverKey),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			MaskedCard:        parameters.MaskedCard,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:       "accept",
+			Currency:          "IDR",
+			CardType:          parameters.CardType,
+			Bank:              parameters.Bank,
+		})
 	default:
 		return nil, fmt.Errorf("unknown payment type")
 	}
@@ -910,6 +1435,11 @@ type expiredWebhookParameters struct {
 	GrossAmount     int64
 	OrderId         string
 	PaymentType     primitive.PaymentType
+	BillKey         string
+	PaymentCode     string
+	MaskedCard      string
+	Bank            string
+	CardType        string
 }
 
 func (d *Dependency) buildExpiredWebhookMessage(parameters expiredWebhookParameters) ([]byte, error) {
@@ -923,9 +1453,9 @@ func (d *Dependency) buildExpiredWebhookMessage(parameters expiredWebhookParamet
 			TransactionId:     parameters.OrderId,
 			OrderId:           parameters.OrderId,
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
-			PaymentType:       parameters.PaymentType.String(),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusExpired.String(),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
 			SignatureKey:      signatureKey,
 		})
 	case primitive.PaymentTypeVirtualAccountBRI:
@@ -935,9 +1465,9 @@ func (d *Dependency) buildExpiredWebhookMessage(parameters expiredWebhookParamet
 			TransactionId:     parameters.OrderId,
 			OrderId:           parameters.OrderId,
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
-			PaymentType:       parameters.PaymentType.String(),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusExpired.String(),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
 			SignatureKey:      signatureKey,
 		})
 	case primitive.PaymentTypeVirtualAccountBNI:
@@ -947,9 +1477,77 @@ func (d *Dependency) buildExpiredWebhookMessage(parameters expiredWebhookParamet
 			TransactionId:     parameters.OrderId,
 			OrderId:           parameters.OrderId,
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
-			PaymentType:       parameters.PaymentType.String(),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		return json.Marshal(schema.CIMBVirtualAccountChargeExpiredResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeVirtualAccountBSI:
+		return json.Marshal(schema.BSIVirtualAccountChargeExpiredResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeMandiriBill:
+		return json.Marshal(schema.MandiriBillChargeExpiredResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusExpired.String(),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
+			BillKey:           parameters.BillKey,
+			BillerCode:        primitive.MandiriBillerCode,
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		return json.Marshal(schema.CStoreChargeExpiredResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
+			PaymentCode:       parameters.PaymentCode,
+			Store:             parameters.PaymentType.ToStore(),
+			SignatureKey:      signatureKey,
+		})
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		return json.Marshal(schema.PaylaterChargeExpiredResponse{
+			StatusCode:        "200",
+			StatusMessage:     "midtrans payment notification",
+			TransactionId:     parameters.OrderId,
+			OrderId:           parameters.OrderId,
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
 			SignatureKey:      signatureKey,
 		})
 	case primitive.PaymentTypeVirtualAccountPermata:
@@ -959,20 +1557,20 @@ func (d *Dependency) buildExpiredWebhookMessage(parameters expiredWebhookParamet
 			TransactionId:     parameters.OrderId,
 			OrderId:           parameters.OrderId,
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
-			PaymentType:       parameters.PaymentType.String(),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusExpired.String(),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
 			SignatureKey:      signatureKey,
 		})
 	case primitive.PaymentTypeEMoneyQRIS:
 		return json.Marshal(schema.QRISChargeExpiredResponse{
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusExpired.String(),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
 			TransactionId:     parameters.OrderId,
 			StatusMessage:     "midtrans payment notification",
 			StatusCode:        "200",
 			SignatureKey:      signatureKey,
-			PaymentType:       parameters.PaymentType.String(),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			OrderId:           parameters.OrderId,
 			MerchantId:        "MOCK",
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
@@ -987,25 +1585,43 @@ func (d *Dependency) buildExpiredWebhookMessage(parameters expiredWebhookParamet
 			TransactionId:     parameters.OrderId,
 			OrderId:           parameters.OrderId,
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
-			PaymentType:       parameters.PaymentType.String(),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusExpired.String(),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
 			SignatureKey:      signatureKey,
 		})
 	case primitive.PaymentTypeEMoneyShopeePay:
 		return json.Marshal(schema.ShopeePayChargeExpiredResponse{
 			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
-			TransactionStatus: primitive.TransactionStatusExpired.String(),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
 			TransactionId:     parameters.OrderId,
 			StatusMessage:     "midtrans payment notification",
 			StatusCode:        "200",
 			SignatureKey:      signatureKey,
-			PaymentType:       parameters.PaymentType.String(),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+			OrderId:           parameters.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:       "accept",
+			Currency:          "IDR",
+		})
+	case primitive.PaymentTypeCreditCard:
+		return json.Marshal(schema.CreditCardChargeExpiredResponse{
+			TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus: primitive.TransactionStatusExpire.String(),
+			TransactionId:     parameters.OrderId,
+			StatusMessage:     "midtrans payment notification",
+			StatusCode:        "202",
+			SignatureKey:      signature.Generate(parameters.OrderId, 202, parameters.GrossAmount, d.serverKey),
+			PaymentType:       parameters.PaymentType.ToPaymentMethod(),
 			OrderId:           parameters.OrderId,
 			MerchantId:        "MOCK",
+			MaskedCard:        parameters.MaskedCard,
 			GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
 			FraudStatus:       "accept",
 			Currency:          "IDR",
+			CardType:          parameters.CardType,
+			Bank:              parameters.Bank,
 		})
 	default:
 		return nil, fmt.Errorf("unknown payment type")
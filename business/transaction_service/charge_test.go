@@ -1,13 +1,177 @@
 package transaction_service_test
 
 import (
+	"context"
 	"errors"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"mock-payment-provider/business"
 	"mock-payment-provider/business/transaction_service"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
 )
 
+func TestDependency_Charge(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cardChargeRequest := func(tokenId string) business.ChargeRequest {
+		return business.ChargeRequest{
+			PaymentType:         primitive.PaymentTypeCreditCard,
+			OrderId:             uuid.NewString(),
+			TransactionAmount:   50000,
+			TransactionCurrency: primitive.CurrencyIDR,
+			Customer: business.CustomerInformation{
+				FirstName:   "tony",
+				LastName:    "stark",
+				Email:       "tonystark01@email.com",
+				PhoneNumber: "+62123456789",
+				BillingAddress: business.Address{
+					FirstName:   "tony",
+					LastName:    "stark",
+					Email:       "tonystark01@email.com",
+					Phone:       "+628123456789",
+					Address:     "Jl. Kenangan",
+					PostalCode:  "55123",
+					CountryCode: "62",
+				},
+			},
+			Seller: business.SellerInformation{
+				FirstName:   "tom",
+				LastName:    "holland",
+				Email:       "tomholland01@email.com",
+				PhoneNumber: "+62123456780",
+				Address:     "Jl. Nin Aja Dulu",
+			},
+			ProductItems: []business.ProductItem{
+				{
+					ID:       "A123",
+					Price:    50000,
+					Quantity: 1,
+					Name:     "Keyboard",
+					Category: "Electronic",
+				},
+			},
+			CreditCardOptions: business.CreditCardOptions{
+				TokenId: tokenId,
+			},
+		}
+	}
+
+	t.Run("Reused Card Token", func(t *testing.T) {
+		cardToken, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "481111-1114",
+			Outcome:       primitive.CardOutcomeAccept,
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().Add(time.Minute * 10),
+		})
+		if err != nil {
+			t.Fatalf("creating card token: %s", err.Error())
+		}
+
+		_, err = transactionService.Charge(ctx, cardChargeRequest(cardToken.TokenId))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		_, err = transactionService.Charge(ctx, cardChargeRequest(cardToken.TokenId))
+		if !errors.Is(err, business.ErrInvalidCardToken) {
+			t.Errorf("expecting an error of ErrInvalidCardToken, instead got %v", err)
+		}
+	})
+
+	t.Run("Duplicate Order Id", func(t *testing.T) {
+		orderId := createCardTransaction(t, ctx, primitive.TransactionStatusPending, primitive.CardOutcomeAccept)
+
+		cardToken, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "481111-1114",
+			Outcome:       primitive.CardOutcomeAccept,
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().Add(time.Minute * 10),
+		})
+		if err != nil {
+			t.Fatalf("creating card token: %s", err.Error())
+		}
+
+		request := cardChargeRequest(cardToken.TokenId)
+		request.OrderId = orderId
+		_, err = transactionService.Charge(ctx, request)
+		if !errors.Is(err, business.ErrDuplicateOrderId) {
+			t.Fatalf("expecting an error of ErrDuplicateOrderId, instead got %v", err)
+		}
+
+		// The rejected charge didn't use up the token, so the merchant can retry with it
+		_, err = transactionService.Charge(ctx, cardChargeRequest(cardToken.TokenId))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("Saved Card Token", func(t *testing.T) {
+		cardToken, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "481111-1114",
+			Outcome:       primitive.CardOutcomeAccept,
+			Saved:         true,
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().AddDate(1, 0, 0),
+		})
+		if err != nil {
+			t.Fatalf("creating card token: %s", err.Error())
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err = transactionService.Charge(ctx, cardChargeRequest(cardToken.TokenId))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		}
+	})
+
+	// The merchant verifies a notification by signing its own status code and gross amount
+	t.Run("Pending Webhook Signature", func(t *testing.T) {
+		for _, paymentType := range []primitive.PaymentType{
+			primitive.PaymentTypeVirtualAccountBCA,
+			primitive.PaymentTypeMandiriBill,
+			primitive.PaymentTypeCStoreIndomaret,
+			primitive.PaymentTypeCStoreAlfamart,
+			primitive.PaymentTypePaylaterAkulaku,
+			primitive.PaymentTypePaylaterKredivo,
+		} {
+			request := cardChargeRequest("")
+			request.PaymentType = paymentType
+
+			_, err := transactionService.Charge(ctx, request)
+			if err != nil {
+				t.Fatalf("unexpected error charging %s: %s", paymentType, err.Error())
+			}
+
+			payloads := outboxPayloads(t, ctx, request.OrderId)
+			if len(payloads) != 1 {
+				t.Fatalf("expecting 1 pending webhook of %s, instead got %d", paymentType, len(payloads))
+			}
+
+			statusCode, err := strconv.Atoi(payloads[0].StatusCode)
+			if err != nil {
+				t.Fatalf("parsing status code of %s: %s", paymentType, err.Error())
+			}
+
+			grossAmount, err := strconv.ParseInt(payloads[0].GrossAmount, 10, 64)
+			if err != nil {
+				t.Fatalf("parsing gross amount of %s: %s", paymentType, err.Error())
+			}
+
+			expected := signature.Generate(payloads[0].OrderId, statusCode, grossAmount, "server-key")
+			if payloads[0].SignatureKey != expected {
+				t.Errorf("expecting the pending webhook of %s to be signed with status code %s", paymentType, payloads[0].StatusCode)
+			}
+		}
+	})
+}
+
 func TestValidateChargeRequest(t *testing.T) {
 	// provide correct value
 	request := business.ChargeRequest{
@@ -87,6 +251,28 @@ func TestValidateChargeRequest(t *testing.T) {
 		}
 	})
 
+	// test credit_card.token_id
+	t.Run("credit_card.token_id", func(t *testing.T) {
+		// arrange
+		mock := request
+		var requestValidationError *business.RequestValidationError
+
+		t.Run("required", func(t *testing.T) {
+			// credit card payment without token
+			mock.PaymentType = primitive.PaymentTypeCreditCard
+			mock.CreditCardOptions.TokenId = ""
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			// assert
+			if err == nil {
+				t.Errorf("expect errors as *business.RequestValidationError when the given TokenId is empty string, instead got %T", err)
+			}
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError when the given TokenId is empty string, instead got %T", err)
+			}
+		})
+	})
+
 	// test order_id
 	t.Run("order_id", func(t *testing.T) {
 		// arrange
@@ -164,6 +350,209 @@ func TestValidateChargeRequest(t *testing.T) {
 		})
 	})
 
+	// test custom_expiry
+	t.Run("custom_expiry", func(t *testing.T) {
+		// arrange
+		mock := request
+		var requestValidationError *business.RequestValidationError
+
+		t.Run("valid value", func(t *testing.T) {
+			mock.CustomExpiry = &business.CustomExpiry{
+				ExpiryDuration: 1,
+				Unit:           primitive.ExpiryUnitMinute,
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if err != nil {
+				t.Errorf("expect error nil when the given CustomExpiry is valid, but got %v instead", err)
+			}
+		})
+
+		t.Run("expiry_duration greater than 0", func(t *testing.T) {
+			mock.CustomExpiry = &business.CustomExpiry{
+				ExpiryDuration: 0,
+				Unit:           primitive.ExpiryUnitMinute,
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given CustomExpiry.ExpiryDuration is 0, instead got %T", err)
+			}
+		})
+
+		t.Run("invalid unit", func(t *testing.T) {
+			mock.CustomExpiry = &business.CustomExpiry{
+				ExpiryDuration: 60,
+				Unit:           primitive.ExpiryUnitUnspecified,
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given CustomExpiry.Unit is invalid, instead got %T", err)
+			}
+		})
+
+		t.Run("already expired", func(t *testing.T) {
+			mock.CustomExpiry = &business.CustomExpiry{
+				OrderTime:      time.Now().Add(-time.Hour * 2),
+				ExpiryDuration: 1,
+				Unit:           primitive.ExpiryUnitHour,
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given CustomExpiry has already expired, instead got %T", err)
+			}
+		})
+	})
+
+	// test NotificationURLs
+	t.Run("NotificationURLs", func(t *testing.T) {
+		// arrange
+		mock := request
+		var requestValidationError *business.RequestValidationError
+
+		t.Run("valid value", func(t *testing.T) {
+			mock.NotificationURLs = primitive.NotificationURLs{
+				Override: []string{"https://a.example.com/notify", "http://b.example.com/notify"},
+				Append:   []string{"https://c.example.com/notify"},
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if err != nil {
+				t.Errorf("expect error nil when the given NotificationURLs is valid, but got %v instead", err)
+			}
+		})
+
+		t.Run("more than 3 urls", func(t *testing.T) {
+			mock.NotificationURLs = primitive.NotificationURLs{
+				Append: []string{
+					"https://a.example.com/notify",
+					"https://b.example.com/notify",
+					"https://c.example.com/notify",
+					"https://d.example.com/notify",
+				},
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given NotificationURLs.Append has more than 3 urls, instead got %T", err)
+			}
+		})
+
+		t.Run("invalid url", func(t *testing.T) {
+			mock.NotificationURLs = primitive.NotificationURLs{
+				Override: []string{"ftp://a.example.com/notify"},
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given NotificationURLs.Override is not an http url, instead got %T", err)
+			}
+		})
+	})
+
+	// test BankTransferOptions
+	t.Run("BankTransferOptions", func(t *testing.T) {
+		// arrange
+		mock := request
+		mock.PaymentType = primitive.PaymentTypeVirtualAccountBCA
+		var requestValidationError *business.RequestValidationError
+
+		t.Run("valid value", func(t *testing.T) {
+			mock.BankTransferOptions = business.BankTransferOptions{
+				VirtualAccountNumber: "12345678901",
+				RecipientName:        "SUDARSONO",
+				SubCompanyCode:       "00000",
+				FreeText: primitive.FreeText{
+					Inquiry: []primitive.FreeTextLine{{Indonesian: "Tagihan bulan ini", English: "This month's bill"}},
+				},
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if err != nil {
+				t.Errorf("expect error nil when the given BankTransferOptions is valid, but got %v instead", err)
+			}
+		})
+
+		t.Run("va_number not numeric", func(t *testing.T) {
+			mock.BankTransferOptions = business.BankTransferOptions{VirtualAccountNumber: "1234abc"}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given VirtualAccountNumber is not numeric, instead got %T", err)
+			}
+		})
+
+		t.Run("va_number too long", func(t *testing.T) {
+			mock.BankTransferOptions = business.BankTransferOptions{VirtualAccountNumber: "123456789012"}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given VirtualAccountNumber is longer than 11 digits for BCA, instead got %T", err)
+			}
+		})
+
+		t.Run("invalid sub_company_code", func(t *testing.T) {
+			mock.BankTransferOptions = business.BankTransferOptions{SubCompanyCode: "ABC"}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given SubCompanyCode is not numeric, instead got %T", err)
+			}
+		})
+
+		t.Run("recipient_name too long", func(t *testing.T) {
+			mock.BankTransferOptions = business.BankTransferOptions{RecipientName: "SUDARSONO BIN SUDARSONO"}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given RecipientName is longer than 20 characters, instead got %T", err)
+			}
+		})
+
+		t.Run("more than 10 free text lines", func(t *testing.T) {
+			var lines []primitive.FreeTextLine
+			for i := 0; i < 11; i++ {
+				lines = append(lines, primitive.FreeTextLine{Indonesian: "Terima kasih", English: "Thank you"})
+			}
+
+			mock.BankTransferOptions = business.BankTransferOptions{FreeText: primitive.FreeText{Payment: lines}}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given FreeText.Payment has more than 10 lines, instead got %T", err)
+			}
+		})
+
+		t.Run("free text line too long", func(t *testing.T) {
+			mock.BankTransferOptions = business.BankTransferOptions{
+				FreeText: primitive.FreeText{
+					Inquiry: []primitive.FreeTextLine{{
+						Indonesian: "Tagihan ini harus dibayar sebelum tanggal sepuluh bulan depan",
+						English:    "Due on the 10th",
+					}},
+				},
+			}
+			err := transaction_service.ValidateChargeRequest(mock)
+
+			if !errors.As(err, &requestValidationError) {
+				t.Errorf("expect errors as *business.RequestValidationError"+
+					"when the given FreeText.Inquiry line is longer than 50 characters, instead got %T", err)
+			}
+		})
+	})
+
 	// test Customer.FirstName
 	t.Run("Customer.FirstName", func(t *testing.T) {
 		// arrange
@@ -0,0 +1,57 @@
+package transaction_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) Deny(ctx context.Context, orderId string) (business.FraudReviewResponse, error) {
+	transaction, creditCardCharge, err := d.acquireChallengedTransaction(ctx, orderId)
+	if err != nil {
+		return business.FraudReviewResponse{}, err
+	}
+
+	// The challenge may have been resolved by another request since it was acquired
+	err = d.creditCardRepository.DenyChallenge(ctx, orderId, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.Is(err, repository.ErrNotFound) || errors.As(err, &invalidTransitionError) {
+			return business.FraudReviewResponse{}, business.ErrCannotModifyStatus
+		}
+
+		return business.FraudReviewResponse{}, fmt.Errorf("denying credit card challenge: %w", err)
+	}
+	creditCardCharge.Outcome = primitive.CardOutcomeDenyByFraud
+
+	// Send a DENY webhook
+	payload, err := d.buildFraudReviewWebhookMessage(fraudReviewWebhookParameters{
+		TransactionTime:   transaction.TransactionTime,
+		TransactionStatus: primitive.TransactionStatusDeny,
+		GrossAmount:       transaction.TransactionAmount,
+		OrderId:           orderId,
+		CreditCardCharge:  creditCardCharge,
+	})
+	if err != nil {
+		return business.FraudReviewResponse{}, fmt.Errorf("building fraud review webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, orderId, payload, time.Now())
+
+	return business.FraudReviewResponse{
+		OrderId:           orderId,
+		TransactionAmount: transaction.TransactionAmount,
+		PaymentType:       transaction.PaymentType,
+		TransactionStatus: primitive.TransactionStatusDeny,
+		TransactionTime:   transaction.TransactionTime,
+		MaskedCard:        creditCardCharge.MaskedCard,
+		Bank:              creditCardCharge.Bank,
+		ApprovalCode:      creditCardCharge.ApprovalCode,
+		FraudStatus:       primitive.FraudStatusDeny,
+	}, nil
+}
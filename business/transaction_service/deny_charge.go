@@ -0,0 +1,72 @@
+package transaction_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+// denyCharge records the charge as denied without creating any payment entry, since
+// the customer never gets to pay it.
+func (d *Dependency) denyCharge(ctx context.Context, request business.ChargeRequest, transactionTime time.Time) (business.ChargeResponse, error) {
+	err := d.transactionRepository.Create(
+		ctx,
+		repository.CreateTransactionParam{
+			OrderID:          request.OrderId,
+			Amount:           request.TransactionAmount,
+			PaymentType:      request.PaymentType,
+			Status:           primitive.TransactionStatusDeny,
+			ExpiredAt:        transactionTime,
+			Source:           primitive.StatusChangeSourceCharge,
+			Actor:            primitive.StatusChangeActorSystem,
+			NotificationURLs: request.NotificationURLs,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return business.ChargeResponse{}, business.ErrDuplicateOrderId
+		}
+
+		return business.ChargeResponse{}, fmt.Errorf("creating new transaction: %w", err)
+	}
+
+	payload, err := json.Marshal(schema.ChargeDenyResponse{
+		StatusCode:        "202",
+		StatusMessage:     "midtrans payment notification",
+		TransactionId:     request.OrderId,
+		OrderId:           request.OrderId,
+		MerchantId:        "MOCK",
+		GrossAmount:       strconv.FormatInt(request.TransactionAmount, 10),
+		Currency:          "IDR",
+		PaymentType:       request.PaymentType.ToPaymentMethod(),
+		TransactionTime:   transactionTime.Format(time.DateTime),
+		TransactionStatus: primitive.TransactionStatusDeny.String(),
+		FraudStatus:       "accept",
+		SignatureKey:      signature.Generate(request.OrderId, 202, request.TransactionAmount, d.serverKey),
+	})
+	if err != nil {
+		return business.ChargeResponse{}, fmt.Errorf("building deny webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, request.OrderId, payload, time.Now())
+
+	return business.ChargeResponse{
+		OrderId:              request.OrderId,
+		TransactionAmount:    request.TransactionAmount,
+		PaymentType:          request.PaymentType,
+		TransactionStatus:    primitive.TransactionStatusDeny,
+		TransactionTime:      transactionTime,
+		ExpiresAt:            transactionTime,
+		EMoneyAction:         []business.EMoneyAction{},
+		VirtualAccountAction: business.VirtualAccountAction{},
+	}, nil
+}
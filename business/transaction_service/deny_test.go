@@ -0,0 +1,87 @@
+package transaction_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+)
+
+func TestDependency_Deny(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := transactionService.Deny(ctx, "deny-not-found")
+		if !errors.Is(err, business.ErrTransactionNotFound) {
+			t.Errorf("expecting ErrTransactionNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Not Challenged", func(t *testing.T) {
+		orderId := createCardTransaction(t, ctx, primitive.TransactionStatusCapture, primitive.CardOutcomeAccept)
+
+		_, err := transactionService.Deny(ctx, orderId)
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+
+		status, err := transactionService.GetStatus(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring status: %s", err.Error())
+		}
+
+		if status.TransactionStatus != primitive.TransactionStatusCapture {
+			t.Errorf("expecting status to stay %s, instead got %s", primitive.TransactionStatusCapture, status.TransactionStatus)
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		orderId := createCardTransaction(t, ctx, primitive.TransactionStatusAuthorize, primitive.CardOutcomeChallenge)
+
+		response, err := transactionService.Deny(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if response.TransactionStatus != primitive.TransactionStatusDeny || response.FraudStatus != primitive.FraudStatusDeny {
+			t.Errorf("expecting a denied transaction and fraud status, instead got %s and %s", response.TransactionStatus, response.FraudStatus)
+		}
+
+		status, err := transactionService.GetStatus(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring status: %s", err.Error())
+		}
+
+		if status.TransactionStatus != primitive.TransactionStatusDeny || status.FraudStatus != primitive.FraudStatusDeny {
+			t.Errorf("expecting the stored transaction and fraud status to be denied, instead got %s and %s", status.TransactionStatus, status.FraudStatus)
+		}
+
+		notifications := claimNotifications(t, ctx, orderId)
+		if len(notifications) != 1 {
+			t.Fatalf("expecting 1 notification, instead got %d", len(notifications))
+		}
+
+		if notifications[0].TransactionStatus != "deny" || notifications[0].FraudStatus != "deny" || notifications[0].StatusCode != "202" {
+			t.Errorf("expecting a deny notification, instead got %+v", notifications[0])
+		}
+
+		// The challenge is already resolved, nothing is notified again
+		_, err = transactionService.Deny(ctx, orderId)
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+
+		_, err = transactionService.Approve(ctx, orderId)
+		if !errors.Is(err, business.ErrCannotModifyStatus) {
+			t.Errorf("expecting ErrCannotModifyStatus, instead got %v", err)
+		}
+
+		if notifications := claimNotifications(t, ctx, orderId); len(notifications) != 0 {
+			t.Errorf("expecting no other notification, instead got %d", len(notifications))
+		}
+	})
+}
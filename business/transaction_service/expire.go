@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"mock-payment-provider/business"
 	"mock-payment-provider/primitive"
@@ -25,22 +26,83 @@ func (d *Dependency) Expire(ctx context.Context, orderId string) (business.Expir
 		return business.ExpireResponse{}, fmt.Errorf("acquiring transaction status: %w", err)
 	}
 
-	// We can't expire any transaction that's not pending
-	if transactionStatus.TransactionStatus != primitive.TransactionStatusPending {
+	// We can only expire transactions that are still waiting for the customer or the merchant
+	if !transactionStatus.TransactionStatus.CanTransitionTo(primitive.TransactionStatusExpire) {
 		return business.ExpireResponse{}, business.ErrCannotModifyStatus
 	}
 
-	// Cancel the transaction
-	err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusExpired)
+	// Expire the transaction
+	err = d.transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusExpire, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
 	if err != nil {
-		return business.ExpireResponse{}, fmt.Errorf("modifying the transaction status to canceled: %w", err)
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return business.ExpireResponse{}, business.ErrCannotModifyStatus
+		}
+
+		return business.ExpireResponse{}, fmt.Errorf("modifying the transaction status to expired: %w", err)
+	}
+
+	err = d.releaseExpired(ctx, transactionStatus)
+	if err != nil {
+		return business.ExpireResponse{}, err
 	}
 
 	return business.ExpireResponse{
 		OrderId:           orderId,
 		TransactionAmount: transactionStatus.TransactionAmount,
 		PaymentType:       transactionStatus.PaymentType,
-		TransactionStatus: primitive.TransactionStatusCanceled,
+		TransactionStatus: primitive.TransactionStatusExpire,
 		TransactionTime:   transactionStatus.TransactionTime,
 	}, nil
 }
+
+// releaseExpired frees the charge of a transaction that has just been expired, so the
+// customer can no longer pay for the order, and sends the EXPIRED webhook.
+func (d *Dependency) releaseExpired(ctx context.Context, transaction primitive.Transaction) error {
+	// The bill key is the virtual account number, which is gone once the charge is released
+	billKey, err := d.mandiriBillKey(ctx, transaction.OrderId, transaction.PaymentType)
+	if err != nil {
+		return err
+	}
+
+	err = d.releaseCharge(ctx, transaction.OrderId, transaction.PaymentType)
+	if err != nil {
+		return fmt.Errorf("releasing charge: %w", err)
+	}
+
+	var creditCardCharge primitive.CreditCardCharge
+	if transaction.PaymentType == primitive.PaymentTypeCreditCard {
+		creditCardCharge, err = d.creditCardRepository.GetByOrderId(ctx, transaction.OrderId)
+		if err != nil {
+			return fmt.Errorf("acquiring credit card charge: %w", err)
+		}
+	}
+
+	var cstoreEntry repository.Entry
+	if transaction.PaymentType.ToStore() != "" {
+		cstoreEntry, err = d.cstoreRepository.GetByOrderId(ctx, transaction.OrderId)
+		if err != nil {
+			return fmt.Errorf("acquiring cstore entry: %w", err)
+		}
+	}
+
+	// Send a EXPIRED webhook
+	payload, err := d.buildExpiredWebhookMessage(expiredWebhookParameters{
+		TransactionTime: transaction.TransactionTime,
+		GrossAmount:     transaction.TransactionAmount,
+		OrderId:         transaction.OrderId,
+		PaymentType:     transaction.PaymentType,
+		BillKey:         billKey,
+		PaymentCode:     cstoreEntry.PaymentCode,
+		MaskedCard:      creditCardCharge.MaskedCard,
+		Bank:            creditCardCharge.Bank,
+		CardType:        creditCardCharge.CardType,
+	})
+	if err != nil {
+		return fmt.Errorf("building expired webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, transaction.OrderId, payload, time.Now())
+
+	return nil
+}
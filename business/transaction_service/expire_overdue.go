@@ -0,0 +1,70 @@
+package transaction_service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+// expireOverdueBatchSize limits how many transactions are expired at once, so a long
+// backlog after a downtime doesn't hold the database for too long.
+const expireOverdueBatchSize = 100
+
+func (d *Dependency) ExpireOverdue(ctx context.Context) (int, error) {
+	var expired int
+	for {
+		transactions, err := d.transactionRepository.ExpireOverdue(ctx, expireOverdueBatchSize)
+		if err != nil {
+			return expired, fmt.Errorf("expiring overdue transactions: %w", err)
+		}
+
+		expired += len(transactions)
+
+		if len(transactions) < expireOverdueBatchSize {
+			break
+		}
+	}
+
+	// The expired transactions are released and notified apart from expiring them, the
+	// ones left behind by a crash or an error on an earlier scan are picked up here again
+	err := d.releaseUnreleasedExpired(ctx)
+	if err != nil {
+		return expired, fmt.Errorf("releasing expired transactions: %w", err)
+	}
+
+	return expired, nil
+}
+
+func (d *Dependency) releaseUnreleasedExpired(ctx context.Context) error {
+	log := zerolog.Ctx(ctx)
+
+	for {
+		transactions, err := d.transactionRepository.ListUnreleasedExpired(ctx, expireOverdueBatchSize)
+		if err != nil {
+			return fmt.Errorf("listing unreleased expired transactions: %w", err)
+		}
+
+		var released int
+		for _, transaction := range transactions {
+			err := d.releaseExpired(ctx, transaction)
+			if err != nil {
+				log.Err(err).Str("orderId", transaction.OrderId).Msg("releasing expired transaction")
+				continue
+			}
+
+			err = d.transactionRepository.MarkExpiryReleased(ctx, transaction.OrderId)
+			if err != nil {
+				log.Err(err).Str("orderId", transaction.OrderId).Msg("marking expired transaction as released")
+				continue
+			}
+
+			released++
+		}
+
+		// Whatever keeps failing is retried on the next scan instead of over and over again
+		if len(transactions) < expireOverdueBatchSize || released == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package transaction_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+// acquireChallengedTransaction returns the card transaction and its charge, as long as the
+// customer went through 3-D Secure and the charge is waiting for the merchant's review.
+func (d *Dependency) acquireChallengedTransaction(ctx context.Context, orderId string) (primitive.Transaction, primitive.CreditCardCharge, error) {
+	if orderId == "" {
+		return primitive.Transaction{}, primitive.CreditCardCharge{}, fmt.Errorf("empty order id")
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return primitive.Transaction{}, primitive.CreditCardCharge{}, business.ErrTransactionNotFound
+		}
+
+		return primitive.Transaction{}, primitive.CreditCardCharge{}, fmt.Errorf("acquiring transaction: %w", err)
+	}
+
+	if transaction.PaymentType != primitive.PaymentTypeCreditCard ||
+		(transaction.TransactionStatus != primitive.TransactionStatusCapture &&
+			transaction.TransactionStatus != primitive.TransactionStatusAuthorize) {
+		return primitive.Transaction{}, primitive.CreditCardCharge{}, business.ErrCannotModifyStatus
+	}
+
+	creditCardCharge, err := d.creditCardRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return primitive.Transaction{}, primitive.CreditCardCharge{}, fmt.Errorf("acquiring credit card charge: %w", err)
+	}
+
+	if creditCardCharge.Outcome != primitive.CardOutcomeChallenge {
+		return primitive.Transaction{}, primitive.CreditCardCharge{}, business.ErrCannotModifyStatus
+	}
+
+	return transaction, creditCardCharge, nil
+}
+
+type fraudReviewWebhookParameters struct {
+	TransactionTime   time.Time
+	TransactionStatus primitive.TransactionStatus
+	GrossAmount       int64
+	OrderId           string
+	CreditCardCharge  primitive.CreditCardCharge
+}
+
+// buildFraudReviewWebhookMessage builds the notification of a card transaction whose fraud
+// challenge was resolved, the charge in the parameters must already carry the new outcome.
+func (d *Dependency) buildFraudReviewWebhookMessage(parameters fraudReviewWebhookParameters) ([]byte, error) {
+	switch parameters.TransactionStatus {
+	case primitive.TransactionStatusCapture:
+		return d.buildCaptureWebhookMessage(captureWebhookParameters{
+			TransactionTime: parameters.TransactionTime,
+			GrossAmount:     parameters.GrossAmount,
+			OrderId:         parameters.OrderId,
+			PaymentType:     primitive.PaymentTypeCreditCard,
+			MaskedCard:      parameters.CreditCardCharge.MaskedCard,
+			Bank:            parameters.CreditCardCharge.Bank,
+			CardType:        parameters.CreditCardCharge.CardType,
+			ApprovalCode:    parameters.CreditCardCharge.ApprovalCode,
+			FraudStatus:     parameters.CreditCardCharge.Outcome.FraudStatus(),
+		})
+	case primitive.TransactionStatusAuthorize:
+		return json.Marshal(schema.CreditCardChargeAuthorizeResponse{
+			TransactionTime:        parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus:      parameters.TransactionStatus.String(),
+			TransactionId:          parameters.OrderId,
+			StatusMessage:          "midtrans payment notification",
+			StatusCode:             "200",
+			SignatureKey:           signature.Generate(parameters.OrderId, 200, parameters.GrossAmount, d.serverKey),
+			PaymentType:            primitive.PaymentTypeCreditCard.ToPaymentMethod(),
+			OrderId:                parameters.OrderId,
+			MerchantId:             "MOCK",
+			MaskedCard:             parameters.CreditCardCharge.MaskedCard,
+			GrossAmount:            strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:            parameters.CreditCardCharge.Outcome.FraudStatus().String(),
+			Eci:                    "05",
+			Currency:               "IDR",
+			ChannelResponseMessage: "Approved",
+			ChannelResponseCode:    "00",
+			CardType:               parameters.CreditCardCharge.CardType,
+			Bank:                   parameters.CreditCardCharge.Bank,
+			ApprovalCode:           parameters.CreditCardCharge.ApprovalCode,
+		})
+	case primitive.TransactionStatusDeny:
+		return json.Marshal(schema.CreditCardChargeDenyResponse{
+			TransactionTime:        parameters.TransactionTime.Format(time.DateTime),
+			TransactionStatus:      parameters.TransactionStatus.String(),
+			TransactionId:          parameters.OrderId,
+			StatusMessage:          "midtrans payment notification",
+			StatusCode:             "202",
+			SignatureKey:           signature.Generate(parameters.OrderId, 202, parameters.GrossAmount, d.serverKey),
+			PaymentType:            primitive.PaymentTypeCreditCard.ToPaymentMethod(),
+			OrderId:                parameters.OrderId,
+			MerchantId:             "MOCK",
+			MaskedCard:             parameters.CreditCardCharge.MaskedCard,
+			GrossAmount:            strconv.FormatInt(parameters.GrossAmount, 10),
+			FraudStatus:            parameters.CreditCardCharge.Outcome.FraudStatus().String(),
+			Eci:                    "05",
+			Currency:               "IDR",
+			ChannelResponseMessage: "Denied by merchant",
+			ChannelResponseCode:    "05",
+			CardType:               parameters.CreditCardCharge.CardType,
+			Bank:                   parameters.CreditCardCharge.Bank,
+		})
+	default:
+		return nil, fmt.Errorf("invalid transaction status")
+	}
+}
@@ -0,0 +1,38 @@
+package transaction_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+)
+
+// scheduleMagicOutcome schedules the outcome of the first magic value that matches the charge.
+// The charge has already been made, so failing to schedule its outcome is only logged.
+func (d *Dependency) scheduleMagicOutcome(ctx context.Context, request business.ChargeRequest) {
+	log := zerolog.Ctx(ctx)
+
+	for _, magicValue := range d.magicValues {
+		if !magicValue.Matches(request.OrderId, request.Customer.Email, request.TransactionAmount) {
+			continue
+		}
+
+		// A card is only charged through 3-D Secure, it can't be settled on its own
+		if magicValue.Outcome == primitive.TransactionStatusSettlement && request.PaymentType == primitive.PaymentTypeCreditCard {
+			return
+		}
+
+		err := d.outcomeRepository.Schedule(ctx, primitive.ScheduledOutcome{
+			OrderId: request.OrderId,
+			Outcome: magicValue.Outcome,
+			DueAt:   time.Now().Add(magicValue.Delay),
+		})
+		if err != nil {
+			log.Err(err).Str("orderId", request.OrderId).Msg("scheduling magic value outcome")
+		}
+
+		return
+	}
+}
@@ -0,0 +1,211 @@
+package transaction_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/signature"
+)
+
+func (d *Dependency) Refund(ctx context.Context, orderId string, request business.RefundRequest) (business.RefundResponse, error) {
+	if orderId == "" {
+		return business.RefundResponse{}, fmt.Errorf("empty order id")
+	}
+
+	if request.Amount < 0 {
+		return business.RefundResponse{}, &business.RequestValidationError{
+			Issues: []business.RequestValidationIssue{
+				{
+					Code:    business.RequestValidationCodeInvalidValue,
+					Field:   "amount",
+					Message: "must not be negative",
+				},
+			},
+		}
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return business.RefundResponse{}, business.ErrTransactionNotFound
+		}
+
+		return business.RefundResponse{}, fmt.Errorf("acquiring transaction: %w", err)
+	}
+
+	// Only settled or captured transactions (or the ones that are partially refunded) can be refunded
+	if !transaction.TransactionStatus.CanTransitionTo(primitive.TransactionStatusRefund) {
+		return business.RefundResponse{}, business.ErrCannotModifyStatus
+	}
+
+	// Bank transfers can't be refunded, and direct refund is only available for e-money
+	switch transaction.PaymentType {
+	case primitive.PaymentTypeEMoneyQRIS:
+		fallthrough
+	case primitive.PaymentTypeEMoneyGopay:
+		fallthrough
+	case primitive.PaymentTypeEMoneyShopeePay:
+		// Supports both refund methods
+	case primitive.PaymentTypeCreditCard:
+		fallthrough
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		if request.Direct {
+			return business.RefundResponse{}, business.ErrRefundNotSupported
+		}
+	default:
+		return business.RefundResponse{}, business.ErrRefundNotSupported
+	}
+
+	// A partially captured card transaction can only be refunded up to the captured amount
+	refundableAmount := transaction.TransactionAmount
+	if transaction.PaymentType == primitive.PaymentTypeCreditCard {
+		creditCardCharge, err := d.creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			return business.RefundResponse{}, fmt.Errorf("acquiring credit card charge: %w", err)
+		}
+
+		if creditCardCharge.CapturedAmount > 0 {
+			refundableAmount = creditCardCharge.CapturedAmount
+		}
+	}
+
+	refundKey := request.RefundKey
+	if refundKey == "" {
+		refundKey = uuid.NewString()
+	}
+
+	// The refund is checked against the previous ones as it is stored, so concurrent
+	// refunds never go over the refundable amount
+	refund, err := d.refundRepository.Create(ctx, repository.CreateRefundParam{
+		OrderId:          orderId,
+		RefundKey:        refundKey,
+		Amount:           request.Amount,
+		RefundableAmount: refundableAmount,
+		Reason:           request.Reason,
+		Direct:           request.Direct,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return business.RefundResponse{}, business.ErrDuplicateRefundKey
+		}
+
+		if errors.Is(err, repository.ErrAmountExceeded) {
+			return business.RefundResponse{}, business.ErrRefundAmountExceeded
+		}
+
+		return business.RefundResponse{}, fmt.Errorf("creating refund: %w", err)
+	}
+
+	// Every refund so far, including this one
+	refunds, err := d.refundRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return business.RefundResponse{}, fmt.Errorf("acquiring refunds: %w", err)
+	}
+
+	var refundedAmount int64
+	for _, previousRefund := range refunds {
+		refundedAmount += previousRefund.Amount
+	}
+
+	transactionStatus := primitive.TransactionStatusPartialRefund
+	if refundedAmount == refundableAmount {
+		transactionStatus = primitive.TransactionStatusRefund
+	}
+
+	err = d.transactionRepository.UpdateStatus(ctx, orderId, transactionStatus, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+	if err != nil {
+		var invalidTransitionError *repository.InvalidTransitionError
+		if errors.As(err, &invalidTransitionError) {
+			return business.RefundResponse{}, business.ErrCannotModifyStatus
+		}
+
+		return business.RefundResponse{}, fmt.Errorf("updating transaction status: %w", err)
+	}
+
+	// Send a REFUND webhook
+	payload, err := d.buildRefundWebhookMessage(refundWebhookParameters{
+		TransactionTime:   transaction.TransactionTime,
+		TransactionStatus: transactionStatus,
+		GrossAmount:       transaction.TransactionAmount,
+		OrderId:           orderId,
+		PaymentType:       transaction.PaymentType,
+		Refunds:           refunds,
+	})
+	if err != nil {
+		return business.RefundResponse{}, fmt.Errorf("building refund webhook message: %w", err)
+	}
+
+	d.notifier.Send(ctx, orderId, payload, time.Now())
+
+	return business.RefundResponse{
+		OrderId:            orderId,
+		TransactionAmount:  transaction.TransactionAmount,
+		PaymentType:        transaction.PaymentType,
+		TransactionStatus:  transactionStatus,
+		TransactionTime:    transaction.TransactionTime,
+		RefundChargebackId: refund.RefundChargebackId,
+		RefundAmount:       refund.Amount,
+		RefundKey:          refund.RefundKey,
+	}, nil
+}
+
+type refundWebhookParameters struct {
+	TransactionTime   time.Time
+	TransactionStatus primitive.TransactionStatus
+	GrossAmount       int64
+	OrderId           string
+	PaymentType       primitive.PaymentType
+	Refunds           []primitive.Refund
+}
+
+func (d *Dependency) buildRefundWebhookMessage(parameters refundWebhookParameters) ([]byte, error) {
+	signatureKey := signature.Generate(parameters.OrderId, 200, parameters.GrossAmount, d.serverKey)
+
+	var refundAmount int64
+	var refunds []schema.Refund
+	for _, refund := range parameters.Refunds {
+		refundAmount += refund.Amount
+
+		var refundMethod string
+		if refund.Direct {
+			refundMethod = "online"
+		}
+
+		refunds = append(refunds, schema.Refund{
+			RefundChargebackId: refund.RefundChargebackId,
+			RefundAmount:       strconv.FormatInt(refund.Amount, 10),
+			CreatedAt:          refund.CreatedAt.Format(time.DateTime),
+			Reason:             refund.Reason,
+			RefundKey:          refund.RefundKey,
+			RefundMethod:       refundMethod,
+		})
+	}
+
+	return json.Marshal(schema.RefundNotification{
+		TransactionTime:   parameters.TransactionTime.Format(time.DateTime),
+		TransactionStatus: parameters.TransactionStatus.String(),
+		TransactionId:     parameters.OrderId,
+		StatusMessage:     "midtrans payment notification",
+		StatusCode:        "200",
+		SignatureKey:      signatureKey,
+		PaymentType:       parameters.PaymentType.ToPaymentMethod(),
+		OrderId:           parameters.OrderId,
+		MerchantId:        "MOCK",
+		GrossAmount:       strconv.FormatInt(parameters.GrossAmount, 10),
+		FraudStatus:       "accept",
+		Currency:          "IDR",
+		RefundAmount:      strconv.FormatInt(refundAmount, 10),
+		Refunds:           refunds,
+	})
+}
@@ -0,0 +1,70 @@
+package transaction_service
+
+import (
+	"context"
+	"fmt"
+
+	"mock-payment-provider/primitive"
+)
+
+// releaseCharge frees the virtual account number, the e-money entry or the payment code
+// out of the charge of the order, so the customer can no longer pay for it. Credit card charges
+// have nothing to release.
+func (d *Dependency) releaseCharge(ctx context.Context, orderId string, paymentType primitive.PaymentType) error {
+	switch paymentType {
+	case primitive.PaymentTypeVirtualAccountBCA:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBNI:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBRI:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountPermata:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		fallthrough
+	case primitive.PaymentTypeVirtualAccountBSI:
+		fallthrough
+	case primitive.PaymentTypeMandiriBill:
+		err := d.virtualAccountRepository.ReleaseCharge(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("releasing virtual account charge: %w", err)
+		}
+	case primitive.PaymentTypeEMoneyQRIS:
+		fallthrough
+	case primitive.PaymentTypeEMoneyGopay:
+		fallthrough
+	case primitive.PaymentTypeEMoneyShopeePay:
+		fallthrough
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		err := d.emoneyRepository.CancelCharge(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("canceling e-money charge: %w", err)
+		}
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		err := d.cstoreRepository.CancelCharge(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("canceling cstore charge: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mandiriBillKey returns the bill key of a Mandiri Bill Payment charge, which has to be
+// acquired before the charge is released. It is empty for any other payment type.
+func (d *Dependency) mandiriBillKey(ctx context.Context, orderId string, paymentType primitive.PaymentType) (string, error) {
+	if paymentType != primitive.PaymentTypeMandiriBill {
+		return "", nil
+	}
+
+	virtualAccountEntry, err := d.virtualAccountRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return "", fmt.Errorf("acquiring virtual account entry from order id: %w", err)
+	}
+
+	return virtualAccountEntry.VirtualAccountNumber, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
 	"mock-payment-provider/repository"
 )
 
@@ -23,11 +24,62 @@ func (d *Dependency) GetStatus(ctx context.Context, orderId string) (business.Ge
 		return business.GetStatusResponse{}, fmt.Errorf("acquiring transaction by order id: %w", err)
 	}
 
+	refunds, err := d.refundRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return business.GetStatusResponse{}, fmt.Errorf("acquiring refunds by order id: %w", err)
+	}
+
+	var refundAmount int64
+	for _, refund := range refunds {
+		refundAmount += refund.Amount
+	}
+
+	fraudStatus := primitive.FraudStatusAccept
+	var creditCardCharge primitive.CreditCardCharge
+	if transaction.PaymentType == primitive.PaymentTypeCreditCard {
+		creditCardCharge, err = d.creditCardRepository.GetByOrderId(ctx, orderId)
+		// A charge denied by a rule never had its card stored
+		if err != nil && !(errors.Is(err, repository.ErrNotFound) && transaction.TransactionStatus == primitive.TransactionStatusDeny) {
+			return business.GetStatusResponse{}, fmt.Errorf("acquiring credit card charge by order id: %w", err)
+		}
+
+		// The approval code is only given once the card has been charged
+		if transaction.TransactionStatus == primitive.TransactionStatusPending ||
+			transaction.TransactionStatus == primitive.TransactionStatusDeny ||
+			transaction.TransactionStatus == primitive.TransactionStatusExpire {
+			creditCardCharge.ApprovalCode = ""
+		}
+
+		// The fraud detection system only looks at the card once it has been authenticated
+		if transaction.TransactionStatus != primitive.TransactionStatusPending &&
+			transaction.TransactionStatus != primitive.TransactionStatusExpire {
+			fraudStatus = creditCardCharge.Outcome.FraudStatus()
+		}
+	}
+
+	var virtualAccountDetail primitive.VirtualAccountDetail
+	if transaction.PaymentType.ToBank() != "" {
+		virtualAccountDetail, err = d.virtualAccountRepository.GetDetail(ctx, orderId)
+		if err != nil {
+			return business.GetStatusResponse{}, fmt.Errorf("acquiring virtual account detail by order id: %w", err)
+		}
+	}
+
 	return business.GetStatusResponse{
 		OrderId:           orderId,
 		TransactionStatus: transaction.TransactionStatus,
 		TransactionAmount: transaction.TransactionAmount,
 		PaymentType:       transaction.PaymentType,
 		TransactionTime:   transaction.TransactionTime,
+		ExpiresAt:         transaction.ExpiresAt,
+		RefundAmount:      refundAmount,
+		Refunds:           refunds,
+		MaskedCard:        creditCardCharge.MaskedCard,
+		Bank:              creditCardCharge.Bank,
+		CardType:          creditCardCharge.CardType,
+		ApprovalCode:      creditCardCharge.ApprovalCode,
+		FraudStatus:       fraudStatus,
+		RecipientName:     virtualAccountDetail.RecipientName,
+		FreeText:          virtualAccountDetail.FreeText,
 	}, nil
 }
@@ -0,0 +1,37 @@
+package transaction_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) GetStatusHistory(ctx context.Context, orderId string) (business.GetStatusHistoryResponse, error) {
+	if orderId == "" {
+		return business.GetStatusHistoryResponse{}, fmt.Errorf("empty order id")
+	}
+
+	transaction, err := d.transactionRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return business.GetStatusHistoryResponse{}, business.ErrTransactionNotFound
+		}
+
+		return business.GetStatusHistoryResponse{}, fmt.Errorf("acquiring transaction by order id: %w", err)
+	}
+
+	// Transactions that were created before the status history existed have none
+	history, err := d.transactionRepository.GetStatusHistory(ctx, orderId)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return business.GetStatusHistoryResponse{}, fmt.Errorf("acquiring status history: %w", err)
+	}
+
+	return business.GetStatusHistoryResponse{
+		OrderId:           transaction.OrderId,
+		TransactionStatus: transaction.TransactionStatus,
+		History:           history,
+	}, nil
+}
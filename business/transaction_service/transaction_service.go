@@ -2,24 +2,53 @@ package transaction_service
 
 import (
 	"fmt"
+	"net/url"
 
+	"mock-payment-provider/business"
+	"mock-payment-provider/business/webhook_service"
+	"mock-payment-provider/primitive"
 	"mock-payment-provider/repository"
 )
 
 type Config struct {
 	ServerKey                string
 	TransactionRepository    repository.TransactionRepository
-	WebhookClient            repository.WebhookClient
+	WebhookOutboxRepository  repository.WebhookOutboxRepository
 	VirtualAccountRepository repository.VirtualAccountRepository
 	EMoneyRepository         repository.EMoneyRepository
+	CStoreRepository         repository.CStoreRepository
+	RefundRepository         repository.RefundRepository
+	CreditCardRepository     repository.CreditCardRepository
+	CardTokenRepository      repository.CardTokenRepository
+	// RuleService can force charges to be denied and webhooks to be dropped.
+	RuleService business.Rule
+	// OutcomeRepository stores the outcome of the charges that match one of MagicValues,
+	// which is empty unless magic values are enabled.
+	OutcomeRepository repository.OutcomeRepository
+	MagicValues       []primitive.MagicValue
+	// FraudChallenge puts the matching card charges into challenge, waiting for the
+	// merchant to approve or deny them. It never matches unless it is configured.
+	FraudChallenge primitive.FraudChallengeTrigger
+	// PublicBaseURL is the base URL that the customer (or the merchant's frontend)
+	// uses to reach this service. It is used for building absolute action URLs.
+	PublicBaseURL string
 }
 
 type Dependency struct {
 	serverKey                string
 	transactionRepository    repository.TransactionRepository
-	webhookClient            repository.WebhookClient
 	virtualAccountRepository repository.VirtualAccountRepository
 	emoneyRepository         repository.EMoneyRepository
+	cstoreRepository         repository.CStoreRepository
+	refundRepository         repository.RefundRepository
+	creditCardRepository     repository.CreditCardRepository
+	cardTokenRepository      repository.CardTokenRepository
+	ruleService              business.Rule
+	outcomeRepository        repository.OutcomeRepository
+	magicValues              []primitive.MagicValue
+	fraudChallenge           primitive.FraudChallengeTrigger
+	publicBaseURL            *url.URL
+	notifier                 *webhook_service.Notifier
 }
 
 // NewTransactionService validates input from Dependency and return an error if
@@ -29,8 +58,8 @@ func NewTransactionService(config Config) (*Dependency, error) {
 		return &Dependency{}, fmt.Errorf("nil transaction repository")
 	}
 
-	if config.WebhookClient == nil {
-		return &Dependency{}, fmt.Errorf("nil webhook client")
+	if config.WebhookOutboxRepository == nil {
+		return &Dependency{}, fmt.Errorf("nil webhook outbox repository")
 	}
 
 	if config.VirtualAccountRepository == nil {
@@ -41,11 +70,62 @@ func NewTransactionService(config Config) (*Dependency, error) {
 		return &Dependency{}, fmt.Errorf("nil emoney repository")
 	}
 
+	if config.CStoreRepository == nil {
+		return &Dependency{}, fmt.Errorf("nil cstore repository")
+	}
+
+	if config.RefundRepository == nil {
+		return &Dependency{}, fmt.Errorf("nil refund repository")
+	}
+
+	if config.CreditCardRepository == nil {
+		return &Dependency{}, fmt.Errorf("nil credit card repository")
+	}
+
+	if config.CardTokenRepository == nil {
+		return &Dependency{}, fmt.Errorf("nil card token repository")
+	}
+
+	if config.RuleService == nil {
+		return &Dependency{}, fmt.Errorf("nil rule service")
+	}
+
+	if config.OutcomeRepository == nil {
+		return &Dependency{}, fmt.Errorf("nil outcome repository")
+	}
+
+	publicBaseURL, err := url.Parse(config.PublicBaseURL)
+	if err != nil {
+		return &Dependency{}, fmt.Errorf("invalid public base url: %w", err)
+	}
+
+	if publicBaseURL.Scheme == "" || publicBaseURL.Host == "" {
+		return &Dependency{}, fmt.Errorf("public base url must be absolute")
+	}
+
+	notifier, err := webhook_service.NewNotifier(webhook_service.NotifierConfig{
+		TransactionRepository:   config.TransactionRepository,
+		WebhookOutboxRepository: config.WebhookOutboxRepository,
+		RuleService:             config.RuleService,
+	})
+	if err != nil {
+		return &Dependency{}, fmt.Errorf("creating notifier: %w", err)
+	}
+
 	return &Dependency{
 		serverKey:                config.ServerKey,
 		transactionRepository:    config.TransactionRepository,
-		webhookClient:            config.WebhookClient,
 		virtualAccountRepository: config.VirtualAccountRepository,
 		emoneyRepository:         config.EMoneyRepository,
+		cstoreRepository:         config.CStoreRepository,
+		refundRepository:         config.RefundRepository,
+		creditCardRepository:     config.CreditCardRepository,
+		cardTokenRepository:      config.CardTokenRepository,
+		ruleService:              config.RuleService,
+		outcomeRepository:        config.OutcomeRepository,
+		magicValues:              config.MagicValues,
+		fraudChallenge:           config.FraudChallenge,
+		publicBaseURL:            publicBaseURL,
+		notifier:                 notifier,
 	}, nil
 }
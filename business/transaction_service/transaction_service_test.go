@@ -0,0 +1,262 @@
+package transaction_service_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"mock-payment-provider/business/rule_service"
+	"mock-payment-provider/business/transaction_service"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/card_token"
+	"mock-payment-provider/repository/credit_card"
+	"mock-payment-provider/repository/cstore"
+	"mock-payment-provider/repository/emoney"
+	"mock-payment-provider/repository/outcome"
+	"mock-payment-provider/repository/refund"
+	"mock-payment-provider/repository/rule"
+	"mock-payment-provider/repository/transaction"
+	"mock-payment-provider/repository/virtual_account"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+var (
+	db                      *sql.DB
+	transactionRepository   *transaction.Repository
+	creditCardRepository    *credit_card.Repository
+	cardTokenRepository     *card_token.Repository
+	webhookOutboxRepository *webhook_outbox.Repository
+	transactionService      *transaction_service.Dependency
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	db, err = sql.Open("sqlite3", ":memory:?_txlock=exclusive&_foreign_keys=1&")
+	if err != nil {
+		log.Fatalf("Opening sql database: %s", err.Error())
+	}
+
+	db.SetMaxOpenConns(1)
+
+	setupCtx, setupCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer setupCancel()
+
+	transactionRepository, err = transaction.NewTransactionRepository(db)
+	if err != nil {
+		log.Fatalf("Creating transaction repository: %s", err.Error())
+	}
+
+	creditCardRepository, err = credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		log.Fatalf("Creating credit card repository: %s", err.Error())
+	}
+
+	webhookOutboxRepository, err = webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		log.Fatalf("Creating webhook outbox repository: %s", err.Error())
+	}
+
+	virtualAccountRepository, err := virtual_account.NewVirtualAccountRepository(db)
+	if err != nil {
+		log.Fatalf("Creating virtual account repository: %s", err.Error())
+	}
+
+	emoneyRepository, err := emoney.NewEmoneyRepository(db)
+	if err != nil {
+		log.Fatalf("Creating emoney repository: %s", err.Error())
+	}
+
+	cstoreRepository, err := cstore.NewCStoreRepository(db)
+	if err != nil {
+		log.Fatalf("Creating cstore repository: %s", err.Error())
+	}
+
+	refundRepository, err := refund.NewRefundRepository(db)
+	if err != nil {
+		log.Fatalf("Creating refund repository: %s", err.Error())
+	}
+
+	cardTokenRepository, err = card_token.NewCardTokenRepository(db)
+	if err != nil {
+		log.Fatalf("Creating card token repository: %s", err.Error())
+	}
+
+	ruleRepository, err := rule.NewRuleRepository(db)
+	if err != nil {
+		log.Fatalf("Creating rule repository: %s", err.Error())
+	}
+
+	outcomeRepository, err := outcome.NewOutcomeRepository(db)
+	if err != nil {
+		log.Fatalf("Creating outcome repository: %s", err.Error())
+	}
+
+	for _, migrator := range []interface{ Migrate(context.Context) error }{
+		transactionRepository,
+		creditCardRepository,
+		webhookOutboxRepository,
+		virtualAccountRepository,
+		emoneyRepository,
+		cstoreRepository,
+		refundRepository,
+		cardTokenRepository,
+		ruleRepository,
+		outcomeRepository,
+	} {
+		err := migrator.Migrate(setupCtx)
+		if err != nil {
+			log.Fatalf("migrating database: %s", err.Error())
+		}
+	}
+
+	ruleService, err := rule_service.NewRuleService(rule_service.Config{RuleRepository: ruleRepository})
+	if err != nil {
+		log.Fatalf("Creating rule service: %s", err.Error())
+	}
+
+	transactionService, err = transaction_service.NewTransactionService(transaction_service.Config{
+		ServerKey:                "server-key",
+		TransactionRepository:    transactionRepository,
+		WebhookOutboxRepository:  webhookOutboxRepository,
+		VirtualAccountRepository: virtualAccountRepository,
+		EMoneyRepository:         emoneyRepository,
+		CStoreRepository:         cstoreRepository,
+		RefundRepository:         refundRepository,
+		CreditCardRepository:     creditCardRepository,
+		CardTokenRepository:      cardTokenRepository,
+		RuleService:              ruleService,
+		OutcomeRepository:        outcomeRepository,
+		PublicBaseURL:            "http://localhost:3000",
+	})
+	if err != nil {
+		log.Fatalf("Creating transaction service: %s", err.Error())
+	}
+
+	exitCode := m.Run()
+
+	err = db.Close()
+	if err != nil {
+		log.Printf("Closing database: %s", err.Error())
+	}
+
+	os.Exit(exitCode)
+}
+
+// createCardTransaction creates a card transaction that went through 3-D Secure with
+// the outcome of the fraud detection system.
+func createCardTransaction(t *testing.T, ctx context.Context, status primitive.TransactionStatus, outcome primitive.CardOutcome) string {
+	t.Helper()
+
+	orderId := uuid.NewString()
+	err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+		OrderID:     orderId,
+		Amount:      50000,
+		PaymentType: primitive.PaymentTypeCreditCard,
+		Status:      status,
+		ExpiredAt:   time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("creating transaction: %s", err.Error())
+	}
+
+	_, err = creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{
+		OrderId:         orderId,
+		TokenId:         "481111-1114-" + uuid.NewString(),
+		MaskedCard:      "481111-1114",
+		Bank:            "bni",
+		CardType:        "credit",
+		ApprovalCode:    "1234567890123",
+		Outcome:         outcome,
+		TransactionType: primitive.CardTransactionTypeAuthorizeCapture,
+		Amount:          50000,
+		ExpiresAt:       time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("creating charge: %s", err.Error())
+	}
+
+	return orderId
+}
+
+type notificationPayload struct {
+	StatusCode        string `json:"status_code"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+}
+
+// claimNotifications takes every notification of the order that is due to be delivered,
+// so a notification is only ever returned once.
+func claimNotifications(t *testing.T, ctx context.Context, orderId string) []notificationPayload {
+	t.Helper()
+
+	notifications, err := webhookOutboxRepository.ClaimDue(ctx, 100, time.Hour)
+	if err != nil {
+		t.Fatalf("claiming notifications: %s", err.Error())
+	}
+
+	var payloads []notificationPayload
+	for _, notification := range notifications {
+		if notification.OrderId != orderId {
+			continue
+		}
+
+		var payload notificationPayload
+		err := json.Unmarshal(notification.Payload, &payload)
+		if err != nil {
+			t.Fatalf("unmarshaling notification payload: %s", err.Error())
+		}
+
+		payloads = append(payloads, payload)
+	}
+
+	return payloads
+}
+
+type signedPayload struct {
+	OrderId      string `json:"order_id"`
+	StatusCode   string `json:"status_code"`
+	GrossAmount  string `json:"gross_amount"`
+	SignatureKey string `json:"signature_key"`
+}
+
+// outboxPayloads returns every notification of the order in the webhook outbox, including
+// the ones that are not due yet, such as the delayed pending webhook of a charge.
+func outboxPayloads(t *testing.T, ctx context.Context, orderId string) []signedPayload {
+	t.Helper()
+
+	rows, err := db.QueryContext(ctx, `SELECT payload FROM webhook_outbox WHERE order_id = ?`, orderId)
+	if err != nil {
+		t.Fatalf("querying webhook outbox: %s", err.Error())
+	}
+	defer rows.Close()
+
+	var payloads []signedPayload
+	for rows.Next() {
+		var raw []byte
+		err := rows.Scan(&raw)
+		if err != nil {
+			t.Fatalf("scanning webhook outbox: %s", err.Error())
+		}
+
+		var payload signedPayload
+		err = json.Unmarshal(raw, &payload)
+		if err != nil {
+			t.Fatalf("unmarshaling notification payload: %s", err.Error())
+		}
+
+		payloads = append(payloads, payload)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.Fatalf("iterating webhook outbox: %s", err.Error())
+	}
+
+	return payloads
+}
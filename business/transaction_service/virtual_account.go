@@ -0,0 +1,50 @@
+package transaction_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+// acquireVirtualAccountNumber returns the virtual account number that the customer pays the charge
+// to. The merchant's own number is claimed for the customer, and BCA numbers are prefixed with
+// the sub-company code if there is one.
+func (d *Dependency) acquireVirtualAccountNumber(ctx context.Context, request business.ChargeRequest) (string, error) {
+	customerUniqueField := request.Customer.Email
+	var prefix string
+	if request.PaymentType == primitive.PaymentTypeVirtualAccountBCA && request.BankTransferOptions.SubCompanyCode != "" {
+		prefix = request.BankTransferOptions.SubCompanyCode
+		// The customer gets another number for each sub-company
+		customerUniqueField = prefix + ":" + customerUniqueField
+	}
+
+	virtualAccountNumber := request.BankTransferOptions.VirtualAccountNumber
+	if virtualAccountNumber == "" {
+		generated, err := d.virtualAccountRepository.CreateOrGetVirtualAccountNumber(ctx, request.Customer.Email)
+		if err != nil {
+			return "", fmt.Errorf("acquiring virtual account number for %s: %w", request.Customer.Email, err)
+		}
+
+		if prefix == "" {
+			return generated, nil
+		}
+
+		virtualAccountNumber = generated
+	}
+
+	virtualAccountNumber = prefix + virtualAccountNumber
+	err := d.virtualAccountRepository.ClaimVirtualAccountNumber(ctx, customerUniqueField, virtualAccountNumber)
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return "", business.ErrVirtualAccountNumberTaken
+		}
+
+		return "", fmt.Errorf("claiming virtual account number %s: %w", virtualAccountNumber, err)
+	}
+
+	return virtualAccountNumber, nil
+}
@@ -0,0 +1,26 @@
+package business
+
+import (
+	"context"
+
+	"mock-payment-provider/primitive"
+)
+
+// Webhook interface delivers the notifications that the other services put into the
+// webhook outbox, and keeps a log of every delivery attempt.
+type Webhook interface {
+	// Run delivers due notifications until ctx is canceled, then waits for the deliveries
+	// that are in flight. Notifications that are left pending are picked up again on the
+	// next Run, including after a restart.
+	Run(ctx context.Context)
+	// ListAttempts returns every delivery attempt of the notifications of an order, from
+	// the oldest one.
+	ListAttempts(ctx context.Context, orderId string) ([]primitive.WebhookAttempt, error)
+	// Resend puts the payload of a previous attempt back into the outbox, to be delivered
+	// to the same URL as a new notification. It returns ErrWebhookAttemptNotFound if the
+	// attempt doesn't exist.
+	Resend(ctx context.Context, attemptId string) (primitive.WebhookNotification, error)
+	// SendTest sends a sample settlement notification to targetUrl right away and returns
+	// the outcome. It is not retried, nor recorded in the attempt log.
+	SendTest(ctx context.Context, targetUrl string) (primitive.WebhookAttempt, error)
+}
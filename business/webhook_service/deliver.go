@@ -0,0 +1,103 @@
+package webhook_service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+// Midtrans retry rules:
+//
+// for 2xx: No retries, it is considered success.
+// for 500: Retry only once.
+// for 503: Retry four times.
+// for 400/404: Retry two times.
+// for 301/302/303: No retries.
+// for 307/308: Follow the new URL with POST method and the same notification body,
+// at most five times. This is done by the webhook client within a single attempt.
+// for all other failures: Retry five times.
+//
+// Different retry intervals from 1st time to 5th time (2m, 10m, 30m, 1.5hour, 3.5hour).
+// The first retry is two minutes after the first attempt failed, the second retry is ten
+// minutes after the first retry failed, and so on.
+var DefaultRetryIntervals = []time.Duration{
+	time.Minute * 2,
+	time.Minute * 10,
+	time.Minute * 30,
+	time.Hour + (time.Minute * 30),
+	(time.Hour * 3) + (time.Minute * 30),
+}
+
+// maximumRetries follows the Midtrans retry rules, but never retries more often than
+// there are retry intervals.
+func (d *Dependency) maximumRetries(statusCode int) int {
+	var retries int
+	switch statusCode {
+	case 301, 302, 303:
+		retries = 0
+	case 400, 404:
+		retries = 2
+	case 500:
+		retries = 1
+	case 503:
+		retries = 4
+	default:
+		retries = 5
+	}
+
+	if retries > len(d.retryIntervals) {
+		return len(d.retryIntervals)
+	}
+
+	return retries
+}
+
+func (d *Dependency) deliver(ctx context.Context, notification primitive.WebhookNotification) {
+	log := zerolog.Ctx(ctx).With().Str("webhookId", notification.Id).Str("orderId", notification.OrderId).Str("targetUrl", notification.TargetURL).Logger()
+
+	sendCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	response, err := d.webhookClient.Send(sendCtx, notification.TargetURL, notification.Payload)
+	cancel()
+
+	statusCode := response.StatusCode
+	attempt := repository.RecordWebhookAttemptParam{
+		Id:           notification.Id,
+		Status:       primitive.WebhookStatusDelivered,
+		TargetURL:    response.TargetURL,
+		StatusCode:   statusCode,
+		ResponseBody: response.Body,
+		Latency:      response.Latency,
+	}
+
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		log.Info().Bytes("payload", notification.Payload).Int("statusCode", statusCode).Msg("sent a webhook")
+	} else {
+		if err != nil {
+			attempt.Error = err.Error()
+		} else {
+			attempt.Error = fmt.Sprintf("unexpected status code %d", statusCode)
+		}
+
+		// Every attempt after the first one is a retry
+		retries := notification.Attempts
+		if retries < d.maximumRetries(statusCode) {
+			attempt.Status = primitive.WebhookStatusPending
+			attempt.NextAttemptAt = time.Now().Add(d.retryIntervals[retries])
+
+			log.Warn().Str("error", attempt.Error).Time("nextAttemptAt", attempt.NextAttemptAt).Msg("sending webhook, retrying later")
+		} else {
+			attempt.Status = primitive.WebhookStatusFailed
+
+			log.Error().Str("error", attempt.Error).Msg("sending webhook, giving up after too many retries")
+		}
+	}
+
+	err = d.webhookOutboxRepository.RecordAttempt(ctx, attempt)
+	if err != nil {
+		log.Err(err).Msg("recording webhook attempt")
+	}
+}
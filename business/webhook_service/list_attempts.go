@@ -0,0 +1,28 @@
+package webhook_service
+
+import (
+	"context"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+)
+
+func (d *Dependency) ListAttempts(ctx context.Context, orderId string) ([]primitive.WebhookAttempt, error) {
+	if orderId == "" {
+		return nil, &business.RequestValidationError{Issues: []business.RequestValidationIssue{
+			{
+				Code:    business.RequestValidationCodeRequired,
+				Field:   "order_id",
+				Message: "can not be empty",
+			},
+		}}
+	}
+
+	attempts, err := d.webhookOutboxRepository.ListAttempts(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook attempts: %w", err)
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,91 @@
+package webhook_service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+type NotifierConfig struct {
+	TransactionRepository   repository.TransactionRepository
+	WebhookOutboxRepository repository.WebhookOutboxRepository
+	// RuleService can force webhooks to be dropped.
+	RuleService business.Rule
+}
+
+// Notifier puts the notifications of the other services into the webhook outbox, for
+// the webhook service to deliver them.
+type Notifier struct {
+	transactionRepository   repository.TransactionRepository
+	webhookOutboxRepository repository.WebhookOutboxRepository
+	ruleService             business.Rule
+}
+
+// NewNotifier validates input from NotifierConfig and return an error if any of it is nil.
+func NewNotifier(config NotifierConfig) (*Notifier, error) {
+	if config.TransactionRepository == nil {
+		return nil, fmt.Errorf("nil transaction repository")
+	}
+
+	if config.WebhookOutboxRepository == nil {
+		return nil, fmt.Errorf("nil webhook outbox repository")
+	}
+
+	if config.RuleService == nil {
+		return nil, fmt.Errorf("nil rule service")
+	}
+
+	return &Notifier{
+		transactionRepository:   config.TransactionRepository,
+		webhookOutboxRepository: config.WebhookOutboxRepository,
+		ruleService:             config.RuleService,
+	}, nil
+}
+
+// Send puts the notification into the webhook outbox once for every receiver of the
+// transaction, and the webhook service delivers it from deliverAt onwards. The status
+// change that triggered the notification has already been made, so failing to store it
+// is only logged. A drop_webhook rule leaves the notification out of the outbox.
+func (n *Notifier) Send(ctx context.Context, orderId string, payload []byte, deliverAt time.Time) {
+	log := zerolog.Ctx(ctx)
+
+	transaction, err := n.transactionRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		log.Err(err).Str("orderId", orderId).Msg("getting transaction to apply rules")
+	} else {
+		rule, ok, err := n.ruleService.Apply(ctx, primitive.RuleActionDropWebhook, primitive.RuleSubject{
+			OrderId:     orderId,
+			PaymentType: transaction.PaymentType,
+			Amount:      transaction.TransactionAmount,
+		})
+		if err != nil {
+			log.Err(err).Str("orderId", orderId).Msg("applying drop webhook rules")
+		} else if ok {
+			log.Info().Str("orderId", orderId).Str("ruleId", rule.Id).Msg("dropping webhook by rule")
+			return
+		}
+	}
+
+	notificationUrls, err := n.transactionRepository.GetNotificationURLs(ctx, orderId)
+	if err != nil {
+		log.Err(err).Str("orderId", orderId).Msg("getting notification urls")
+		return
+	}
+
+	for _, targetUrl := range notificationUrls.Targets() {
+		_, err := n.webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+			OrderId:       orderId,
+			TargetURL:     targetUrl,
+			Payload:       payload,
+			NextAttemptAt: deliverAt,
+		})
+		if err != nil {
+			log.Err(err).Str("orderId", orderId).Str("targetUrl", targetUrl).Msg("enqueueing webhook")
+		}
+	}
+}
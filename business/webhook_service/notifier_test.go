@@ -0,0 +1,191 @@
+package webhook_service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"mock-payment-provider/business/rule_service"
+	"mock-payment-provider/business/webhook_service"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/rule"
+	"mock-payment-provider/repository/transaction"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+func TestNewNotifier(t *testing.T) {
+	t.Run("Nil Transaction Repository", func(t *testing.T) {
+		_, err := webhook_service.NewNotifier(webhook_service.NotifierConfig{})
+		if err.Error() != "nil transaction repository" {
+			t.Errorf("expecting an error of 'nil transaction repository', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Nil Webhook Outbox Repository", func(t *testing.T) {
+		_, err := webhook_service.NewNotifier(webhook_service.NotifierConfig{
+			TransactionRepository: &transaction.Repository{},
+		})
+		if err.Error() != "nil webhook outbox repository" {
+			t.Errorf("expecting an error of 'nil webhook outbox repository', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Nil Rule Service", func(t *testing.T) {
+		_, err := webhook_service.NewNotifier(webhook_service.NotifierConfig{
+			TransactionRepository:   &transaction.Repository{},
+			WebhookOutboxRepository: &webhook_outbox.Repository{},
+		})
+		if err.Error() != "nil rule service" {
+			t.Errorf("expecting an error of 'nil rule service', instead got %s", err.Error())
+		}
+	})
+}
+
+func TestNotifier_Send(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:?_txlock=exclusive&_foreign_keys=1&")
+	if err != nil {
+		t.Fatalf("opening sql database: %s", err.Error())
+	}
+	defer db.Close()
+
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("creating transaction repository: %s", err.Error())
+	}
+
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		t.Fatalf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	ruleRepository, err := rule.NewRuleRepository(db)
+	if err != nil {
+		t.Fatalf("creating rule repository: %s", err.Error())
+	}
+
+	for _, migrator := range []interface{ Migrate(context.Context) error }{
+		transactionRepository,
+		webhookOutboxRepository,
+		ruleRepository,
+	} {
+		err := migrator.Migrate(ctx)
+		if err != nil {
+			t.Fatalf("migrating database: %s", err.Error())
+		}
+	}
+
+	ruleService, err := rule_service.NewRuleService(rule_service.Config{RuleRepository: ruleRepository})
+	if err != nil {
+		t.Fatalf("creating rule service: %s", err.Error())
+	}
+
+	notifier, err := webhook_service.NewNotifier(webhook_service.NotifierConfig{
+		TransactionRepository:   transactionRepository,
+		WebhookOutboxRepository: webhookOutboxRepository,
+		RuleService:             ruleService,
+	})
+	if err != nil {
+		t.Fatalf("creating notifier: %s", err.Error())
+	}
+
+	createTransaction := func(t *testing.T, notificationURLs primitive.NotificationURLs) string {
+		t.Helper()
+
+		orderId := uuid.NewString()
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:          orderId,
+			Amount:           50000,
+			PaymentType:      primitive.PaymentTypeEMoneyGopay,
+			Status:           primitive.TransactionStatusPending,
+			ExpiredAt:        time.Now().Add(time.Hour),
+			NotificationURLs: notificationURLs,
+		})
+		if err != nil {
+			t.Fatalf("creating transaction: %s", err.Error())
+		}
+
+		return orderId
+	}
+
+	// claimTargets takes every due notification of the order, and returns their target URLs
+	claimTargets := func(t *testing.T, orderId string) []string {
+		t.Helper()
+
+		notifications, err := webhookOutboxRepository.ClaimDue(ctx, 100, time.Hour)
+		if err != nil {
+			t.Fatalf("claiming notifications: %s", err.Error())
+		}
+
+		var targets []string
+		for _, notification := range notifications {
+			if notification.OrderId == orderId {
+				targets = append(targets, notification.TargetURL)
+			}
+		}
+
+		return targets
+	}
+
+	t.Run("Every Receiver", func(t *testing.T) {
+		orderId := createTransaction(t, primitive.NotificationURLs{Append: []string{"https://example.com/notify"}})
+
+		notifier.Send(ctx, orderId, []byte(`{}`), time.Now())
+
+		targets := claimTargets(t, orderId)
+		if len(targets) != 2 {
+			t.Fatalf("expecting 2 notifications, instead got %d", len(targets))
+		}
+
+		if targets[0] != "" && targets[1] != "" {
+			t.Errorf("expecting a notification to the default target, instead got %v", targets)
+		}
+	})
+
+	t.Run("Not Due Yet", func(t *testing.T) {
+		orderId := createTransaction(t, primitive.NotificationURLs{})
+
+		notifier.Send(ctx, orderId, []byte(`{}`), time.Now().Add(time.Hour))
+
+		targets := claimTargets(t, orderId)
+		if len(targets) != 0 {
+			t.Errorf("expecting no due notification, instead got %d", len(targets))
+		}
+	})
+
+	t.Run("Dropped By Rule", func(t *testing.T) {
+		orderId := createTransaction(t, primitive.NotificationURLs{})
+
+		_, err := ruleService.Create(ctx, primitive.Rule{
+			OrderIdPattern: "^" + orderId + "$",
+			Action:         primitive.RuleActionDropWebhook,
+			OneShot:        true,
+		})
+		if err != nil {
+			t.Fatalf("creating rule: %s", err.Error())
+		}
+
+		notifier.Send(ctx, orderId, []byte(`{}`), time.Now())
+
+		targets := claimTargets(t, orderId)
+		if len(targets) != 0 {
+			t.Errorf("expecting the notification to be dropped, instead got %d", len(targets))
+		}
+
+		// The rule is used up by the first notification
+		notifier.Send(ctx, orderId, []byte(`{}`), time.Now())
+
+		targets = claimTargets(t, orderId)
+		if len(targets) != 1 {
+			t.Errorf("expecting 1 notification, instead got %d", len(targets))
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package webhook_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/business"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (d *Dependency) Resend(ctx context.Context, attemptId string) (primitive.WebhookNotification, error) {
+	attempt, err := d.webhookOutboxRepository.GetAttempt(ctx, attemptId)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return primitive.WebhookNotification{}, business.ErrWebhookAttemptNotFound
+		}
+
+		return primitive.WebhookNotification{}, fmt.Errorf("getting webhook attempt: %w", err)
+	}
+
+	// A resend starts over as a new notification, so it gets the whole retry schedule again
+	notification, err := d.webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+		OrderId:   attempt.OrderId,
+		TargetURL: attempt.TargetURL,
+		Payload:   attempt.Payload,
+	})
+	if err != nil {
+		return primitive.WebhookNotification{}, fmt.Errorf("enqueueing webhook: %w", err)
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,69 @@
+package webhook_service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+// deliveryLease is how long a claimed notification is kept from being claimed again.
+// It outlasts a delivery attempt by far, so it only matters when the service stops
+// before recording the attempt, in which case the notification is retried afterwards.
+const deliveryLease = time.Minute * 2
+
+func (d *Dependency) Run(ctx context.Context) {
+	log := zerolog.Ctx(ctx)
+
+	notifications := make(chan primitive.WebhookNotification)
+
+	var wg sync.WaitGroup
+	for i := 0; i < d.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for notification := range notifications {
+				d.deliver(log.WithContext(context.Background()), notification)
+			}
+		}()
+	}
+
+	defer func() {
+		close(notifications)
+		wg.Wait()
+	}()
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := d.webhookOutboxRepository.ClaimDue(ctx, d.workers, deliveryLease)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Err(err).Msg("claiming due webhook notifications")
+		}
+
+		for _, notification := range claimed {
+			select {
+			case notifications <- notification:
+			case <-ctx.Done():
+				// The rest of the claimed notifications are delivered once their lease ends
+				return
+			}
+		}
+
+		// A full batch means there might be more notifications that are already due
+		if len(claimed) == d.workers && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package webhook_service_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"mock-payment-provider/business/webhook_service"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/webhook"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+func TestDependency_Run(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:?_txlock=exclusive&_foreign_keys=1&")
+	if err != nil {
+		t.Fatalf("opening sql database: %s", err.Error())
+	}
+	defer db.Close()
+
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		t.Fatalf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	err = webhookOutboxRepository.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("migrating database: %s", err.Error())
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/found", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/not-found", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/internal-server-error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/service-unavailable", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/bad-gateway", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	webhookClient, err := webhook.NewWebhookClient(webhook.Config{TargetURL: server.URL + "/ok"})
+	if err != nil {
+		t.Fatalf("creating webhook client: %s", err.Error())
+	}
+
+	// The Midtrans schedule, compressed from hours to milliseconds
+	webhookService, err := webhook_service.NewWebhookService(webhook_service.Config{
+		WebhookOutboxRepository: webhookOutboxRepository,
+		WebhookClient:           webhookClient,
+		PollInterval:            time.Millisecond * 5,
+		RetryIntervals: []time.Duration{
+			time.Millisecond * 10,
+			time.Millisecond * 10,
+			time.Millisecond * 10,
+			time.Millisecond * 10,
+			time.Millisecond * 10,
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating webhook service: %s", err.Error())
+	}
+
+	runCtx, runCancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		webhookService.Run(runCtx)
+	}()
+	defer func() {
+		runCancel()
+		wg.Wait()
+	}()
+
+	testCases := []struct {
+		name             string
+		targetPath       string
+		expectedStatus   primitive.WebhookStatus
+		expectedAttempts int
+	}{
+		{name: "2xx", targetPath: "/ok", expectedStatus: primitive.WebhookStatusDelivered, expectedAttempts: 1},
+		{name: "307/308", targetPath: "/redirect", expectedStatus: primitive.WebhookStatusDelivered, expectedAttempts: 1},
+		{name: "301/302/303", targetPath: "/found", expectedStatus: primitive.WebhookStatusFailed, expectedAttempts: 1},
+		{name: "400/404", targetPath: "/not-found", expectedStatus: primitive.WebhookStatusFailed, expectedAttempts: 3},
+		{name: "500", targetPath: "/internal-server-error", expectedStatus: primitive.WebhookStatusFailed, expectedAttempts: 2},
+		{name: "503", targetPath: "/service-unavailable", expectedStatus: primitive.WebhookStatusFailed, expectedAttempts: 5},
+		{name: "Other Failures", targetPath: "/bad-gateway", expectedStatus: primitive.WebhookStatusFailed, expectedAttempts: 6},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			orderId := uuid.NewString()
+			_, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+				OrderId:   orderId,
+				TargetURL: server.URL + testCase.targetPath,
+				Payload:   []byte(`{"transaction_status":"settlement"}`),
+			})
+			if err != nil {
+				t.Fatalf("enqueueing notification: %s", err.Error())
+			}
+
+			var attempts []primitive.WebhookAttempt
+			deadline := time.Now().Add(time.Second * 10)
+			for time.Now().Before(deadline) {
+				attempts, err = webhookOutboxRepository.ListAttempts(ctx, orderId)
+				if err != nil {
+					t.Fatalf("listing attempts: %s", err.Error())
+				}
+
+				if len(attempts) > 0 && attempts[len(attempts)-1].Status != primitive.WebhookStatusPending {
+					break
+				}
+
+				time.Sleep(time.Millisecond * 10)
+			}
+
+			if len(attempts) == 0 {
+				t.Fatalf("expecting the notification to be attempted")
+			}
+
+			if status := attempts[len(attempts)-1].Status; status != testCase.expectedStatus {
+				t.Errorf("expecting the notification to end up %s, instead got %s", testCase.expectedStatus, status)
+			}
+
+			if len(attempts) != testCase.expectedAttempts {
+				t.Errorf("expecting %d attempts, instead got %d", testCase.expectedAttempts, len(attempts))
+			}
+		})
+	}
+}
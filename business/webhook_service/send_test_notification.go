@@ -0,0 +1,76 @@
+package webhook_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/signature"
+)
+
+// testNotificationAmount is the gross amount of the sample notification.
+const testNotificationAmount = 10_000
+
+func (d *Dependency) SendTest(ctx context.Context, targetUrl string) (primitive.WebhookAttempt, error) {
+	parsedUrl, err := url.Parse(targetUrl)
+	if targetUrl == "" || err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return primitive.WebhookAttempt{}, &business.RequestValidationError{Issues: []business.RequestValidationIssue{
+			{
+				Code:    business.RequestValidationCodeInvalidValue,
+				Field:   "url",
+				Message: "must be a valid http or https url",
+			},
+		}}
+	}
+
+	// The order id is made up, so the merchant can tell it apart from real orders
+	orderId := "test-" + uuid.NewString()
+	payload, err := json.Marshal(schema.GopayChargeSettlementResponse{
+		StatusCode:        "200",
+		StatusMessage:     "midtrans payment notification",
+		TransactionId:     orderId,
+		OrderId:           orderId,
+		GrossAmount:       strconv.FormatInt(testNotificationAmount, 10),
+		PaymentType:       primitive.PaymentTypeEMoneyGopay.ToPaymentMethod(),
+		TransactionTime:   time.Now().Format(time.DateTime),
+		TransactionStatus: primitive.TransactionStatusSettlement.String(),
+		SignatureKey:      signature.Generate(orderId, 200, testNotificationAmount, d.serverKey),
+	})
+	if err != nil {
+		return primitive.WebhookAttempt{}, fmt.Errorf("building test webhook message: %w", err)
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	attemptedAt := time.Now()
+	response, err := d.webhookClient.Send(sendCtx, targetUrl, payload)
+
+	attempt := primitive.WebhookAttempt{
+		OrderId:      orderId,
+		TargetURL:    response.TargetURL,
+		Payload:      payload,
+		Status:       primitive.WebhookStatusDelivered,
+		StatusCode:   response.StatusCode,
+		ResponseBody: response.Body,
+		Latency:      response.Latency,
+		AttemptedAt:  attemptedAt,
+	}
+
+	if err != nil {
+		attempt.Status = primitive.WebhookStatusFailed
+		attempt.Error = err.Error()
+	} else if response.StatusCode >= 400 {
+		attempt.Status = primitive.WebhookStatusFailed
+		attempt.Error = fmt.Sprintf("unexpected status code %d", response.StatusCode)
+	}
+
+	return attempt, nil
+}
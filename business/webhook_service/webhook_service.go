@@ -0,0 +1,83 @@
+package webhook_service
+
+import (
+	"fmt"
+	"time"
+
+	"mock-payment-provider/repository"
+)
+
+type Config struct {
+	// ServerKey signs the sample notification of SendTest.
+	ServerKey               string
+	WebhookOutboxRepository repository.WebhookOutboxRepository
+	WebhookClient           repository.WebhookClient
+	// Workers is the number of notifications that are delivered at the same time.
+	// Defaults to 4.
+	Workers int
+	// PollInterval is how often the outbox is checked for due notifications.
+	// Defaults to 1 second.
+	PollInterval time.Duration
+	// RetryIntervals is the wait before each retry of a failed delivery, which also caps
+	// the number of retries. Defaults to the Midtrans schedule, see DefaultRetryIntervals.
+	RetryIntervals []time.Duration
+}
+
+type Dependency struct {
+	serverKey               string
+	webhookOutboxRepository repository.WebhookOutboxRepository
+	webhookClient           repository.WebhookClient
+	workers                 int
+	pollInterval            time.Duration
+	retryIntervals          []time.Duration
+}
+
+// NewWebhookService validates input from Config and return an error if any of it is nil.
+// It implements business.Webhook interface.
+func NewWebhookService(config Config) (*Dependency, error) {
+	if config.WebhookOutboxRepository == nil {
+		return nil, fmt.Errorf("nil webhook outbox repository")
+	}
+
+	if config.WebhookClient == nil {
+		return nil, fmt.Errorf("nil webhook client")
+	}
+
+	if config.Workers < 0 {
+		return nil, fmt.Errorf("workers must not be negative")
+	}
+
+	if config.PollInterval < 0 {
+		return nil, fmt.Errorf("poll interval must not be negative")
+	}
+
+	workers := config.Workers
+	if workers == 0 {
+		workers = 4
+	}
+
+	pollInterval := config.PollInterval
+	if pollInterval == 0 {
+		pollInterval = time.Second
+	}
+
+	retryIntervals := config.RetryIntervals
+	if len(retryIntervals) == 0 {
+		retryIntervals = DefaultRetryIntervals
+	}
+
+	for _, interval := range retryIntervals {
+		if interval <= 0 {
+			return nil, fmt.Errorf("retry intervals must be greater than zero")
+		}
+	}
+
+	return &Dependency{
+		serverKey:               config.ServerKey,
+		webhookOutboxRepository: config.WebhookOutboxRepository,
+		webhookClient:           config.WebhookClient,
+		workers:                 workers,
+		pollInterval:            pollInterval,
+		retryIntervals:          retryIntervals,
+	}, nil
+}
@@ -1,6 +1,16 @@
 package main
 
-import "os"
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"mock-payment-provider/primitive"
+)
 
 type config struct {
 	httpHostname     string
@@ -8,17 +18,37 @@ type config struct {
 	databasePath     string
 	webhookTargetURL string
 	serverKey        string
+	publicBaseURL    string
+	webhookWorkers   int
+	// webhookTimeout and webhookRetryIntervals are left empty for the Midtrans defaults.
+	webhookTimeout        time.Duration
+	webhookRetryIntervals []time.Duration
+	// finishRedirectURL is left empty to keep the customer on the payment page.
+	finishRedirectURL string
+	// rulesFile is left empty to start without any rule.
+	rulesFile string
+	// magicValues is left empty to disable magic values.
+	magicValues []primitive.MagicValue
+	// autopay settles every pending charge after a delay between autopayMinimumDelay and
+	// autopayMaximumDelay, except autopayExpirePercent of them that expire instead.
+	autopay              bool
+	autopayMinimumDelay  time.Duration
+	autopayMaximumDelay  time.Duration
+	autopayExpirePercent int
+	// fraudChallenge is left empty to never challenge card charges.
+	fraudChallenge primitive.FraudChallengeTrigger
 }
 
 func defaultConfig() config {
 	return config{
-		httpHostname: "localhost",
-		httpPort:     "3000",
-		databasePath: "payment.db",
+		httpHostname:   "localhost",
+		httpPort:       "3000",
+		databasePath:   "payment.db",
+		webhookWorkers: 4,
 	}
 }
 
-func parseConfig() config {
+func parseConfig() (config, error) {
 	result := defaultConfig()
 
 	if v, ok := os.LookupEnv("HTTP_HOSTNAME"); ok {
@@ -37,9 +67,167 @@ func parseConfig() config {
 		result.webhookTargetURL = v
 	}
 
+	if v, ok := os.LookupEnv("WEBHOOK_WORKERS"); ok {
+		if workers, err := strconv.Atoi(v); err == nil && workers > 0 {
+			result.webhookWorkers = workers
+		}
+	}
+
+	if v, ok := os.LookupEnv("WEBHOOK_TIMEOUT"); ok {
+		if timeout, err := time.ParseDuration(v); err == nil && timeout > 0 {
+			result.webhookTimeout = timeout
+		}
+	}
+
+	// A comma separated list of durations, e.g. "1s,5s,10s" to retry failed webhooks
+	// in seconds instead of hours.
+	if v, ok := os.LookupEnv("WEBHOOK_RETRY_INTERVALS"); ok {
+		var intervals []time.Duration
+		for _, value := range strings.Split(v, ",") {
+			interval, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil || interval <= 0 {
+				intervals = nil
+				break
+			}
+
+			intervals = append(intervals, interval)
+		}
+
+		result.webhookRetryIntervals = intervals
+	}
+
 	if v, ok := os.LookupEnv("SERVER_KEY"); ok {
 		result.serverKey = v
 	}
 
-	return result
+	if v, ok := os.LookupEnv("PUBLIC_BASE_URL"); ok {
+		result.publicBaseURL = v
+	} else {
+		// Fallback to the HTTP listener address, which is enough for local usage
+		result.publicBaseURL = "http://" + net.JoinHostPort(result.httpHostname, result.httpPort)
+	}
+
+	if v, ok := os.LookupEnv("FINISH_REDIRECT_URL"); ok {
+		result.finishRedirectURL = v
+	}
+
+	// A JSON array of rules in the same format as /internal/rules, replacing the stored rules
+	if v, ok := os.LookupEnv("RULES_FILE"); ok {
+		result.rulesFile = v
+	}
+
+	// A comma separated list of magic values, e.g. "amount_suffix=01:settlement:5s,email_prefix=deny+:deny"
+	// to settle charges of 10001 after 5 seconds and deny charges of deny+qa@example.com right away.
+	// Conditions are joined with "&".
+	if v, ok := os.LookupEnv("MAGIC_VALUES"); ok {
+		magicValues, err := parseMagicValues(v)
+		if err != nil {
+			return config{}, fmt.Errorf("MAGIC_VALUES is invalid: %w", err)
+		}
+
+		result.magicValues = magicValues
+	}
+
+	// Either a single duration, e.g. "30s", or a range to pick from at random, e.g. "10s-1m"
+	if v, ok := os.LookupEnv("AUTOPAY_DELAY"); ok {
+		minimum, maximum, isRange := strings.Cut(v, "-")
+		if !isRange {
+			maximum = minimum
+		}
+
+		minimumDelay, minimumErr := time.ParseDuration(strings.TrimSpace(minimum))
+		maximumDelay, maximumErr := time.ParseDuration(strings.TrimSpace(maximum))
+		if minimumErr != nil || maximumErr != nil || minimumDelay < 0 || maximumDelay < minimumDelay {
+			return config{}, fmt.Errorf("AUTOPAY_DELAY must be a duration or an increasing range of durations, got %q", v)
+		}
+
+		result.autopay = true
+		result.autopayMinimumDelay = minimumDelay
+		result.autopayMaximumDelay = maximumDelay
+	}
+
+	if v, ok := os.LookupEnv("AUTOPAY_EXPIRE_PERCENT"); ok {
+		percent, err := strconv.Atoi(v)
+		if err != nil || percent < 0 || percent > 100 {
+			return config{}, fmt.Errorf("AUTOPAY_EXPIRE_PERCENT must be a number from 0 to 100, got %q", v)
+		}
+
+		result.autopayExpirePercent = percent
+	}
+
+	// Card charges of at least this amount are challenged, waiting for /{order_id}/approve or /{order_id}/deny
+	if v, ok := os.LookupEnv("FRAUD_CHALLENGE_MIN_AMOUNT"); ok {
+		amount, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || amount <= 0 {
+			return config{}, fmt.Errorf("FRAUD_CHALLENGE_MIN_AMOUNT must be a positive amount, got %q", v)
+		}
+
+		result.fraudChallenge.MinimumAmount = amount
+	}
+
+	// A regular expression on the customer's email, e.g. "^review\+"
+	if v, ok := os.LookupEnv("FRAUD_CHALLENGE_EMAIL_PATTERN"); ok {
+		emailPattern, err := regexp.Compile(v)
+		if err != nil {
+			return config{}, fmt.Errorf("FRAUD_CHALLENGE_EMAIL_PATTERN is not a valid regular expression: %w", err)
+		}
+
+		result.fraudChallenge.EmailPattern = emailPattern
+	}
+
+	return result, nil
+}
+
+var magicValueOutcomes = map[string]primitive.TransactionStatus{
+	primitive.TransactionStatusSettlement.String(): primitive.TransactionStatusSettlement,
+	primitive.TransactionStatusDeny.String():       primitive.TransactionStatusDeny,
+	primitive.TransactionStatusExpire.String():     primitive.TransactionStatusExpire,
+	primitive.TransactionStatusFailure.String():    primitive.TransactionStatusFailure,
+}
+
+func parseMagicValues(v string) ([]primitive.MagicValue, error) {
+	var magicValues []primitive.MagicValue
+	for _, entry := range strings.Split(v, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid magic value %q", entry)
+		}
+
+		var magicValue primitive.MagicValue
+		for _, condition := range strings.Split(parts[0], "&") {
+			key, value, _ := strings.Cut(condition, "=")
+			if value == "" {
+				return nil, fmt.Errorf("empty condition %s in %q", condition, entry)
+			}
+
+			switch key {
+			case "amount_suffix":
+				magicValue.AmountSuffix = value
+			case "email_prefix":
+				magicValue.EmailPrefix = value
+			case "order_id_prefix":
+				magicValue.OrderIdPrefix = value
+			default:
+				return nil, fmt.Errorf("invalid condition %s in %q", condition, entry)
+			}
+		}
+
+		outcome, ok := magicValueOutcomes[parts[1]]
+		if !ok {
+			return nil, fmt.Errorf("invalid outcome %s in %q", parts[1], entry)
+		}
+		magicValue.Outcome = outcome
+
+		if len(parts) == 3 {
+			delay, err := time.ParseDuration(parts[2])
+			if err != nil || delay < 0 {
+				return nil, fmt.Errorf("invalid delay %s in %q", parts[2], entry)
+			}
+			magicValue.Delay = delay
+		}
+
+		magicValues = append(magicValues, magicValue)
+	}
+
+	return magicValues, nil
 }
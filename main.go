@@ -7,26 +7,47 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+	"mock-payment-provider/business/autopay_service"
+	"mock-payment-provider/business/expiry_service"
+	"mock-payment-provider/business/outcome_service"
 	"mock-payment-provider/business/payment_service"
+	"mock-payment-provider/business/rule_service"
+	"mock-payment-provider/business/snap_service"
 	"mock-payment-provider/business/transaction_service"
+	"mock-payment-provider/business/webhook_service"
 	"mock-payment-provider/presentation"
+	"mock-payment-provider/repository/card_token"
+	"mock-payment-provider/repository/credit_card"
+	"mock-payment-provider/repository/cstore"
 	"mock-payment-provider/repository/emoney"
+	"mock-payment-provider/repository/outcome"
+	"mock-payment-provider/repository/refund"
+	"mock-payment-provider/repository/rule"
+	"mock-payment-provider/repository/snap"
 	"mock-payment-provider/repository/transaction"
 	"mock-payment-provider/repository/virtual_account"
 	"mock-payment-provider/repository/webhook"
+	"mock-payment-provider/repository/webhook_outbox"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
 func main() {
-	cfg := parseConfig()
-
 	log := zerolog.New(os.Stdout)
 
-	database, err := sql.Open("sqlite3", cfg.databasePath)
+	cfg, err := parseConfig()
+	if err != nil {
+		log.Fatal().Msgf("parsing config: %s", err.Error())
+	}
+
+	// Transactions take the write lock as they begin, so ones that read before writing (such
+	// as refunds summing up the previous refunds) wait for each other instead of failing
+	// with "database is locked" when both try to write
+	database, err := sql.Open("sqlite3", "file:"+cfg.databasePath+"?_txlock=immediate")
 	if err != nil {
 		log.Fatal().Msgf("opening sql connection: %s", err.Error())
 	}
@@ -52,17 +73,87 @@ func main() {
 		log.Fatal().Msgf("creating emoney repository: %s", err.Error())
 	}
 
-	webhookClient, err := webhook.NewWebhookClient(cfg.webhookTargetURL)
+	cstoreRepository, err := cstore.NewCStoreRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating cstore repository: %s", err.Error())
+	}
+
+	refundRepository, err := refund.NewRefundRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating refund repository: %s", err.Error())
+	}
+
+	creditCardRepository, err := credit_card.NewCreditCardRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating credit card repository: %s", err.Error())
+	}
+
+	cardTokenRepository, err := card_token.NewCardTokenRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating card token repository: %s", err.Error())
+	}
+
+	snapRepository, err := snap.NewSnapRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating snap repository: %s", err.Error())
+	}
+
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	ruleRepository, err := rule.NewRuleRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating rule repository: %s", err.Error())
+	}
+
+	outcomeRepository, err := outcome.NewOutcomeRepository(database)
+	if err != nil {
+		log.Fatal().Msgf("creating outcome repository: %s", err.Error())
+	}
+
+	webhookClient, err := webhook.NewWebhookClient(webhook.Config{
+		TargetURL: cfg.webhookTargetURL,
+		Timeout:   cfg.webhookTimeout,
+	})
 	if err != nil {
 		log.Fatal().Msgf("creating webhook client: %s", err.Error())
 	}
 
+	webhookService, err := webhook_service.NewWebhookService(webhook_service.Config{
+		ServerKey:               cfg.serverKey,
+		WebhookOutboxRepository: webhookOutboxRepository,
+		WebhookClient:           webhookClient,
+		Workers:                 cfg.webhookWorkers,
+		RetryIntervals:          cfg.webhookRetryIntervals,
+	})
+	if err != nil {
+		log.Fatal().Msgf("creating webhook service: %s", err.Error())
+	}
+
+	ruleService, err := rule_service.NewRuleService(rule_service.Config{
+		RuleRepository: ruleRepository,
+	})
+	if err != nil {
+		log.Fatal().Msgf("creating rule service: %s", err.Error())
+	}
+
 	transactionService, err := transaction_service.NewTransactionService(transaction_service.Config{
 		ServerKey:                cfg.serverKey,
 		TransactionRepository:    transactionRepository,
-		WebhookClient:            webhookClient,
+		WebhookOutboxRepository:  webhookOutboxRepository,
 		VirtualAccountRepository: virtualAccountRepository,
 		EMoneyRepository:         emoneyRepository,
+		CStoreRepository:         cstoreRepository,
+		RefundRepository:         refundRepository,
+		CreditCardRepository:     creditCardRepository,
+		CardTokenRepository:      cardTokenRepository,
+		PublicBaseURL:            cfg.publicBaseURL,
+		RuleService:              ruleService,
+		OutcomeRepository:        outcomeRepository,
+		MagicValues:              cfg.magicValues,
+		FraudChallenge:           cfg.fraudChallenge,
 	})
 	if err != nil {
 		log.Fatal().Msgf("creating transaction service: %s", err.Error())
@@ -71,21 +162,68 @@ func main() {
 	paymentService, err := payment_service.NewPaymentService(payment_service.Config{
 		ServerKey:                cfg.serverKey,
 		TransactionRepository:    transactionRepository,
-		WebhookClient:            webhookClient,
+		WebhookOutboxRepository:  webhookOutboxRepository,
 		EMoneyRepository:         emoneyRepository,
 		VirtualAccountRepository: virtualAccountRepository,
+		CStoreRepository:         cstoreRepository,
+		CreditCardRepository:     creditCardRepository,
+		CardTokenRepository:      cardTokenRepository,
+		RuleService:              ruleService,
 	})
 	if err != nil {
 		log.Fatal().Msgf("creating payment service: %s", err.Error())
 	}
 
+	snapService, err := snap_service.NewSnapService(snap_service.Config{
+		SnapRepository:     snapRepository,
+		TransactionService: transactionService,
+		PaymentService:     paymentService,
+		PublicBaseURL:      cfg.publicBaseURL,
+	})
+	if err != nil {
+		log.Fatal().Msgf("creating snap service: %s", err.Error())
+	}
+
+	expiryService, err := expiry_service.NewExpiryService(expiry_service.Config{
+		TransactionService: transactionService,
+	})
+	if err != nil {
+		log.Fatal().Msgf("creating expiry service: %s", err.Error())
+	}
+
+	outcomeService, err := outcome_service.NewOutcomeService(outcome_service.Config{
+		OutcomeRepository:  outcomeRepository,
+		TransactionService: transactionService,
+		PaymentService:     paymentService,
+	})
+	if err != nil {
+		log.Fatal().Msgf("creating outcome service: %s", err.Error())
+	}
+
+	autopayService, err := autopay_service.NewAutopayService(autopay_service.Config{
+		TransactionRepository: transactionRepository,
+		CreditCardRepository:  creditCardRepository,
+		TransactionService:    transactionService,
+		PaymentService:        paymentService,
+		MinimumDelay:          cfg.autopayMinimumDelay,
+		MaximumDelay:          cfg.autopayMaximumDelay,
+		ExpirePercent:         cfg.autopayExpirePercent,
+	})
+	if err != nil {
+		log.Fatal().Msgf("creating autopay service: %s", err.Error())
+	}
+
 	httpServer, err := presentation.NewPresenter(presentation.PresenterConfig{
-		Hostname:  cfg.httpHostname,
-		Port:      cfg.httpPort,
-		ServerKey: cfg.serverKey,
+		Hostname:          cfg.httpHostname,
+		Port:              cfg.httpPort,
+		ServerKey:         cfg.serverKey,
+		FinishRedirectURL: cfg.finishRedirectURL,
 		Dependency: &presentation.Dependency{
 			TransactionService: transactionService,
 			PaymentService:     paymentService,
+			SnapService:        snapService,
+			WebhookService:     webhookService,
+			RuleService:        ruleService,
 		},
 	})
 	if err != nil {
@@ -111,6 +249,94 @@ func main() {
 		log.Fatal().Msgf("migrating emoney repository: %s", err.Error())
 	}
 
+	err = cstoreRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating cstore repository: %s", err.Error())
+	}
+
+	err = refundRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating refund repository: %s", err.Error())
+	}
+
+	err = creditCardRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating credit card repository: %s", err.Error())
+	}
+
+	err = cardTokenRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating card token repository: %s", err.Error())
+	}
+
+	err = snapRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating snap repository: %s", err.Error())
+	}
+
+	err = webhookOutboxRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating webhook outbox repository: %s", err.Error())
+	}
+
+	err = outcomeRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating outcome repository: %s", err.Error())
+	}
+
+	err = ruleRepository.Migrate(ctx)
+	if err != nil {
+		log.Fatal().Msgf("migrating rule repository: %s", err.Error())
+	}
+
+	if cfg.rulesFile != "" {
+		rules, err := presentation.ReadRulesFile(cfg.rulesFile)
+		if err != nil {
+			log.Fatal().Msgf("reading rules file: %s", err.Error())
+		}
+
+		_, err = ruleService.Replace(ctx, rules)
+		if err != nil {
+			log.Fatal().Msgf("replacing rules: %s", err.Error())
+		}
+	}
+
+	// Run the background services until the HTTP server shuts down. Webhooks that were
+	// left pending and transactions that went overdue while the service was down are
+	// picked up right away.
+	backgroundCtx, backgroundCancel := context.WithCancel(log.WithContext(context.Background()))
+	defer backgroundCancel()
+
+	var background sync.WaitGroup
+	background.Add(2)
+
+	go func() {
+		defer background.Done()
+		webhookService.Run(backgroundCtx)
+	}()
+
+	go func() {
+		defer background.Done()
+		expiryService.Run(backgroundCtx)
+	}()
+
+	// Magic values are off unless enabled, so are their outcomes
+	if len(cfg.magicValues) > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			outcomeService.Run(backgroundCtx)
+		}()
+	}
+
+	if cfg.autopay {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			autopayService.Run(backgroundCtx)
+		}()
+	}
+
 	exitSignal := make(chan os.Signal, 1)
 	signal.Notify(exitSignal, os.Interrupt)
 
@@ -134,4 +360,9 @@ func main() {
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatal().Msgf("serving HTTP server: %s", err.Error())
 	}
+
+	// Let the webhooks and the background scans that are in flight finish, the rest is
+	// picked up on the next start
+	backgroundCancel()
+	background.Wait()
 }
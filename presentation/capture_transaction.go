@@ -0,0 +1,128 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+)
+
+// CaptureTransaction captures an authorized card transaction. The whole authorized
+// amount is captured if gross_amount is empty.
+func (p *Presenter) CaptureTransaction(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	orderId := chi.URLParam(r, "order_id")
+
+	var requestBody schema.CaptureTransactionRequest
+	// An empty body is allowed, it captures the whole amount
+	if r.ContentLength != 0 {
+		err := json.NewDecoder(r.Body).Decode(&requestBody)
+		if err != nil {
+			responseBody, e := json.Marshal(schema.Error{
+				StatusCode:    http.StatusBadRequest,
+				StatusMessage: "Malformed JSON",
+			})
+			if e != nil {
+				log.Err(e).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(responseBody)
+			return
+		}
+	}
+
+	// Call business logic
+	captureResponse, err := p.transactionService.Capture(r.Context(), orderId, business.CaptureRequest{
+		Amount: requestBody.GrossAmount,
+	})
+	if err != nil {
+		var statusCode int
+		var statusMessage string
+		var requestValidationError *business.RequestValidationError
+		switch {
+		case errors.Is(err, business.ErrTransactionNotFound):
+			statusCode = 404
+			statusMessage = "Transaction doesn't exist."
+		case errors.Is(err, business.ErrCannotModifyStatus):
+			statusCode = 412
+			statusMessage = "Merchant cannot modify the status of the transaction"
+		case errors.Is(err, business.ErrCaptureAmountExceeded):
+			statusCode = 412
+			statusMessage = "Capture amount is greater than the authorized amount of the transaction"
+		case errors.As(err, &requestValidationError):
+			statusCode = 400
+			statusMessage = requestValidationError.Error()
+		default:
+			log.Err(err).Str("order_id", orderId).Msg("executing business function")
+
+			responseBody, e := json.Marshal(schema.Error{
+				StatusCode:    http.StatusInternalServerError,
+				StatusMessage: "internal server error",
+			})
+			if e != nil {
+				log.Err(e).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(responseBody)
+			return
+		}
+
+		responseBody, e := json.Marshal(schema.Error{
+			StatusCode:    statusCode,
+			StatusMessage: statusMessage,
+			Id:            uuid.NewString(),
+		})
+		if e != nil {
+			log.Err(e).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.CaptureTransactionResponse{
+		StatusCode:        "200",
+		StatusMessage:     "Success, Credit Card capture transaction is successful",
+		TransactionId:     captureResponse.OrderId,
+		OrderId:           captureResponse.OrderId,
+		PaymentType:       captureResponse.PaymentType.ToPaymentMethod(),
+		TransactionTime:   captureResponse.TransactionTime.Format(time.DateTime),
+		TransactionStatus: captureResponse.TransactionStatus.String(),
+		GrossAmount:       strconv.FormatInt(captureResponse.CapturedAmount, 10),
+		Currency:          "IDR",
+		FraudStatus:       captureResponse.FraudStatus.String(),
+		Bank:              captureResponse.Bank,
+		MaskedCard:        captureResponse.MaskedCard,
+		CardType:          captureResponse.CardType,
+		ApprovalCode:      captureResponse.ApprovalCode,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
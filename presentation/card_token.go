@@ -0,0 +1,138 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+)
+
+// CardToken tokenizes the card details from the query parameters. Passing a saved
+// "token_id" along with "card_cvv" creates a new token from a saved card instead.
+func (p *Presenter) CardToken(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	cardToken, err := p.paymentService.CreateCardToken(r.Context(), cardTokenRequestFromQuery(r))
+	if err != nil {
+		writeCardTokenError(w, r, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.CardTokenResponse{
+		StatusCode:    "200",
+		StatusMessage: "OK, success request new token",
+		TokenId:       cardToken.TokenId,
+		MaskedCard:    cardToken.MaskedCard,
+		ExpiredAt:     cardToken.ExpiresAt.Format(time.DateTime),
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// CardRegister saves the card details from the query parameters, the returned saved
+// token can be used as token_id for the following charges.
+func (p *Presenter) CardRegister(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	cardToken, err := p.paymentService.RegisterCard(r.Context(), cardTokenRequestFromQuery(r))
+	if err != nil {
+		writeCardTokenError(w, r, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.CardRegisterResponse{
+		StatusCode:    "200",
+		StatusMessage: "OK, success register card",
+		SavedTokenId:  cardToken.TokenId,
+		MaskedCard:    cardToken.MaskedCard,
+		ExpiredAt:     cardToken.ExpiresAt.Format(time.DateTime),
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func cardTokenRequestFromQuery(r *http.Request) business.CardTokenRequest {
+	query := r.URL.Query()
+
+	return business.CardTokenRequest{
+		CardNumber:      query.Get("card_number"),
+		CardExpiryMonth: query.Get("card_exp_month"),
+		CardExpiryYear:  query.Get("card_exp_year"),
+		CardCVV:         query.Get("card_cvv"),
+		SavedTokenId:    query.Get("token_id"),
+	}
+}
+
+func writeCardTokenError(w http.ResponseWriter, r *http.Request, err error) {
+	log := zerolog.Ctx(r.Context())
+
+	var requestValidationError *business.RequestValidationError
+	if errors.As(err, &requestValidationError) {
+		validationError := schema.ValidationError{
+			Error: schema.Error{
+				StatusCode:    400,
+				StatusMessage: "One or more parameters in the payload is invalid.",
+			},
+		}
+		for _, issue := range requestValidationError.Issues {
+			validationError.Issues = append(validationError.Issues, schema.ValidationIssue{
+				Field:   issue.Field,
+				Code:    issue.Code.String(),
+				Message: fmt.Sprintf("%s %s", issue.Field, issue.Message),
+			})
+		}
+
+		responseBody, err := json.Marshal(validationError)
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(400)
+		w.Write(responseBody)
+		return
+	}
+
+	if errors.Is(err, business.ErrInvalidCardToken) {
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    411,
+			StatusMessage: "Token id is missing, invalid, or timed out",
+			Id:            uuid.NewString(),
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		w.Write(responseBody)
+		return
+	}
+
+	log.Err(err).Msg("creating card token")
+	w.WriteHeader(http.StatusInternalServerError)
+}
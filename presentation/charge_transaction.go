@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -54,6 +55,42 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cardTransactionType, err := parseCardTransactionType(requestBody)
+	if err != nil {
+		responseBody, e := json.Marshal(schema.Error{
+			StatusCode:    http.StatusBadRequest,
+			StatusMessage: err.Error(),
+		})
+		if e != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(responseBody)
+		return
+	}
+
+	customExpiry, err := parseCustomExpiry(requestBody)
+	if err != nil {
+		responseBody, e := json.Marshal(schema.Error{
+			StatusCode:    http.StatusBadRequest,
+			StatusMessage: err.Error(),
+		})
+		if e != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(responseBody)
+		return
+	}
+
 	var callbackURL string
 	if paymentType == primitive.PaymentTypeEMoneyGopay && requestBody.Gopay.EnableCallback {
 		callbackURL = requestBody.Gopay.CallbackURL
@@ -104,10 +141,26 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 		BankTransferOptions: business.BankTransferOptions{
 			VirtualAccountNumber: requestBody.BankTransfer.VirtualAccountNumber,
 			RecipientName:        requestBody.BankTransfer.Permata.RecipientName,
+			SubCompanyCode:       requestBody.BCA.SubCompanyCode,
+			FreeText: primitive.FreeText{
+				Inquiry: parseFreeTextLines(requestBody.BankTransfer.FreeText.Inquiry),
+				Payment: parseFreeTextLines(requestBody.BankTransfer.FreeText.Payment),
+			},
 		},
 		EMoneyOptions: business.EMoneyOptions{
 			CallbackURL: callbackURL,
 		},
+		CreditCardOptions: business.CreditCardOptions{
+			TokenId:     requestBody.CreditCard.TokenId,
+			Bank:        requestBody.CreditCard.Bank,
+			SaveTokenId: requestBody.CreditCard.SaveTokenId,
+			Type:        cardTransactionType,
+		},
+		CustomExpiry: customExpiry,
+		NotificationURLs: primitive.NotificationURLs{
+			Override: parseNotificationURLs(r.Header.Get("X-Override-Notification")),
+			Append:   parseNotificationURLs(r.Header.Get("X-Append-Notification")),
+		},
 	}
 	for _, item := range requestBody.ItemDetails {
 		chargeRequest.ProductItems = append(chargeRequest.ProductItems, business.ProductItem{
@@ -142,6 +195,41 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, business.ErrInvalidCardToken) {
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    411,
+				StatusMessage: "Token id is missing, invalid, or timed out",
+				Id:            uuid.NewString(),
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(200)
+			w.Write(responseBody)
+			return
+		}
+
+		if errors.Is(err, business.ErrVirtualAccountNumberTaken) {
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    400,
+				StatusMessage: "The va_number is already used by another customer",
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(400)
+			w.Write(responseBody)
+			return
+		}
+
 		if errors.Is(err, business.ErrMismatchedTransactionAmount) {
 			responseBody, err := json.Marshal(schema.Error{
 				StatusCode:    400,
@@ -211,6 +299,33 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A denied charge looks the same whatever the payment type is
+	if chargeResponse.TransactionStatus == primitive.TransactionStatusDeny {
+		responseBody, err := json.Marshal(schema.ChargeDenyResponse{
+			StatusCode:        "202",
+			StatusMessage:     "Transaction is denied",
+			TransactionId:     chargeResponse.OrderId,
+			OrderId:           chargeResponse.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(chargeResponse.TransactionAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
+			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
+			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			FraudStatus:       "accept",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	}
+
 	var emoneyActions []struct {
 		Name   string `json:"name"`
 		Method string `json:"method"`
@@ -241,6 +356,7 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			PaymentType:            chargeResponse.PaymentType.ToPaymentMethod(),
 			TransactionTime:        chargeResponse.TransactionTime.Format(time.DateTime),
 			TransactionStatus:      chargeResponse.TransactionStatus.String(),
+			ExpiryTime:             chargeResponse.ExpiresAt.Format(time.DateTime),
 			Actions:                emoneyActions,
 			ChannelResponseCode:    "200",
 			ChannelResponseMessage: "Success",
@@ -270,6 +386,7 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			PaymentType:            chargeResponse.PaymentType.ToPaymentMethod(),
 			TransactionTime:        chargeResponse.TransactionTime.Format(time.DateTime),
 			TransactionStatus:      chargeResponse.TransactionStatus.String(),
+			ExpiryTime:             chargeResponse.ExpiresAt.Format(time.DateTime),
 			FraudStatus:            "accept",
 			Actions:                emoneyActions,
 		})
@@ -295,9 +412,11 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
 			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
 			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
 			FraudStatus:       "accept",
 			Acquirer:          "nobu",
-			Actions:           nil,
+			QRString:          chargeResponse.QRString,
+			Actions:           emoneyActions,
 		})
 		if err != nil {
 			log.Err(err).Msg("marshaling json")
@@ -319,6 +438,7 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
 			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
 			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
 			VaNumbers: []struct {
 				Bank     string `json:"bank"`
 				VaNumber string `json:"va_number"`
@@ -351,6 +471,7 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
 			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
 			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
 			VaNumbers: []struct {
 				Bank     string `json:"bank"`
 				VaNumber string `json:"va_number"`
@@ -383,6 +504,40 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
 			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
 			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
+			VaNumbers: []struct {
+				Bank     string `json:"bank"`
+				VaNumber string `json:"va_number"`
+			}{
+				{
+					Bank:     chargeResponse.VirtualAccountAction.Bank,
+					VaNumber: chargeResponse.VirtualAccountAction.VirtualAccountNumber,
+				},
+			},
+			FraudStatus: "accept",
+			Currency:    "IDR",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	case primitive.PaymentTypeVirtualAccountCIMB:
+		responseBody, err := json.Marshal(schema.CIMBVirtualAccountChargeSuccessResponse{
+			StatusCode:        "201",
+			StatusMessage:     "Success, Bank Transfer transaction is created",
+			TransactionId:     chargeResponse.OrderId,
+			OrderId:           chargeResponse.OrderId,
+			GrossAmount:       strconv.FormatInt(chargeResponse.TransactionAmount, 10),
+			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
+			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
+			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
 			VaNumbers: []struct {
 				Bank     string `json:"bank"`
 				VaNumber string `json:"va_number"`
@@ -401,6 +556,123 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	case primitive.PaymentTypeVirtualAccountBSI:
+		responseBody, err := json.Marshal(schema.BSIVirtualAccountChargeSuccessResponse{
+			StatusCode:        "201",
+			StatusMessage:     "Success, Bank Transfer transaction is created",
+			TransactionId:     chargeResponse.OrderId,
+			OrderId:           chargeResponse.OrderId,
+			GrossAmount:       strconv.FormatInt(chargeResponse.TransactionAmount, 10),
+			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
+			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
+			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
+			VaNumbers: []struct {
+				Bank     string `json:"bank"`
+				VaNumber string `json:"va_number"`
+			}{
+				{
+					Bank:     chargeResponse.VirtualAccountAction.Bank,
+					VaNumber: chargeResponse.VirtualAccountAction.VirtualAccountNumber,
+				},
+			},
+			FraudStatus: "accept",
+			Currency:    "IDR",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	case primitive.PaymentTypeMandiriBill:
+		responseBody, err := json.Marshal(schema.MandiriBillChargeSuccessResponse{
+			StatusCode:        "201",
+			StatusMessage:     "OK, Mandiri Bill transaction is successful",
+			TransactionId:     chargeResponse.OrderId,
+			OrderId:           chargeResponse.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(chargeResponse.TransactionAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
+			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
+			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
+			FraudStatus:       "accept",
+			BillKey:           chargeResponse.VirtualAccountAction.VirtualAccountNumber,
+			BillerCode:        primitive.MandiriBillerCode,
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	case primitive.PaymentTypeCStoreIndomaret:
+		fallthrough
+	case primitive.PaymentTypeCStoreAlfamart:
+		responseBody, err := json.Marshal(schema.CStoreChargeSuccessResponse{
+			StatusCode:        "201",
+			StatusMessage:     "Success, cstore transaction is successful",
+			TransactionId:     chargeResponse.OrderId,
+			OrderId:           chargeResponse.OrderId,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(chargeResponse.TransactionAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
+			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
+			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
+			FraudStatus:       "accept",
+			PaymentCode:       chargeResponse.CStoreAction.PaymentCode,
+			Store:             chargeResponse.CStoreAction.Store,
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	case primitive.PaymentTypePaylaterAkulaku:
+		fallthrough
+	case primitive.PaymentTypePaylaterKredivo:
+		responseBody, err := json.Marshal(schema.PaylaterChargeSuccessResponse{
+			StatusCode:        "201",
+			StatusMessage:     "Success, " + chargeResponse.PaymentType.ToPaymentMethod() + " transaction is created",
+			TransactionId:     chargeResponse.OrderId,
+			OrderId:           chargeResponse.OrderId,
+			RedirectURL:       chargeResponse.PaylaterAction.RedirectURL,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(chargeResponse.TransactionAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
+			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
+			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
+			FraudStatus:       "accept",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write(responseBody)
@@ -415,6 +687,7 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
 			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
 			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
 			FraudStatus:       "accept",
 			PermataVaNumber:   chargeResponse.VirtualAccountAction.VirtualAccountNumber,
 		})
@@ -424,6 +697,43 @@ func (p *Presenter) ChargeTransaction(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	case primitive.PaymentTypeCreditCard:
+		var savedTokenIdExpiredAt string
+		if chargeResponse.CreditCardAction.SavedTokenId != "" {
+			savedTokenIdExpiredAt = chargeResponse.CreditCardAction.SavedTokenIdExpiresAt.Format(time.DateTime)
+		}
+
+		responseBody, err := json.Marshal(schema.CreditCardChargeSuccessResponse{
+			StatusCode:        "201",
+			StatusMessage:     "OK, success do 3DS authentication",
+			Bank:              chargeResponse.CreditCardAction.Bank,
+			TransactionId:     chargeResponse.OrderId,
+			OrderId:           chargeResponse.OrderId,
+			RedirectURL:       chargeResponse.CreditCardAction.RedirectURL,
+			MerchantId:        "MOCK",
+			GrossAmount:       strconv.FormatInt(chargeResponse.TransactionAmount, 10),
+			Currency:          "IDR",
+			PaymentType:       chargeResponse.PaymentType.ToPaymentMethod(),
+			TransactionTime:   chargeResponse.TransactionTime.Format(time.DateTime),
+			TransactionStatus: chargeResponse.TransactionStatus.String(),
+			ExpiryTime:        chargeResponse.ExpiresAt.Format(time.DateTime),
+			FraudStatus:       "accept",
+			MaskedCard:        chargeResponse.CreditCardAction.MaskedCard,
+			CardType:          chargeResponse.CreditCardAction.CardType,
+			// Saved token
+			SavedTokenId:          chargeResponse.CreditCardAction.SavedTokenId,
+			SavedTokenIdExpiredAt: savedTokenIdExpiredAt,
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write(responseBody)
@@ -446,6 +756,23 @@ func parseValidPaymentMethod(r schema.ChargeTransactionRequest) (primitive.Payme
 		return primitive.PaymentTypeEMoneyShopeePay, nil
 	case "qris":
 		return primitive.PaymentTypeEMoneyQRIS, nil
+	case "echannel":
+		return primitive.PaymentTypeMandiriBill, nil
+	case "credit_card":
+		return primitive.PaymentTypeCreditCard, nil
+	case "akulaku":
+		return primitive.PaymentTypePaylaterAkulaku, nil
+	case "kredivo":
+		return primitive.PaymentTypePaylaterKredivo, nil
+	case "cstore":
+		switch r.CStore.Store {
+		case "indomaret":
+			return primitive.PaymentTypeCStoreIndomaret, nil
+		case "alfamart":
+			return primitive.PaymentTypeCStoreAlfamart, nil
+		default:
+			return primitive.PaymentTypeUnspecified, fmt.Errorf("invalid store name")
+		}
 	case "bank_transfer":
 		switch r.BankTransfer.Bank {
 		case "bca":
@@ -456,6 +783,10 @@ func parseValidPaymentMethod(r schema.ChargeTransactionRequest) (primitive.Payme
 			return primitive.PaymentTypeVirtualAccountPermata, nil
 		case "bni":
 			return primitive.PaymentTypeVirtualAccountBNI, nil
+		case "cimb":
+			return primitive.PaymentTypeVirtualAccountCIMB, nil
+		case "bsi":
+			return primitive.PaymentTypeVirtualAccountBSI, nil
 		default:
 			return primitive.PaymentTypeUnspecified, fmt.Errorf("invalid bank name")
 		}
@@ -463,3 +794,94 @@ func parseValidPaymentMethod(r schema.ChargeTransactionRequest) (primitive.Payme
 		return primitive.PaymentTypeUnspecified, fmt.Errorf("unknown payment type")
 	}
 }
+
+func parseCardTransactionType(r schema.ChargeTransactionRequest) (primitive.CardTransactionType, error) {
+	switch r.CreditCard.Type {
+	case "":
+		return primitive.CardTransactionTypeUnspecified, nil
+	case "authorize_capture":
+		return primitive.CardTransactionTypeAuthorizeCapture, nil
+	case "authorize":
+		return primitive.CardTransactionTypeAuthorize, nil
+	default:
+		return primitive.CardTransactionTypeUnspecified, fmt.Errorf("unknown credit card transaction type")
+	}
+}
+
+// customExpiryOrderTimeLayout is the format of custom_expiry.order_time, e.g. "2016-12-07 11:54:12 +0700".
+const customExpiryOrderTimeLayout = "2006-01-02 15:04:05 -0700"
+
+func parseCustomExpiry(r schema.ChargeTransactionRequest) (*business.CustomExpiry, error) {
+	if r.CustomExpiry == nil {
+		return nil, nil
+	}
+
+	// The order time is optional, the expiry is counted from the charge itself without it
+	var orderTime time.Time
+	if r.CustomExpiry.OrderTime != "" {
+		var err error
+		orderTime, err = time.Parse(customExpiryOrderTimeLayout, r.CustomExpiry.OrderTime)
+		if err != nil {
+			return nil, fmt.Errorf("custom_expiry.order_time must be in yyyy-MM-dd hh:mm:ss Z format")
+		}
+
+		// Keep the instant but drop the merchant's offset, every other time is in the server's zone
+		orderTime = orderTime.Local()
+	}
+
+	return &business.CustomExpiry{
+		OrderTime:      orderTime,
+		ExpiryDuration: r.CustomExpiry.ExpiryDuration,
+		Unit:           expiryUnitMap[r.CustomExpiry.Unit],
+	}, nil
+}
+
+// parseNotificationURLs splits the comma separated URLs of X-Override-Notification
+// and X-Append-Notification.
+func parseNotificationURLs(header string) []string {
+	var urls []string
+	for _, notificationUrl := range strings.Split(header, ",") {
+		notificationUrl = strings.TrimSpace(notificationUrl)
+		if notificationUrl != "" {
+			urls = append(urls, notificationUrl)
+		}
+	}
+
+	return urls
+}
+
+func parseFreeTextLines(lines []schema.FreeTextLine) []primitive.FreeTextLine {
+	var freeTextLines []primitive.FreeTextLine
+	for _, line := range lines {
+		freeTextLines = append(freeTextLines, primitive.FreeTextLine{
+			Indonesian: line.Indonesian,
+			English:    line.English,
+		})
+	}
+
+	return freeTextLines
+}
+
+// buildFreeText returns nil if there is no free text, so it is left out of the response.
+func buildFreeText(freeText primitive.FreeText) *schema.FreeText {
+	if freeText.Empty() {
+		return nil
+	}
+
+	buildLines := func(lines []primitive.FreeTextLine) []schema.FreeTextLine {
+		var freeTextLines []schema.FreeTextLine
+		for _, line := range lines {
+			freeTextLines = append(freeTextLines, schema.FreeTextLine{
+				Indonesian: line.Indonesian,
+				English:    line.English,
+			})
+		}
+
+		return freeTextLines
+	}
+
+	return &schema.FreeText{
+		Inquiry: buildLines(freeText.Inquiry),
+		Payment: buildLines(freeText.Payment),
+	}
+}
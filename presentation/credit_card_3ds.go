@@ -0,0 +1,171 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+// CreditCard3DSPage serves the simulated 3-D Secure page, where the customer enters
+// the OTP sent by their card issuer. API clients that don't ask for HTML will get the
+// payment detail as JSON instead.
+func (p *Presenter) CreditCard3DSPage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getCreditCardDetail(r, id)
+	if err != nil {
+		p.writeCreditCard3DSError(w, r, err)
+		return
+	}
+
+	p.writeCreditCard3DSDetail(w, r, detail, "Success, transaction found")
+}
+
+// CreditCard3DSAuthenticate submits the OTP from the "otp" form value. The card is
+// captured if the OTP is correct, and denied otherwise.
+func (p *Presenter) CreditCard3DSAuthenticate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getCreditCardDetail(r, id)
+	if err != nil {
+		p.writeCreditCard3DSError(w, r, err)
+		return
+	}
+
+	_, err = p.paymentService.AuthenticateCard(r.Context(), detail.CreditCardID, r.FormValue("otp"))
+	if err != nil {
+		p.writeCreditCard3DSError(w, r, err)
+		return
+	}
+
+	if acceptsHTML(r) {
+		http.Redirect(w, r, "/3ds/"+id, http.StatusSeeOther)
+		return
+	}
+
+	detail, err = p.getCreditCardDetail(r, id)
+	if err != nil {
+		p.writeCreditCard3DSError(w, r, err)
+		return
+	}
+
+	p.writeCreditCard3DSDetail(w, r, detail, "Success, 3DS authentication is completed")
+}
+
+// getCreditCardDetail acquires the payment detail, making sure the ID belongs to a credit
+// card charge and not to any other payment type.
+func (p *Presenter) getCreditCardDetail(r *http.Request, id string) (business.PaymentDetailsResponse, error) {
+	if id == "" {
+		return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+	}
+
+	detail, err := p.paymentService.GetDetail(r.Context(), id)
+	if err != nil {
+		return business.PaymentDetailsResponse{}, err
+	}
+
+	if detail.CreditCardID == "" {
+		return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+	}
+
+	return detail, nil
+}
+
+type creditCard3DSPageData struct {
+	Error             string
+	CreditCardId      string
+	OrderId           string
+	MaskedCard        string
+	GrossAmount       string
+	TransactionStatus string
+	Authenticable     bool
+}
+
+func (p *Presenter) writeCreditCard3DSDetail(w http.ResponseWriter, r *http.Request, detail business.PaymentDetailsResponse, statusMessage string) {
+	log := zerolog.Ctx(r.Context())
+
+	if acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		err := templates.ExecuteTemplate(w, "credit_card_3ds.html", creditCard3DSPageData{
+			CreditCardId:      detail.CreditCardID,
+			OrderId:           detail.OrderId,
+			MaskedCard:        detail.MaskedCard,
+			GrossAmount:       strconv.FormatInt(detail.ChargedAmount, 10),
+			TransactionStatus: detail.Status.String(),
+			Authenticable:     detail.Status == primitive.TransactionStatusPending,
+		})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.CreditCard3DSResponse{
+		StatusCode:        "200",
+		StatusMessage:     statusMessage,
+		OrderId:           detail.OrderId,
+		GrossAmount:       strconv.FormatInt(detail.ChargedAmount, 10),
+		Currency:          "IDR",
+		PaymentType:       detail.PaymentMethod.ToPaymentMethod(),
+		MaskedCard:        detail.MaskedCard,
+		TransactionStatus: detail.Status.String(),
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (p *Presenter) writeCreditCard3DSError(w http.ResponseWriter, r *http.Request, err error) {
+	log := zerolog.Ctx(r.Context())
+
+	statusCode := http.StatusInternalServerError
+	statusMessage := "Internal server error."
+	switch {
+	case errors.Is(err, business.ErrTransactionNotFound):
+		statusCode = http.StatusNotFound
+		statusMessage = "Transaction doesn't exist."
+	case errors.Is(err, business.ErrCannotModifyStatus):
+		statusCode = http.StatusPreconditionFailed
+		statusMessage = "Transaction is no longer waiting for 3DS authentication."
+	default:
+		log.Err(err).Msg("executing business function")
+	}
+
+	if acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(statusCode)
+		err := templates.ExecuteTemplate(w, "credit_card_3ds.html", creditCard3DSPageData{Error: statusMessage})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.Error{
+		StatusCode:    statusCode,
+		StatusMessage: statusMessage,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(responseBody)
+}
@@ -0,0 +1,32 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EMoneyCancel cancels the e-money payment, as if the customer canceled the payment
+// from their e-wallet app.
+func (p *Presenter) EMoneyCancel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := p.paymentService.Cancel(r.Context(), id)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	if acceptsHTML(r) {
+		http.Redirect(w, r, "/e-money/"+id+"/pay", http.StatusSeeOther)
+		return
+	}
+
+	detail, err := p.getEMoneyDetail(r, id)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	p.writeEMoneyDetail(w, r, detail, "Success, transaction is canceled")
+}
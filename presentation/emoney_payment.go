@@ -0,0 +1,173 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+// EMoneyPaymentPage serves the simulated e-wallet page for the customer. API clients
+// that don't ask for HTML will get the payment detail as JSON instead.
+func (p *Presenter) EMoneyPaymentPage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getEMoneyDetail(r, id)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	p.writeEMoneyDetail(w, r, detail, "Success, transaction found")
+}
+
+// EMoneyPay settles the e-money payment, as if the customer confirmed the payment
+// from their e-wallet app.
+func (p *Presenter) EMoneyPay(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getEMoneyDetail(r, id)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	err = p.paymentService.MarkAsPaid(r.Context(), detail.OrderId, detail.PaymentMethod)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	if acceptsHTML(r) {
+		http.Redirect(w, r, "/e-money/"+id+"/pay", http.StatusSeeOther)
+		return
+	}
+
+	detail, err = p.getEMoneyDetail(r, id)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	p.writeEMoneyDetail(w, r, detail, "Success, transaction is paid")
+}
+
+// getEMoneyDetail acquires the payment detail, making sure the ID belongs to an e-money
+// entry and not to a virtual account number.
+func (p *Presenter) getEMoneyDetail(r *http.Request, id string) (business.PaymentDetailsResponse, error) {
+	if id == "" {
+		return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+	}
+
+	detail, err := p.paymentService.GetDetail(r.Context(), id)
+	if err != nil {
+		return business.PaymentDetailsResponse{}, err
+	}
+
+	if detail.EMoneyID == "" {
+		return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+	}
+
+	return detail, nil
+}
+
+type eMoneyPaymentPageData struct {
+	Error             string
+	EMoneyId          string
+	OrderId           string
+	PaymentType       string
+	GrossAmount       string
+	TransactionStatus string
+	QRString          string
+	Payable           bool
+}
+
+func (p *Presenter) writeEMoneyDetail(w http.ResponseWriter, r *http.Request, detail business.PaymentDetailsResponse, statusMessage string) {
+	log := zerolog.Ctx(r.Context())
+
+	if acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		err := templates.ExecuteTemplate(w, "emoney_pay.html", eMoneyPaymentPageData{
+			EMoneyId:          detail.EMoneyID,
+			OrderId:           detail.OrderId,
+			PaymentType:       detail.PaymentMethod.ToPaymentMethod(),
+			GrossAmount:       strconv.FormatInt(detail.ChargedAmount, 10),
+			TransactionStatus: detail.Status.String(),
+			QRString:          detail.QRString,
+			Payable:           detail.Status == primitive.TransactionStatusPending,
+		})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.EMoneyPaymentResponse{
+		StatusCode:        "200",
+		StatusMessage:     statusMessage,
+		EMoneyId:          detail.EMoneyID,
+		OrderId:           detail.OrderId,
+		GrossAmount:       strconv.FormatInt(detail.ChargedAmount, 10),
+		Currency:          "IDR",
+		PaymentType:       detail.PaymentMethod.ToPaymentMethod(),
+		TransactionStatus: detail.Status.String(),
+		QRString:          detail.QRString,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (p *Presenter) writeEMoneyError(w http.ResponseWriter, r *http.Request, err error) {
+	log := zerolog.Ctx(r.Context())
+
+	statusCode := http.StatusInternalServerError
+	statusMessage := "Internal server error."
+	switch {
+	case errors.Is(err, business.ErrTransactionNotFound):
+		statusCode = http.StatusNotFound
+		statusMessage = "Transaction doesn't exist."
+	case errors.Is(err, business.ErrCannotModifyStatus):
+		statusCode = http.StatusPreconditionFailed
+		statusMessage = "Transaction is no longer waiting for payment."
+	default:
+		log.Err(err).Msg("executing business function")
+	}
+
+	if acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(statusCode)
+		err := templates.ExecuteTemplate(w, "emoney_pay.html", eMoneyPaymentPageData{Error: statusMessage})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.Error{
+		StatusCode:    statusCode,
+		StatusMessage: statusMessage,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(responseBody)
+}
@@ -0,0 +1,47 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/skip2/go-qrcode"
+	"mock-payment-provider/business"
+)
+
+// EMoneyQRCode renders the QRIS payload of the e-money payment as a PNG image.
+// The image size (in pixels) can be set through the size query parameter.
+func (p *Presenter) EMoneyQRCode(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getEMoneyDetail(r, id)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	if detail.QRString == "" {
+		// The payment type can't be paid through QR code (e.g. ShopeePay)
+		p.writeEMoneyError(w, r, business.ErrTransactionNotFound)
+		return
+	}
+
+	size := 256
+	if v, err := strconv.Atoi(r.URL.Query().Get("size")); err == nil && v >= 64 && v <= 1024 {
+		size = v
+	}
+
+	image, err := qrcode.Encode(detail.QRString, qrcode.Medium, size)
+	if err != nil {
+		log.Err(err).Msg("encoding qr code")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
@@ -0,0 +1,19 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (p *Presenter) EMoneyStatus(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getEMoneyDetail(r, id)
+	if err != nil {
+		p.writeEMoneyError(w, r, err)
+		return
+	}
+
+	p.writeEMoneyDetail(w, r, detail, "Success, transaction found")
+}
@@ -0,0 +1,119 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+)
+
+// ApproveTransaction accepts a card transaction that was challenged by the fraud
+// detection system.
+func (p *Presenter) ApproveTransaction(w http.ResponseWriter, r *http.Request) {
+	orderId := chi.URLParam(r, "order_id")
+
+	fraudReviewResponse, err := p.transactionService.Approve(r.Context(), orderId)
+	if err != nil {
+		writeFraudReviewError(w, r, orderId, err)
+		return
+	}
+
+	writeFraudReviewResponse(w, r, "Success, transaction is approved", fraudReviewResponse)
+}
+
+// DenyTransaction denies a card transaction that was challenged by the fraud
+// detection system.
+func (p *Presenter) DenyTransaction(w http.ResponseWriter, r *http.Request) {
+	orderId := chi.URLParam(r, "order_id")
+
+	fraudReviewResponse, err := p.transactionService.Deny(r.Context(), orderId)
+	if err != nil {
+		writeFraudReviewError(w, r, orderId, err)
+		return
+	}
+
+	writeFraudReviewResponse(w, r, "Success, transaction is denied", fraudReviewResponse)
+}
+
+func writeFraudReviewResponse(w http.ResponseWriter, r *http.Request, statusMessage string, fraudReviewResponse business.FraudReviewResponse) {
+	log := zerolog.Ctx(r.Context())
+
+	responseBody, err := json.Marshal(schema.FraudReviewTransactionResponse{
+		StatusCode:        "200",
+		StatusMessage:     statusMessage,
+		TransactionId:     fraudReviewResponse.OrderId,
+		OrderId:           fraudReviewResponse.OrderId,
+		PaymentType:       fraudReviewResponse.PaymentType.ToPaymentMethod(),
+		TransactionTime:   fraudReviewResponse.TransactionTime.Format(time.DateTime),
+		TransactionStatus: fraudReviewResponse.TransactionStatus.String(),
+		GrossAmount:       strconv.FormatInt(fraudReviewResponse.TransactionAmount, 10),
+		Currency:          "IDR",
+		FraudStatus:       fraudReviewResponse.FraudStatus.String(),
+		Bank:              fraudReviewResponse.Bank,
+		MaskedCard:        fraudReviewResponse.MaskedCard,
+		ApprovalCode:      fraudReviewResponse.ApprovalCode,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func writeFraudReviewError(w http.ResponseWriter, r *http.Request, orderId string, err error) {
+	log := zerolog.Ctx(r.Context())
+
+	var statusCode int
+	var statusMessage string
+	switch {
+	case errors.Is(err, business.ErrTransactionNotFound):
+		statusCode = 404
+		statusMessage = "Transaction doesn't exist."
+	case errors.Is(err, business.ErrCannotModifyStatus):
+		statusCode = 412
+		statusMessage = "Merchant cannot modify the status of the transaction"
+	default:
+		log.Err(err).Str("order_id", orderId).Msg("executing business function")
+
+		responseBody, e := json.Marshal(schema.Error{
+			StatusCode:    http.StatusInternalServerError,
+			StatusMessage: "internal server error",
+		})
+		if e != nil {
+			log.Err(e).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(responseBody)
+		return
+	}
+
+	responseBody, e := json.Marshal(schema.Error{
+		StatusCode:    statusCode,
+		StatusMessage: statusMessage,
+		Id:            uuid.NewString(),
+	})
+	if e != nil {
+		log.Err(e).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
@@ -0,0 +1,104 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+)
+
+// InternalCStorePay settles a convenience store charge from its payment code, just like
+// the cashier of the store would after the customer pays.
+func (p *Presenter) InternalCStorePay(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var requestBody schema.InternalCStorePayRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil || requestBody.PaymentCode == "" {
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    400,
+			StatusMessage: "Invalid request body",
+			Id:            "",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(responseBody)
+		return
+	}
+
+	detail, err := p.paymentService.GetDetail(r.Context(), requestBody.PaymentCode)
+	if err == nil && detail.PaymentCode != requestBody.PaymentCode {
+		// The ID belongs to another payment method
+		err = business.ErrTransactionNotFound
+	}
+
+	if err == nil {
+		err = p.paymentService.MarkAsPaid(r.Context(), detail.OrderId, detail.PaymentMethod)
+	}
+
+	if err != nil {
+		if errors.Is(err, business.ErrCannotModifyStatus) {
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    400,
+				StatusMessage: "Transaction is not from PENDING status",
+				Id:            "",
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(responseBody)
+			return
+		}
+
+		if errors.Is(err, business.ErrTransactionNotFound) {
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    400,
+				StatusMessage: "Payment code was not found",
+				Id:            "",
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(responseBody)
+			return
+		}
+
+		log.Err(err).Str("payment_code", requestBody.PaymentCode).Msg("executing business function")
+
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    500,
+			StatusMessage: err.Error(),
+			Id:            "",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(responseBody)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
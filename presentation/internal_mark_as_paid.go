@@ -52,6 +52,25 @@ func (p *Presenter) InternalMarkAsPaid(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		var requestValidationError *business.RequestValidationError
+		if errors.As(err, &requestValidationError) {
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    400,
+				StatusMessage: requestValidationError.Error(),
+				Id:            "",
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(responseBody)
+			return
+		}
+
 		if errors.Is(err, business.ErrTransactionNotFound) {
 			responseBody, err := json.Marshal(schema.Error{
 				StatusCode:    400,
@@ -0,0 +1,195 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+// InternalListRules lists every rule, from the oldest one, which is also the order they
+// are applied in.
+func (p *Presenter) InternalListRules(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	rules, err := p.ruleService.List(r.Context())
+	if err != nil {
+		writeRuleError(w, r, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(buildInternalRulesResponse(rules))
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// InternalCreateRule adds a rule after the existing ones.
+func (p *Presenter) InternalCreateRule(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	var requestBody schema.InternalRule
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		writeRuleError(w, r, fmt.Errorf("%w: %s", errInvalidRule, err.Error()))
+		return
+	}
+
+	rule, err := parseRule(requestBody)
+	if err != nil {
+		writeRuleError(w, r, fmt.Errorf("%w: %s", errInvalidRule, err.Error()))
+		return
+	}
+
+	rule, err = p.ruleService.Create(r.Context(), rule)
+	if err != nil {
+		writeRuleError(w, r, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(buildInternalRule(rule))
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// InternalReplaceRules removes every rule and stores the given ones instead, in order.
+func (p *Presenter) InternalReplaceRules(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	var requestBody []schema.InternalRule
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		writeRuleError(w, r, fmt.Errorf("%w: %s", errInvalidRule, err.Error()))
+		return
+	}
+
+	rules := make([]primitive.Rule, 0, len(requestBody))
+	for i, internalRule := range requestBody {
+		rule, err := parseRule(internalRule)
+		if err != nil {
+			writeRuleError(w, r, fmt.Errorf("%w: [%d] %s", errInvalidRule, i, err.Error()))
+			return
+		}
+
+		rules = append(rules, rule)
+	}
+
+	rules, err = p.ruleService.Replace(r.Context(), rules)
+	if err != nil {
+		writeRuleError(w, r, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(buildInternalRulesResponse(rules))
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// InternalDeleteRule removes a rule before it is applied again.
+func (p *Presenter) InternalDeleteRule(w http.ResponseWriter, r *http.Request) {
+	err := p.ruleService.Delete(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRuleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// errInvalidRule is a rule that can't be read at all, before it reaches the business validation.
+var errInvalidRule = errors.New("invalid rule")
+
+func writeRuleError(w http.ResponseWriter, r *http.Request, err error) {
+	log := zerolog.Ctx(r.Context())
+
+	var requestValidationError *business.RequestValidationError
+	if errors.As(err, &requestValidationError) {
+		validationError := schema.ValidationError{
+			Error: schema.Error{
+				StatusCode:    400,
+				StatusMessage: "One or more parameters in the payload is invalid.",
+			},
+		}
+		for _, issue := range requestValidationError.Issues {
+			validationError.Issues = append(validationError.Issues, schema.ValidationIssue{
+				Field:   issue.Field,
+				Code:    issue.Code.String(),
+				Message: fmt.Sprintf("%s %s", issue.Field, issue.Message),
+			})
+		}
+
+		responseBody, err := json.Marshal(validationError)
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(400)
+		w.Write(responseBody)
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	statusMessage := err.Error()
+	switch {
+	case errors.Is(err, errInvalidRule):
+		statusCode = http.StatusBadRequest
+	case errors.Is(err, business.ErrRuleNotFound):
+		statusCode = http.StatusNotFound
+		statusMessage = "Rule was not found"
+	default:
+		log.Err(err).Msg("executing business function")
+	}
+
+	responseBody, err := json.Marshal(schema.Error{
+		StatusCode:    statusCode,
+		StatusMessage: statusMessage,
+		Id:            "",
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(responseBody)
+}
+
+func buildInternalRulesResponse(rules []primitive.Rule) schema.InternalRulesResponse {
+	internalRules := make([]schema.InternalRule, 0, len(rules))
+	for _, rule := range rules {
+		internalRules = append(internalRules, buildInternalRule(rule))
+	}
+
+	return schema.InternalRulesResponse{Rules: internalRules}
+}
@@ -79,6 +79,13 @@ func (p *Presenter) InternalTransactionDetail(w http.ResponseWriter, r *http.Req
 		Bank:                 transactionDetail.PaymentMethod.ToBank(),
 		VirtualAccountNumber: transactionDetail.VirtualAccountNumber,
 		EMoneyId:             transactionDetail.EMoneyID,
+		PaylaterId:           transactionDetail.PaylaterID,
+		QRString:             transactionDetail.QRString,
+		CreditCardId:         transactionDetail.CreditCardID,
+		MaskedCard:           transactionDetail.MaskedCard,
+		RecipientName:        transactionDetail.RecipientName,
+		FreeText:             buildFreeText(transactionDetail.FreeText),
+		StatusHistory:        buildStatusHistory(transactionDetail.StatusHistory),
 	})
 	if err != nil {
 		log.Err(err).Msg("marshaling json")
@@ -0,0 +1,245 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+// InternalListWebhooks lists every delivery attempt of the webhooks of an order, along
+// with what the merchant responded with.
+func (p *Presenter) InternalListWebhooks(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	orderId := r.URL.Query().Get("order_id")
+	if orderId == "" {
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    400,
+			StatusMessage: "Empty order id",
+			Id:            "",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(responseBody)
+		return
+	}
+
+	attempts, err := p.webhookService.ListAttempts(r.Context(), orderId)
+	if err != nil {
+		log.Err(err).Str("order_id", orderId).Msg("executing business function")
+
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    500,
+			StatusMessage: err.Error(),
+			Id:            "",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(responseBody)
+		return
+	}
+
+	webhookAttempts := make([]schema.InternalWebhookAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		webhookAttempts = append(webhookAttempts, buildWebhookAttempt(attempt))
+	}
+
+	responseBody, err := json.Marshal(schema.InternalWebhookAttemptsResponse{
+		OrderId:  orderId,
+		Attempts: webhookAttempts,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// InternalResendWebhook sends the payload of a previous attempt again, to the same URL.
+func (p *Presenter) InternalResendWebhook(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	attemptId := chi.URLParam(r, "id")
+
+	notification, err := p.webhookService.Resend(r.Context(), attemptId)
+	if err != nil {
+		if errors.Is(err, business.ErrWebhookAttemptNotFound) || attemptId == "" {
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    404,
+				StatusMessage: "Webhook attempt was not found",
+				Id:            "",
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(responseBody)
+			return
+		}
+
+		log.Err(err).Str("attempt_id", attemptId).Msg("executing business function")
+
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    500,
+			StatusMessage: err.Error(),
+			Id:            "",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(responseBody)
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.InternalResendWebhookResponse{
+		WebhookId:     notification.Id,
+		OrderId:       notification.OrderId,
+		URL:           notification.TargetURL,
+		NextAttemptAt: notification.NextAttemptAt.Local().Format(time.DateTime),
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(responseBody)
+}
+
+// InternalTestWebhook sends a sample notification to the given URL right away, so a
+// merchant can check their webhook handler before making any charge.
+func (p *Presenter) InternalTestWebhook(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	var requestBody schema.InternalTestWebhookRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    400,
+			StatusMessage: "Invalid request body",
+			Id:            "",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(responseBody)
+		return
+	}
+
+	attempt, err := p.webhookService.SendTest(r.Context(), requestBody.URL)
+	if err != nil {
+		var requestValidationError *business.RequestValidationError
+		if errors.As(err, &requestValidationError) {
+			validationError := schema.ValidationError{
+				Error: schema.Error{
+					StatusCode:    400,
+					StatusMessage: "some request validation is failed",
+				},
+			}
+			for _, issue := range requestValidationError.Issues {
+				validationError.Issues = append(validationError.Issues, schema.ValidationIssue{
+					Field:   issue.Field,
+					Code:    issue.Code.String(),
+					Message: fmt.Sprintf("%s %s", issue.Field, issue.Message),
+				})
+			}
+
+			responseBody, err := json.Marshal(validationError)
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(responseBody)
+			return
+		}
+
+		log.Err(err).Str("url", requestBody.URL).Msg("executing business function")
+
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    500,
+			StatusMessage: err.Error(),
+			Id:            "",
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(responseBody)
+		return
+	}
+
+	responseBody, err := json.Marshal(buildWebhookAttempt(attempt))
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func buildWebhookAttempt(attempt primitive.WebhookAttempt) schema.InternalWebhookAttempt {
+	return schema.InternalWebhookAttempt{
+		Id:           attempt.Id,
+		WebhookId:    attempt.WebhookId,
+		OrderId:      attempt.OrderId,
+		URL:          attempt.TargetURL,
+		Payload:      attempt.Payload,
+		Status:       attempt.Status.String(),
+		StatusCode:   attempt.StatusCode,
+		ResponseBody: attempt.ResponseBody,
+		LatencyMs:    attempt.Latency.Milliseconds(),
+		Error:        attempt.Error,
+		AttemptedAt:  attempt.AttemptedAt.Local().Format(time.DateTime),
+	}
+}
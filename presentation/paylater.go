@@ -0,0 +1,197 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+// PaylaterPage serves the simulated paylater provider's page, which is the redirect_url
+// of a paylater charge. API clients that don't ask for HTML will get the payment detail
+// as JSON instead.
+func (p *Presenter) PaylaterPage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getPaylaterDetail(r, id)
+	if err != nil {
+		p.writePaylaterError(w, r, err)
+		return
+	}
+
+	p.writePaylaterDetail(w, r, detail, "Success, transaction found", "")
+}
+
+// PaylaterApprove approves the customer for the installment, which settles the transaction.
+func (p *Presenter) PaylaterApprove(w http.ResponseWriter, r *http.Request) {
+	p.completePaylater(w, r, true)
+}
+
+// PaylaterReject rejects the customer, which denies the transaction.
+func (p *Presenter) PaylaterReject(w http.ResponseWriter, r *http.Request) {
+	p.completePaylater(w, r, false)
+}
+
+// completePaylater sends the customer back to the merchant's finish redirect URL once the
+// transaction is approved or rejected, or back to the paylater page if there is none.
+func (p *Presenter) completePaylater(w http.ResponseWriter, r *http.Request, approved bool) {
+	id := chi.URLParam(r, "id")
+
+	detail, err := p.getPaylaterDetail(r, id)
+	if err != nil {
+		p.writePaylaterError(w, r, err)
+		return
+	}
+
+	transactionStatus, err := p.paymentService.CompletePaylater(r.Context(), detail.PaylaterID, approved)
+	if err != nil {
+		p.writePaylaterError(w, r, err)
+		return
+	}
+
+	var finishRedirectURL string
+	if p.finishRedirectURL != "" {
+		finishRedirectURL = callbackURL(p.finishRedirectURL, detail.OrderId, transactionStatus)
+	}
+
+	if acceptsHTML(r) {
+		if finishRedirectURL == "" {
+			finishRedirectURL = "/paylater/" + id
+		}
+
+		http.Redirect(w, r, finishRedirectURL, http.StatusSeeOther)
+		return
+	}
+
+	detail, err = p.getPaylaterDetail(r, id)
+	if err != nil {
+		p.writePaylaterError(w, r, err)
+		return
+	}
+
+	statusMessage := "Success, paylater transaction is approved"
+	if !approved {
+		statusMessage = "Success, paylater transaction is rejected"
+	}
+
+	p.writePaylaterDetail(w, r, detail, statusMessage, finishRedirectURL)
+}
+
+// getPaylaterDetail acquires the payment detail, making sure the ID belongs to a paylater
+// charge and not to any other payment type.
+func (p *Presenter) getPaylaterDetail(r *http.Request, id string) (business.PaymentDetailsResponse, error) {
+	if id == "" {
+		return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+	}
+
+	detail, err := p.paymentService.GetDetail(r.Context(), id)
+	if err != nil {
+		return business.PaymentDetailsResponse{}, err
+	}
+
+	if detail.PaylaterID == "" {
+		return business.PaymentDetailsResponse{}, business.ErrTransactionNotFound
+	}
+
+	return detail, nil
+}
+
+type paylaterPageData struct {
+	Error             string
+	PaylaterId        string
+	OrderId           string
+	PaymentType       string
+	GrossAmount       string
+	TransactionStatus string
+	Approvable        bool
+}
+
+func (p *Presenter) writePaylaterDetail(w http.ResponseWriter, r *http.Request, detail business.PaymentDetailsResponse, statusMessage string, finishRedirectURL string) {
+	log := zerolog.Ctx(r.Context())
+
+	if acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		err := templates.ExecuteTemplate(w, "paylater.html", paylaterPageData{
+			PaylaterId:        detail.PaylaterID,
+			OrderId:           detail.OrderId,
+			PaymentType:       detail.PaymentMethod.ToPaymentMethod(),
+			GrossAmount:       strconv.FormatInt(detail.ChargedAmount, 10),
+			TransactionStatus: detail.Status.String(),
+			Approvable:        detail.Status == primitive.TransactionStatusPending,
+		})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	response := schema.PaylaterResponse{
+		StatusCode:        "200",
+		StatusMessage:     statusMessage,
+		OrderId:           detail.OrderId,
+		GrossAmount:       strconv.FormatInt(detail.ChargedAmount, 10),
+		Currency:          "IDR",
+		PaymentType:       detail.PaymentMethod.ToPaymentMethod(),
+		TransactionStatus: detail.Status.String(),
+		FinishRedirectURL: finishRedirectURL,
+	}
+
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (p *Presenter) writePaylaterError(w http.ResponseWriter, r *http.Request, err error) {
+	log := zerolog.Ctx(r.Context())
+
+	statusCode := http.StatusInternalServerError
+	statusMessage := "Internal server error."
+	switch {
+	case errors.Is(err, business.ErrTransactionNotFound):
+		statusCode = http.StatusNotFound
+		statusMessage = "Transaction doesn't exist."
+	case errors.Is(err, business.ErrCannotModifyStatus):
+		statusCode = http.StatusPreconditionFailed
+		statusMessage = "Transaction is no longer waiting for approval."
+	default:
+		log.Err(err).Msg("executing business function")
+	}
+
+	if acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(statusCode)
+		err := templates.ExecuteTemplate(w, "paylater.html", paylaterPageData{Error: statusMessage})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.Error{
+		StatusCode:    statusCode,
+		StatusMessage: statusMessage,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(responseBody)
+}
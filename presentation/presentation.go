@@ -1,7 +1,9 @@
 package presentation
 
 import (
+	"embed"
 	"encoding/json"
+	"html/template"
 	"net"
 	"net/http"
 	"strings"
@@ -17,27 +19,47 @@ import (
 	"github.com/rs/zerolog"
 )
 
+//go:embed views
+var views embed.FS
+
+var templates = template.Must(template.ParseFS(views, "views/*.html"))
+
 type Presenter struct {
 	transactionService business.Transaction
 	paymentService     business.Payment
+	snapService        business.Snap
+	webhookService     business.Webhook
+	ruleService        business.Rule
+	// finishRedirectURL is where the customer is sent back to after completing a redirect
+	// payment, the Finish Redirect URL on the Midtrans dashboard.
+	finishRedirectURL string
 }
 
 type Dependency struct {
 	TransactionService business.Transaction
 	PaymentService     business.Payment
+	SnapService        business.Snap
+	WebhookService     business.Webhook
+	RuleService        business.Rule
 	Logger             zerolog.Logger
 }
 type PresenterConfig struct {
-	Hostname   string
-	Port       string
-	ServerKey  string
-	Dependency *Dependency
+	Hostname  string
+	Port      string
+	ServerKey string
+	// FinishRedirectURL is optional, the customer stays on the payment page without it.
+	FinishRedirectURL string
+	Dependency        *Dependency
 }
 
 func NewPresenter(config PresenterConfig) (*http.Server, error) {
 	presenter := &Presenter{
 		transactionService: config.Dependency.TransactionService,
 		paymentService:     config.Dependency.PaymentService,
+		snapService:        config.Dependency.SnapService,
+		webhookService:     config.Dependency.WebhookService,
+		ruleService:        config.Dependency.RuleService,
+		finishRedirectURL:  config.FinishRedirectURL,
 	}
 
 	router := chi.NewRouter()
@@ -45,11 +67,52 @@ func NewPresenter(config PresenterConfig) (*http.Server, error) {
 	router.Use(hlog.NewHandler(config.Dependency.Logger))
 	router.Use(hlog.URLHandler("request_url"))
 
+	// Allow browsers to call the routes that snap.js and the checkout page need from any
+	// origin, so frontend tests can talk to this mock directly. Nothing here relies on
+	// cookies, so credentials are never allowed. Preflight requests are answered before
+	// authorization.
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Origin") == "" ||
+				!(strings.HasPrefix(r.URL.Path, "/snap/") ||
+					r.URL.Path == "/v2/token" ||
+					r.URL.Path == "/v2/card/register") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+				requestHeaders := r.Header.Get("Access-Control-Request-Headers")
+				if requestHeaders == "" {
+					requestHeaders = "Accept, Authorization, Content-Type"
+				}
+				w.Header().Set("Access-Control-Allow-Headers", requestHeaders)
+				w.Header().Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	})
+
 	// Apply authorization middleware
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip for index and internal paths
-			if r.URL.Path == "/" || strings.HasPrefix(r.URL.Path, "/internal") {
+			// Skip for index, internal, card tokenization, snap.js, and customer-facing
+			// e-money, 3-D Secure, paylater and Snap checkout paths
+			if r.URL.Path == "/" ||
+				strings.HasPrefix(r.URL.Path, "/internal") ||
+				strings.HasPrefix(r.URL.Path, "/e-money") ||
+				strings.HasPrefix(r.URL.Path, "/3ds") ||
+				strings.HasPrefix(r.URL.Path, "/paylater") ||
+				strings.HasPrefix(r.URL.Path, "/snap/v4") ||
+				r.URL.Path == "/snap/snap.js" ||
+				r.URL.Path == "/v2/token" ||
+				r.URL.Path == "/v2/card/register" {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -79,13 +142,60 @@ func NewPresenter(config PresenterConfig) (*http.Server, error) {
 
 	// Internal routes
 	router.Post("/internal/mark-as-paid", presenter.InternalMarkAsPaid)
+	router.Post("/internal/cstore/pay", presenter.InternalCStorePay)
 	router.Get("/internal/transaction-detail", presenter.InternalTransactionDetail)
-
-	// External routes
-	router.Post("/charge", presenter.ChargeTransaction)
-	router.Post("/{order_id}/cancel", presenter.CancelTransaction)
-	router.Get("/{order_id}/status", presenter.GetTransactionStatus)
-	router.Post("/{order_id}/expire", presenter.ExpireTransaction)
+	router.Get("/internal/webhooks", presenter.InternalListWebhooks)
+	router.Post("/internal/webhooks/test", presenter.InternalTestWebhook)
+	router.Post("/internal/webhooks/{id}/resend", presenter.InternalResendWebhook)
+	router.Get("/internal/rules", presenter.InternalListRules)
+	router.Post("/internal/rules", presenter.InternalCreateRule)
+	router.Put("/internal/rules", presenter.InternalReplaceRules)
+	router.Delete("/internal/rules/{id}", presenter.InternalDeleteRule)
+
+	// Every route but the internal ones goes through the rules engine, so the merchant can
+	// make this service slow or failing on demand
+	router.Group(func(router chi.Router) {
+		router.Use(presenter.applyRules)
+
+		// Customer-facing e-money routes, these are handed out as actions on charge
+		router.Get("/e-money/{id}/pay", presenter.EMoneyPaymentPage)
+		router.Post("/e-money/{id}/pay", presenter.EMoneyPay)
+		router.Get("/e-money/{id}/qr-code", presenter.EMoneyQRCode)
+		router.Get("/e-money/{id}/status", presenter.EMoneyStatus)
+		router.Post("/e-money/{id}/cancel", presenter.EMoneyCancel)
+
+		// Customer-facing 3-D Secure routes, handed out as redirect_url on credit card charge
+		router.Get("/3ds/{id}", presenter.CreditCard3DSPage)
+		router.Post("/3ds/{id}", presenter.CreditCard3DSAuthenticate)
+
+		// Customer-facing paylater routes, handed out as redirect_url on paylater charge
+		router.Get("/paylater/{id}", presenter.PaylaterPage)
+		router.Post("/paylater/{id}/approve", presenter.PaylaterApprove)
+		router.Post("/paylater/{id}/reject", presenter.PaylaterReject)
+
+		// Customer-facing Snap checkout routes, handed out as redirect_url on Snap transaction
+		router.Get("/snap/v4/redirection/{token}", presenter.SnapCheckoutPage)
+		router.Post("/snap/v4/redirection/{token}/pay", presenter.SnapCheckoutPay)
+		router.Post("/snap/v4/redirection/{token}/close", presenter.SnapCheckoutClose)
+		router.Get("/snap/snap.js", presenter.SnapJS)
+
+		// Card tokenization routes, called from the customer's browser before charging
+		router.Get("/v2/token", presenter.CardToken)
+		router.Get("/v2/card/register", presenter.CardRegister)
+
+		// External routes
+		router.Post("/charge", presenter.ChargeTransaction)
+		router.Post("/snap/v1/transactions", presenter.CreateSnapTransaction)
+		router.Post("/{order_id}/cancel", presenter.CancelTransaction)
+		router.Get("/{order_id}/status", presenter.GetTransactionStatus)
+		router.Get("/{order_id}/status/history", presenter.GetTransactionStatusHistory)
+		router.Post("/{order_id}/expire", presenter.ExpireTransaction)
+		router.Post("/{order_id}/refund", presenter.RefundTransaction)
+		router.Post("/{order_id}/refund/online/direct", presenter.DirectRefundTransaction)
+		router.Post("/{order_id}/capture", presenter.CaptureTransaction)
+		router.Post("/{order_id}/approve", presenter.ApproveTransaction)
+		router.Post("/{order_id}/deny", presenter.DenyTransaction)
+	})
 
 	server := &http.Server{
 		Addr:              net.JoinHostPort(config.Hostname, config.Port),
@@ -104,12 +214,33 @@ var paymentTypeMap = map[string]primitive.PaymentType{
 	"VIRTUAL_ACCOUNT_PERMATA": primitive.PaymentTypeVirtualAccountPermata,
 	"VIRTUAL_ACCOUNT_BRI":     primitive.PaymentTypeVirtualAccountBRI,
 	"VIRTUAL_ACCOUNT_BNI":     primitive.PaymentTypeVirtualAccountBNI,
+	"VIRTUAL_ACCOUNT_CIMB":    primitive.PaymentTypeVirtualAccountCIMB,
+	"VIRTUAL_ACCOUNT_BSI":     primitive.PaymentTypeVirtualAccountBSI,
+	"MANDIRI_BILL":            primitive.PaymentTypeMandiriBill,
+	"CSTORE_INDOMARET":        primitive.PaymentTypeCStoreIndomaret,
+	"CSTORE_ALFAMART":         primitive.PaymentTypeCStoreAlfamart,
+	"PAYLATER_AKULAKU":        primitive.PaymentTypePaylaterAkulaku,
+	"PAYLATER_KREDIVO":        primitive.PaymentTypePaylaterKredivo,
 	"E_MONEY_QRIS":            primitive.PaymentTypeEMoneyQRIS,
 	"E_MONEY_GOPAY":           primitive.PaymentTypeEMoneyGopay,
 	"E_MONEY_SHOPEE_PAY":      primitive.PaymentTypeEMoneyShopeePay,
+	"CREDIT_CARD":             primitive.PaymentTypeCreditCard,
 }
 
 var currencyMap = map[string]primitive.Currency{
 	"IDR": primitive.CurrencyIDR,
 	"USD": primitive.CurrencyUSD,
 }
+
+var expiryUnitMap = map[string]primitive.ExpiryUnit{
+	"second": primitive.ExpiryUnitSecond,
+	"minute": primitive.ExpiryUnitMinute,
+	"hour":   primitive.ExpiryUnitHour,
+	"day":    primitive.ExpiryUnitDay,
+}
+
+// acceptsHTML tells whether the request came from a browser (or anything that prefers
+// an HTML page) instead of an API client that expects JSON.
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
@@ -0,0 +1,144 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+)
+
+func (p *Presenter) RefundTransaction(w http.ResponseWriter, r *http.Request) {
+	p.refundTransaction(w, r, false)
+}
+
+func (p *Presenter) DirectRefundTransaction(w http.ResponseWriter, r *http.Request) {
+	p.refundTransaction(w, r, true)
+}
+
+func (p *Presenter) refundTransaction(w http.ResponseWriter, r *http.Request, direct bool) {
+	log := zerolog.Ctx(r.Context())
+
+	orderId := chi.URLParam(r, "order_id")
+
+	var requestBody schema.RefundTransactionRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		responseBody, e := json.Marshal(schema.Error{
+			StatusCode:    http.StatusBadRequest,
+			StatusMessage: "Malformed JSON",
+		})
+		if e != nil {
+			log.Err(e).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(responseBody)
+		return
+	}
+
+	// Call business logic
+	refundResponse, err := p.transactionService.Refund(r.Context(), orderId, business.RefundRequest{
+		RefundKey: requestBody.RefundKey,
+		Amount:    requestBody.Amount,
+		Reason:    requestBody.Reason,
+		Direct:    direct,
+	})
+	if err != nil {
+		var statusCode int
+		var statusMessage string
+		var requestValidationError *business.RequestValidationError
+		switch {
+		case errors.Is(err, business.ErrTransactionNotFound):
+			statusCode = 404
+			statusMessage = "Transaction doesn't exist."
+		case errors.Is(err, business.ErrCannotModifyStatus):
+			statusCode = 412
+			statusMessage = "Merchant cannot modify the status of the transaction"
+		case errors.Is(err, business.ErrRefundNotSupported):
+			statusCode = 412
+			statusMessage = "Payment type does not support this refund method"
+		case errors.Is(err, business.ErrRefundAmountExceeded):
+			statusCode = 412
+			statusMessage = "Refund amount is greater than the refundable amount of the transaction"
+		case errors.Is(err, business.ErrDuplicateRefundKey):
+			statusCode = 406
+			statusMessage = "Duplicate refund key. refund_key has already been utilized previously."
+		case errors.As(err, &requestValidationError):
+			statusCode = 400
+			statusMessage = requestValidationError.Error()
+		default:
+			log.Err(err).Str("order_id", orderId).Msg("executing business function")
+
+			responseBody, e := json.Marshal(schema.Error{
+				StatusCode:    http.StatusInternalServerError,
+				StatusMessage: "internal server error",
+			})
+			if e != nil {
+				log.Err(e).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(responseBody)
+			return
+		}
+
+		responseBody, e := json.Marshal(schema.Error{
+			StatusCode:    statusCode,
+			StatusMessage: statusMessage,
+			Id:            uuid.NewString(),
+		})
+		if e != nil {
+			log.Err(e).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// Do not complain. Do complain to Midtrans about the status code usage instead.
+		w.WriteHeader(http.StatusOK)
+		w.Write(responseBody)
+		return
+	}
+
+	statusMessage := "Success, refund request is approved"
+	if direct {
+		statusMessage = "Success, refund online request is approved"
+	}
+
+	responseBody, err := json.Marshal(schema.RefundTransactionResponse{
+		StatusCode:         "200",
+		StatusMessage:      statusMessage,
+		TransactionId:      refundResponse.OrderId,
+		OrderId:            refundResponse.OrderId,
+		GrossAmount:        strconv.FormatInt(refundResponse.TransactionAmount, 10),
+		Currency:           "IDR",
+		PaymentType:        refundResponse.PaymentType.ToPaymentMethod(),
+		TransactionTime:    refundResponse.TransactionTime.Format(time.DateTime),
+		TransactionStatus:  refundResponse.TransactionStatus.String(),
+		RefundChargebackId: refundResponse.RefundChargebackId,
+		RefundAmount:       strconv.FormatInt(refundResponse.RefundAmount, 10),
+		RefundKey:          refundResponse.RefundKey,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
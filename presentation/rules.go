@@ -0,0 +1,189 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+var ruleActionMap = map[string]primitive.RuleAction{
+	primitive.RuleActionLatency.String():     primitive.RuleActionLatency,
+	primitive.RuleActionRespond.String():     primitive.RuleActionRespond,
+	primitive.RuleActionDeny.String():        primitive.RuleActionDeny,
+	primitive.RuleActionDropWebhook.String(): primitive.RuleActionDropWebhook,
+}
+
+// applyRules holds the response back and replaces it when a latency or respond rule
+// matches the request. It has to run after routing, so the order id is known.
+func (p *Presenter) applyRules(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log := zerolog.Ctx(r.Context())
+
+		subject := buildRuleSubject(r)
+
+		rule, ok, err := p.ruleService.Apply(r.Context(), primitive.RuleActionLatency, subject)
+		if err != nil {
+			log.Err(err).Msg("applying latency rules")
+		} else if ok {
+			log.Info().Str("ruleId", rule.Id).Dur("latency", rule.Latency).Msg("delaying response by rule")
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(rule.Latency):
+			}
+		}
+
+		rule, ok, err = p.ruleService.Apply(r.Context(), primitive.RuleActionRespond, subject)
+		if err != nil {
+			log.Err(err).Msg("applying respond rules")
+		} else if ok {
+			log.Info().Str("ruleId", rule.Id).Int("httpStatus", rule.HTTPStatus).Msg("responding by rule")
+
+			statusCode := rule.StatusCode
+			if statusCode == 0 {
+				statusCode = rule.HTTPStatus
+			}
+
+			statusMessage := rule.StatusMessage
+			if statusMessage == "" {
+				statusMessage = http.StatusText(rule.HTTPStatus)
+			}
+
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    statusCode,
+				StatusMessage: statusMessage,
+				Id:            uuid.NewString(),
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(rule.HTTPStatus)
+			w.Write(responseBody)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// buildRuleSubject takes the order id from the path, or from the body for a charge, which
+// is read and put back for the handler.
+func buildRuleSubject(r *http.Request) primitive.RuleSubject {
+	subject := primitive.RuleSubject{
+		Path:    r.URL.Path,
+		OrderId: chi.URLParam(r, "order_id"),
+	}
+
+	if r.Method != http.MethodPost || (r.URL.Path != "/charge" && r.URL.Path != "/snap/v1/transactions") {
+		return subject
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return subject
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var requestBody schema.ChargeTransactionRequest
+	if err := json.Unmarshal(body, &requestBody); err != nil {
+		return subject
+	}
+
+	subject.OrderId = requestBody.TransactionDetails.OrderId
+	subject.Amount = requestBody.TransactionDetails.GrossAmount
+	// A Snap transaction doesn't have any payment type until the customer picks one
+	if paymentType, err := parseValidPaymentMethod(requestBody); err == nil {
+		subject.PaymentType = paymentType
+	}
+
+	return subject
+}
+
+// ReadRulesFile reads the rules to start with, a JSON array of rules in the same format
+// as /internal/rules.
+func ReadRulesFile(name string) ([]primitive.Rule, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules file: %w", err)
+	}
+
+	var requestBody []schema.InternalRule
+	if err := json.Unmarshal(content, &requestBody); err != nil {
+		return nil, fmt.Errorf("parsing rules file: %w", err)
+	}
+
+	rules := make([]primitive.Rule, 0, len(requestBody))
+	for i, internalRule := range requestBody {
+		rule, err := parseRule(internalRule)
+		if err != nil {
+			return nil, fmt.Errorf("parsing rule %d: %w", i, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func parseRule(r schema.InternalRule) (primitive.Rule, error) {
+	var paymentType primitive.PaymentType
+	if r.Match.PaymentType != "" {
+		var ok bool
+		paymentType, ok = paymentTypeMap[r.Match.PaymentType]
+		if !ok {
+			return primitive.Rule{}, fmt.Errorf("invalid payment type %s", r.Match.PaymentType)
+		}
+	}
+
+	return primitive.Rule{
+		Path:           r.Match.Path,
+		OrderIdPattern: r.Match.OrderId,
+		PaymentType:    paymentType,
+		MinimumAmount:  r.Match.MinAmount,
+		MaximumAmount:  r.Match.MaxAmount,
+		// An unknown action is left unspecified, for the business validation to report
+		Action:        ruleActionMap[r.Action],
+		Latency:       time.Duration(r.LatencyMs) * time.Millisecond,
+		HTTPStatus:    r.HTTPStatus,
+		StatusCode:    r.StatusCode,
+		StatusMessage: r.StatusMessage,
+		OneShot:       r.OneShot,
+	}, nil
+}
+
+func buildInternalRule(rule primitive.Rule) schema.InternalRule {
+	internalRule := schema.InternalRule{
+		Id:            rule.Id,
+		Action:        rule.Action.String(),
+		LatencyMs:     rule.Latency.Milliseconds(),
+		HTTPStatus:    rule.HTTPStatus,
+		StatusCode:    rule.StatusCode,
+		StatusMessage: rule.StatusMessage,
+		OneShot:       rule.OneShot,
+		CreatedAt:     rule.CreatedAt.Local().Format(time.DateTime),
+	}
+	internalRule.Match.Path = rule.Path
+	internalRule.Match.OrderId = rule.OrderIdPattern
+	if rule.PaymentType != primitive.PaymentTypeUnspecified {
+		internalRule.Match.PaymentType = rule.PaymentType.String()
+	}
+	internalRule.Match.MinAmount = rule.MinimumAmount
+	internalRule.Match.MaxAmount = rule.MaximumAmount
+
+	return internalRule
+}
@@ -9,6 +9,7 @@ type BNIVirtualAccountChargeSuccessResponse struct {
 	PaymentType       string `json:"payment_type"`
 	TransactionTime   string `json:"transaction_time"`
 	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
 	VaNumbers         []struct {
 		Bank     string `json:"bank"`
 		VaNumber string `json:"va_number"`
@@ -66,3 +67,15 @@ type BNIVirtualAccountChargeExpiredResponse struct {
 	TransactionStatus string `json:"transaction_status"`
 	SignatureKey      string `json:"signature_key"`
 }
+
+type BNIVirtualAccountChargeCanceledResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	SignatureKey      string `json:"signature_key"`
+}
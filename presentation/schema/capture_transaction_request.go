@@ -0,0 +1,5 @@
+package schema
+
+type CaptureTransactionRequest struct {
+	GrossAmount int64 `json:"gross_amount"`
+}
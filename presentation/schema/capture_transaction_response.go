@@ -0,0 +1,18 @@
+package schema
+
+type CaptureTransactionResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	FraudStatus       string `json:"fraud_status"`
+	Bank              string `json:"bank"`
+	MaskedCard        string `json:"masked_card"`
+	CardType          string `json:"card_type"`
+	ApprovalCode      string `json:"approval_code"`
+}
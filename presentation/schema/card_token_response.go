@@ -0,0 +1,17 @@
+package schema
+
+type CardTokenResponse struct {
+	StatusCode    string `json:"status_code"`
+	StatusMessage string `json:"status_message"`
+	TokenId       string `json:"token_id"`
+	MaskedCard    string `json:"masked_card"`
+	ExpiredAt     string `json:"token_id_expired_at"`
+}
+
+type CardRegisterResponse struct {
+	StatusCode    string `json:"status_code"`
+	StatusMessage string `json:"status_message"`
+	SavedTokenId  string `json:"saved_token_id"`
+	MaskedCard    string `json:"masked_card"`
+	ExpiredAt     string `json:"saved_token_id_expired_at"`
+}
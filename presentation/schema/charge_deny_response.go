@@ -0,0 +1,18 @@
+package schema
+
+// ChargeDenyResponse is sent for a charge that is denied or failed, whatever the
+// payment type is.
+type ChargeDenyResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	SignatureKey      string `json:"signature_key,omitempty"`
+}
@@ -51,19 +51,27 @@ type ChargeTransactionRequest struct {
 		Permata struct {
 			RecipientName string `json:"recipient_name"`
 		} `json:"permata"`
-		VirtualAccountNumber string `json:"va_number"`
-		FreeText             struct {
-			Inquiry []struct {
-				Indonesian string `json:"id"`
-				English    string `json:"english"`
-			} `json:"inquiry"`
-			Payment []struct {
-				Indonesian string `json:"id"`
-				English    string `json:"english"`
-			} `json:"payment"`
-		} `json:"free_text"`
+		VirtualAccountNumber string   `json:"va_number"`
+		FreeText             FreeText `json:"free_text"`
 	} `json:"bank_transfer"`
 	BCA struct {
 		SubCompanyCode string `json:"sub_company_code"`
 	} `json:"bca"`
+	CStore struct {
+		Store   string `json:"store"`
+		Message string `json:"message"`
+	} `json:"cstore"`
+	// CustomExpiry is nil if the request doesn't override the default expiry
+	CustomExpiry *struct {
+		// OrderTime is in "yyyy-MM-dd hh:mm:ss Z" format, e.g. "2016-12-07 11:54:12 +0700"
+		OrderTime      string `json:"order_time"`
+		ExpiryDuration int64  `json:"expiry_duration"`
+		Unit           string `json:"unit"`
+	} `json:"custom_expiry"`
+	CreditCard struct {
+		TokenId     string `json:"token_id"`
+		Bank        string `json:"bank"`
+		SaveTokenId bool   `json:"save_token_id"`
+		Type        string `json:"type"`
+	} `json:"credit_card"`
 }
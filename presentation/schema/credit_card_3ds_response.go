@@ -0,0 +1,12 @@
+package schema
+
+type CreditCard3DSResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	MaskedCard        string `json:"masked_card"`
+	TransactionStatus string `json:"transaction_status"`
+}
@@ -0,0 +1,146 @@
+package schema
+
+type CreditCardChargeSuccessResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	Bank              string `json:"bank"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	RedirectURL       string `json:"redirect_url"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
+	FraudStatus       string `json:"fraud_status"`
+	MaskedCard        string `json:"masked_card"`
+	CardType          string `json:"card_type"`
+	// SavedTokenId is only available if the merchant asked for the card to be saved
+	SavedTokenId          string `json:"saved_token_id,omitempty"`
+	SavedTokenIdExpiredAt string `json:"saved_token_id_expired_at,omitempty"`
+}
+
+type CreditCardChargePendingResponse struct {
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	TransactionId     string `json:"transaction_id"`
+	StatusMessage     string `json:"status_message"`
+	StatusCode        string `json:"status_code"`
+	SignatureKey      string `json:"signature_key"`
+	PaymentType       string `json:"payment_type"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	MaskedCard        string `json:"masked_card"`
+	GrossAmount       string `json:"gross_amount"`
+	FraudStatus       string `json:"fraud_status"`
+	Currency          string `json:"currency"`
+	CardType          string `json:"card_type"`
+	Bank              string `json:"bank"`
+}
+
+type CreditCardChargeCaptureResponse struct {
+	TransactionTime        string `json:"transaction_time"`
+	TransactionStatus      string `json:"transaction_status"`
+	TransactionId          string `json:"transaction_id"`
+	StatusMessage          string `json:"status_message"`
+	StatusCode             string `json:"status_code"`
+	SignatureKey           string `json:"signature_key"`
+	PaymentType            string `json:"payment_type"`
+	OrderId                string `json:"order_id"`
+	MerchantId             string `json:"merchant_id"`
+	MaskedCard             string `json:"masked_card"`
+	GrossAmount            string `json:"gross_amount"`
+	FraudStatus            string `json:"fraud_status"`
+	Eci                    string `json:"eci"`
+	Currency               string `json:"currency"`
+	ChannelResponseMessage string `json:"channel_response_message"`
+	ChannelResponseCode    string `json:"channel_response_code"`
+	CardType               string `json:"card_type"`
+	Bank                   string `json:"bank"`
+	ApprovalCode           string `json:"approval_code"`
+	// SavedTokenId is only available if the merchant asked for the card to be saved
+	SavedTokenId          string `json:"saved_token_id,omitempty"`
+	SavedTokenIdExpiredAt string `json:"saved_token_id_expired_at,omitempty"`
+}
+
+type CreditCardChargeAuthorizeResponse struct {
+	TransactionTime        string `json:"transaction_time"`
+	TransactionStatus      string `json:"transaction_status"`
+	TransactionId          string `json:"transaction_id"`
+	StatusMessage          string `json:"status_message"`
+	StatusCode             string `json:"status_code"`
+	SignatureKey           string `json:"signature_key"`
+	PaymentType            string `json:"payment_type"`
+	OrderId                string `json:"order_id"`
+	MerchantId             string `json:"merchant_id"`
+	MaskedCard             string `json:"masked_card"`
+	GrossAmount            string `json:"gross_amount"`
+	FraudStatus            string `json:"fraud_status"`
+	Eci                    string `json:"eci"`
+	Currency               string `json:"currency"`
+	ChannelResponseMessage string `json:"channel_response_message"`
+	ChannelResponseCode    string `json:"channel_response_code"`
+	CardType               string `json:"card_type"`
+	Bank                   string `json:"bank"`
+	ApprovalCode           string `json:"approval_code"`
+}
+
+type CreditCardChargeDenyResponse struct {
+	TransactionTime        string `json:"transaction_time"`
+	TransactionStatus      string `json:"transaction_status"`
+	TransactionId          string `json:"transaction_id"`
+	StatusMessage          string `json:"status_message"`
+	StatusCode             string `json:"status_code"`
+	SignatureKey           string `json:"signature_key"`
+	PaymentType            string `json:"payment_type"`
+	OrderId                string `json:"order_id"`
+	MerchantId             string `json:"merchant_id"`
+	MaskedCard             string `json:"masked_card"`
+	GrossAmount            string `json:"gross_amount"`
+	FraudStatus            string `json:"fraud_status"`
+	Eci                    string `json:"eci"`
+	Currency               string `json:"currency"`
+	ChannelResponseMessage string `json:"channel_response_message"`
+	ChannelResponseCode    string `json:"channel_response_code"`
+	CardType               string `json:"card_type"`
+	Bank                   string `json:"bank"`
+}
+
+type CreditCardChargeExpiredResponse struct {
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	TransactionId     string `json:"transaction_id"`
+	StatusMessage     string `json:"status_message"`
+	StatusCode        string `json:"status_code"`
+	SignatureKey      string `json:"signature_key"`
+	PaymentType       string `json:"payment_type"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	MaskedCard        string `json:"masked_card"`
+	GrossAmount       string `json:"gross_amount"`
+	FraudStatus       string `json:"fraud_status"`
+	Currency          string `json:"currency"`
+	CardType          string `json:"card_type"`
+	Bank              string `json:"bank"`
+}
+
+type CreditCardChargeCanceledResponse struct {
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	TransactionId     string `json:"transaction_id"`
+	StatusMessage     string `json:"status_message"`
+	StatusCode        string `json:"status_code"`
+	SignatureKey      string `json:"signature_key"`
+	PaymentType       string `json:"payment_type"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	MaskedCard        string `json:"masked_card"`
+	GrossAmount       string `json:"gross_amount"`
+	FraudStatus       string `json:"fraud_status"`
+	Currency          string `json:"currency"`
+	CardType          string `json:"card_type"`
+	Bank              string `json:"bank"`
+	ApprovalCode      string `json:"approval_code"`
+}
@@ -0,0 +1,81 @@
+package schema
+
+type CStoreChargeSuccessResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentCode       string `json:"payment_code"`
+	Store             string `json:"store"`
+}
+
+type CStoreChargePendingResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentCode       string `json:"payment_code"`
+	Store             string `json:"store"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type CStoreChargeSettlementResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	SettlementTime    string `json:"settlement_time"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentCode       string `json:"payment_code"`
+	Store             string `json:"store"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type CStoreChargeExpiredResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	PaymentCode       string `json:"payment_code"`
+	Store             string `json:"store"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type CStoreChargeCanceledResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	PaymentCode       string `json:"payment_code"`
+	Store             string `json:"store"`
+	SignatureKey      string `json:"signature_key"`
+}
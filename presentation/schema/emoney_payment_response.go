@@ -0,0 +1,13 @@
+package schema
+
+type EMoneyPaymentResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	EMoneyId          string `json:"e_money_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionStatus string `json:"transaction_status"`
+	QRString          string `json:"qr_string,omitempty"`
+}
@@ -0,0 +1,19 @@
+package schema
+
+// FraudReviewTransactionResponse is returned once the merchant approved or denied the
+// fraud challenge of a card transaction.
+type FraudReviewTransactionResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	FraudStatus       string `json:"fraud_status"`
+	Bank              string `json:"bank"`
+	MaskedCard        string `json:"masked_card"`
+	ApprovalCode      string `json:"approval_code,omitempty"`
+}
@@ -0,0 +1,13 @@
+package schema
+
+// FreeText is the merchant's message shown to the customer on a virtual account payment, as
+// given on the charge request and returned on the transaction status and detail responses.
+type FreeText struct {
+	Inquiry []FreeTextLine `json:"inquiry,omitempty"`
+	Payment []FreeTextLine `json:"payment,omitempty"`
+}
+
+type FreeTextLine struct {
+	Indonesian string `json:"id"`
+	English    string `json:"english"`
+}
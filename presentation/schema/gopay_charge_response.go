@@ -9,6 +9,7 @@ type GopayChargeSuccessResponse struct {
 	PaymentType       string `json:"payment_type"`
 	TransactionTime   string `json:"transaction_time"`
 	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
 	Actions           []struct {
 		Name   string `json:"name"`
 		Method string `json:"method"`
@@ -54,3 +55,15 @@ type GopayChargeExpiredResponse struct {
 	TransactionStatus string `json:"transaction_status"`
 	SignatureKey      string `json:"signature_key"`
 }
+
+type GopayChargeCanceledResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	SignatureKey      string `json:"signature_key"`
+}
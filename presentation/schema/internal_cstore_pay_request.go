@@ -0,0 +1,5 @@
+package schema
+
+type InternalCStorePayRequest struct {
+	PaymentCode string `json:"payment_code"`
+}
@@ -0,0 +1,28 @@
+package schema
+
+// InternalRule is a rule of the rules engine, both as it is given on /internal/rules and
+// in the rules file.
+type InternalRule struct {
+	// Id and CreatedAt are ignored when creating a rule.
+	Id    string `json:"id,omitempty"`
+	Match struct {
+		Path    string `json:"path,omitempty"`
+		OrderId string `json:"order_id,omitempty"`
+		// PaymentType is one of the payment types of the Snap checkout page, such as E_MONEY_GOPAY.
+		PaymentType string `json:"payment_type,omitempty"`
+		MinAmount   int64  `json:"min_amount,omitempty"`
+		MaxAmount   int64  `json:"max_amount,omitempty"`
+	} `json:"match"`
+	// Action is one of latency, respond, deny or drop_webhook.
+	Action        string `json:"action"`
+	LatencyMs     int64  `json:"latency_ms,omitempty"`
+	HTTPStatus    int    `json:"http_status,omitempty"`
+	StatusCode    int    `json:"status_code,omitempty"`
+	StatusMessage string `json:"status_message,omitempty"`
+	OneShot       bool   `json:"one_shot"`
+	CreatedAt     string `json:"created_at,omitempty"`
+}
+
+type InternalRulesResponse struct {
+	Rules []InternalRule `json:"rules"`
+}
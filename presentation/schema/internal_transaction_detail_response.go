@@ -1,11 +1,18 @@
 package schema
 
 type InternalTransactionDetailResponse struct {
-	OrderId              string `json:"order_id"`
-	ChargedAmount        int64  `json:"charged_amount"`
-	TransactionStatus    string `json:"transaction_status"`
-	PaymentMethod        string `json:"payment_method"`
-	Bank                 string `json:"bank"`
-	VirtualAccountNumber string `json:"virtual_account_number,omitempty"`
-	EMoneyId             string `json:"e_money_id,omitempty"`
+	OrderId              string                    `json:"order_id"`
+	ChargedAmount        int64                     `json:"charged_amount"`
+	TransactionStatus    string                    `json:"transaction_status"`
+	PaymentMethod        string                    `json:"payment_method"`
+	Bank                 string                    `json:"bank"`
+	VirtualAccountNumber string                    `json:"virtual_account_number,omitempty"`
+	EMoneyId             string                    `json:"e_money_id,omitempty"`
+	PaylaterId           string                    `json:"paylater_id,omitempty"`
+	QRString             string                    `json:"qr_string,omitempty"`
+	CreditCardId         string                    `json:"credit_card_id,omitempty"`
+	MaskedCard           string                    `json:"masked_card,omitempty"`
+	RecipientName        string                    `json:"recipient_name,omitempty"`
+	FreeText             *FreeText                 `json:"free_text,omitempty"`
+	StatusHistory        []TransactionStatusChange `json:"status_history"`
 }
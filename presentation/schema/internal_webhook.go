@@ -0,0 +1,35 @@
+package schema
+
+import "encoding/json"
+
+type InternalWebhookAttemptsResponse struct {
+	OrderId  string                   `json:"order_id"`
+	Attempts []InternalWebhookAttempt `json:"attempts"`
+}
+
+type InternalWebhookAttempt struct {
+	// Id is empty for a test notification, as it is not recorded.
+	Id        string          `json:"id,omitempty"`
+	WebhookId string          `json:"webhook_id,omitempty"`
+	OrderId   string          `json:"order_id"`
+	URL       string          `json:"url"`
+	Payload   json.RawMessage `json:"payload"`
+	Status    string          `json:"status"`
+	// StatusCode is zero if no response was received.
+	StatusCode   int    `json:"status_code"`
+	ResponseBody string `json:"response_body"`
+	LatencyMs    int64  `json:"latency_ms"`
+	Error        string `json:"error,omitempty"`
+	AttemptedAt  string `json:"attempted_at"`
+}
+
+type InternalResendWebhookResponse struct {
+	WebhookId     string `json:"webhook_id"`
+	OrderId       string `json:"order_id"`
+	URL           string `json:"url"`
+	NextAttemptAt string `json:"next_attempt_at"`
+}
+
+type InternalTestWebhookRequest struct {
+	URL string `json:"url"`
+}
@@ -0,0 +1,81 @@
+package schema
+
+type MandiriBillChargeSuccessResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
+	FraudStatus       string `json:"fraud_status"`
+	BillKey           string `json:"bill_key"`
+	BillerCode        string `json:"biller_code"`
+}
+
+type MandiriBillChargePendingResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	BillKey           string `json:"bill_key"`
+	BillerCode        string `json:"biller_code"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type MandiriBillChargeSettlementResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	SettlementTime    string `json:"settlement_time"`
+	FraudStatus       string `json:"fraud_status"`
+	BillKey           string `json:"bill_key"`
+	BillerCode        string `json:"biller_code"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type MandiriBillChargeExpiredResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	BillKey           string `json:"bill_key"`
+	BillerCode        string `json:"biller_code"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type MandiriBillChargeCanceledResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	BillKey           string `json:"bill_key"`
+	BillerCode        string `json:"biller_code"`
+	SignatureKey      string `json:"signature_key"`
+}
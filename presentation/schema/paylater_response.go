@@ -0,0 +1,14 @@
+package schema
+
+type PaylaterResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type"`
+	TransactionStatus string `json:"transaction_status"`
+	// FinishRedirectURL is where the customer is sent back to the merchant, only
+	// available once the transaction is approved or rejected.
+	FinishRedirectURL string `json:"finish_redirect_url,omitempty"`
+}
@@ -9,6 +9,7 @@ type PermataVirtualAccountChargeSuccessResponse struct {
 	PaymentType       string `json:"payment_type"`
 	TransactionTime   string `json:"transaction_time"`
 	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
 	FraudStatus       string `json:"fraud_status"`
 	PermataVaNumber   string `json:"permata_va_number"`
 }
@@ -22,6 +23,7 @@ type PermataVirtualAccountChargePendingResponse struct {
 	PaymentType       string `json:"payment_type"`
 	TransactionTime   string `json:"transaction_time"`
 	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
 	FraudStatus       string `json:"fraud_status"`
 	PermataVaNumber   string `json:"permata_va_number"`
 	SignatureKey      string `json:"signature_key"`
@@ -36,6 +38,7 @@ type PermataVirtualAccountChargeSettlementResponse struct {
 	PaymentType       string `json:"payment_type"`
 	TransactionTime   string `json:"transaction_time"`
 	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
 	FraudStatus       string `json:"fraud_status"`
 	PermataVaNumber   string `json:"permata_va_number"`
 	SignatureKey      string `json:"signature_key"`
@@ -50,6 +53,22 @@ type PermataVirtualAccountChargeExpiredResponse struct {
 	PaymentType       string `json:"payment_type"`
 	TransactionTime   string `json:"transaction_time"`
 	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
+	FraudStatus       string `json:"fraud_status"`
+	PermataVaNumber   string `json:"permata_va_number"`
+	SignatureKey      string `json:"signature_key"`
+}
+
+type PermataVirtualAccountChargeCanceledResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionId     string `json:"transaction_id"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
 	FraudStatus       string `json:"fraud_status"`
 	PermataVaNumber   string `json:"permata_va_number"`
 	SignatureKey      string `json:"signature_key"`
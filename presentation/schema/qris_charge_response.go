@@ -11,8 +11,10 @@ type QRISChargeSuccessResponse struct {
 	PaymentType       string `json:"payment_type"`
 	TransactionTime   string `json:"transaction_time"`
 	TransactionStatus string `json:"transaction_status"`
+	ExpiryTime        string `json:"expiry_time"`
 	FraudStatus       string `json:"fraud_status"`
 	Acquirer          string `json:"acquirer"`
+	QRString          string `json:"qr_string"`
 	Actions           []struct {
 		Name   string `json:"name"`
 		Method string `json:"method"`
@@ -72,3 +74,19 @@ type QRISChargeExpiredResponse struct {
 	Currency          string `json:"currency"`
 	Acquirer          string `json:"acquirer"`
 }
+
+type QRISChargeCanceledResponse struct {
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	TransactionId     string `json:"transaction_id"`
+	StatusMessage     string `json:"status_message"`
+	StatusCode        string `json:"status_code"`
+	SignatureKey      string `json:"signature_key"`
+	PaymentType       string `json:"payment_type"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	FraudStatus       string `json:"fraud_status"`
+	Currency          string `json:"currency"`
+	Acquirer          string `json:"acquirer"`
+}
@@ -0,0 +1,29 @@
+package schema
+
+// Refund is a single refund entry, as listed on the refund notification and
+// the transaction status response.
+type Refund struct {
+	RefundChargebackId int64  `json:"refund_chargeback_id"`
+	RefundAmount       string `json:"refund_amount"`
+	CreatedAt          string `json:"created_at"`
+	Reason             string `json:"reason"`
+	RefundKey          string `json:"refund_key"`
+	RefundMethod       string `json:"refund_method,omitempty"`
+}
+
+type RefundNotification struct {
+	TransactionTime   string   `json:"transaction_time"`
+	TransactionStatus string   `json:"transaction_status"`
+	TransactionId     string   `json:"transaction_id"`
+	StatusMessage     string   `json:"status_message"`
+	StatusCode        string   `json:"status_code"`
+	SignatureKey      string   `json:"signature_key"`
+	PaymentType       string   `json:"payment_type"`
+	OrderId           string   `json:"order_id"`
+	MerchantId        string   `json:"merchant_id"`
+	GrossAmount       string   `json:"gross_amount"`
+	FraudStatus       string   `json:"fraud_status"`
+	Currency          string   `json:"currency"`
+	RefundAmount      string   `json:"refund_amount"`
+	Refunds           []Refund `json:"refunds"`
+}
@@ -0,0 +1,7 @@
+package schema
+
+type RefundTransactionRequest struct {
+	RefundKey string `json:"refund_key"`
+	Amount    int64  `json:"amount"`
+	Reason    string `json:"reason"`
+}
@@ -0,0 +1,16 @@
+package schema
+
+type RefundTransactionResponse struct {
+	StatusCode         string `json:"status_code"`
+	StatusMessage      string `json:"status_message"`
+	TransactionId      string `json:"transaction_id"`
+	OrderId            string `json:"order_id"`
+	GrossAmount        string `json:"gross_amount"`
+	Currency           string `json:"currency"`
+	PaymentType        string `json:"payment_type"`
+	TransactionTime    string `json:"transaction_time"`
+	TransactionStatus  string `json:"transaction_status"`
+	RefundChargebackId int64  `json:"refund_chargeback_id"`
+	RefundAmount       string `json:"refund_amount"`
+	RefundKey          string `json:"refund_key"`
+}
@@ -13,6 +13,7 @@ type ShopeePayChargeSuccessResponse struct {
 	PaymentType            string `json:"payment_type"`
 	TransactionTime        string `json:"transaction_time"`
 	TransactionStatus      string `json:"transaction_status"`
+	ExpiryTime             string `json:"expiry_time"`
 	FraudStatus            string `json:"fraud_status"`
 	Actions                []struct {
 		Name   string `json:"name"`
@@ -68,3 +69,18 @@ type ShopeePayChargeExpiredResponse struct {
 	FraudStatus       string `json:"fraud_status"`
 	Currency          string `json:"currency"`
 }
+
+type ShopeePayChargeCanceledResponse struct {
+	TransactionTime   string `json:"transaction_time"`
+	TransactionStatus string `json:"transaction_status"`
+	TransactionId     string `json:"transaction_id"`
+	StatusMessage     string `json:"status_message"`
+	StatusCode        string `json:"status_code"`
+	SignatureKey      string `json:"signature_key"`
+	PaymentType       string `json:"payment_type"`
+	OrderId           string `json:"order_id"`
+	MerchantId        string `json:"merchant_id"`
+	GrossAmount       string `json:"gross_amount"`
+	FraudStatus       string `json:"fraud_status"`
+	Currency          string `json:"currency"`
+}
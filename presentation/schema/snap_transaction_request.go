@@ -0,0 +1,43 @@
+package schema
+
+type SnapTransactionRequest struct {
+	TransactionDetails struct {
+		OrderId     string `json:"order_id"`
+		GrossAmount int64  `json:"gross_amount"`
+		Currency    string `json:"currency"`
+	} `json:"transaction_details"`
+	CustomerDetails struct {
+		FirstName      string `json:"first_name"`
+		LastName       string `json:"last_name"`
+		Email          string `json:"email"`
+		PhoneNumber    string `json:"phone"`
+		BillingAddress struct {
+			FirstName   string `json:"first_name"`
+			LastName    string `json:"last_name"`
+			Email       string `json:"email"`
+			Phone       string `json:"phone"`
+			Address     string `json:"address"`
+			PostalCode  string `json:"postal_code"`
+			CountryCode string `json:"country_code"`
+		} `json:"billing_address"`
+	} `json:"customer_details"`
+	Seller struct {
+		FirstName   string `json:"first_name"`
+		LastName    string `json:"last_name"`
+		Email       string `json:"email"`
+		PhoneNumber string `json:"phone_number"`
+		Address     string `json:"address"`
+	} `json:"seller"`
+	ItemDetails []struct {
+		Id       string `json:"id"`
+		Price    int64  `json:"price"`
+		Quantity int64  `json:"quantity"`
+		Name     string `json:"name"`
+		Category string `json:"category"`
+	} `json:"item_details"`
+	Callbacks struct {
+		Finish   string `json:"finish"`
+		Unfinish string `json:"unfinish"`
+		Error    string `json:"error"`
+	} `json:"callbacks"`
+}
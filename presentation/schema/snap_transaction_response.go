@@ -0,0 +1,30 @@
+package schema
+
+type SnapTransactionResponse struct {
+	Token       string `json:"token"`
+	RedirectURL string `json:"redirect_url"`
+}
+
+// SnapError is the error format of the Snap API, which differs from the Core API.
+type SnapError struct {
+	ErrorMessages []string `json:"error_messages"`
+}
+
+type SnapCheckoutResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	Token             string `json:"token"`
+	OrderId           string `json:"order_id"`
+	GrossAmount       string `json:"gross_amount"`
+	Currency          string `json:"currency"`
+	PaymentType       string `json:"payment_type,omitempty"`
+	TransactionStatus string `json:"transaction_status,omitempty"`
+}
+
+// SnapCheckoutMessage is posted by the embedded checkout page to snap.js, which calls
+// the callback of the event with the result.
+type SnapCheckoutMessage struct {
+	Source string               `json:"source"`
+	Event  string               `json:"event"`
+	Result SnapCheckoutResponse `json:"result"`
+}
@@ -0,0 +1,18 @@
+package schema
+
+type TransactionStatusHistoryResponse struct {
+	StatusCode        string                    `json:"status_code"`
+	StatusMessage     string                    `json:"status_message"`
+	OrderId           string                    `json:"order_id"`
+	TransactionStatus string                    `json:"transaction_status"`
+	History           []TransactionStatusChange `json:"history"`
+}
+
+type TransactionStatusChange struct {
+	// FromStatus is empty for the entry that records the creation of the transaction.
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	Source     string `json:"source"`
+	Actor      string `json:"actor"`
+	ChangedAt  string `json:"changed_at"`
+}
@@ -0,0 +1,386 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+// snapPaymentTypes lists the payment types that the customer can pick on the checkout page.
+var snapPaymentTypes = []primitive.PaymentType{
+	primitive.PaymentTypeCreditCard,
+	primitive.PaymentTypeVirtualAccountBCA,
+	primitive.PaymentTypeVirtualAccountBNI,
+	primitive.PaymentTypeVirtualAccountBRI,
+	primitive.PaymentTypeVirtualAccountPermata,
+	primitive.PaymentTypeVirtualAccountCIMB,
+	primitive.PaymentTypeVirtualAccountBSI,
+	primitive.PaymentTypeMandiriBill,
+	primitive.PaymentTypeCStoreIndomaret,
+	primitive.PaymentTypeCStoreAlfamart,
+	primitive.PaymentTypePaylaterAkulaku,
+	primitive.PaymentTypePaylaterKredivo,
+	primitive.PaymentTypeEMoneyGopay,
+	primitive.PaymentTypeEMoneyShopeePay,
+	primitive.PaymentTypeEMoneyQRIS,
+}
+
+// SnapCheckoutPage serves the hosted checkout page, which is the redirect_url of a Snap
+// transaction. API clients that don't ask for HTML will get the checkout detail as JSON.
+func (p *Presenter) SnapCheckoutPage(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	snapTransaction, err := p.snapService.GetTransaction(r.Context(), token)
+	if err != nil {
+		p.writeSnapCheckoutError(w, r, err)
+		return
+	}
+
+	p.writeSnapCheckoutDetail(w, r, snapTransaction, "", "Success, transaction found")
+}
+
+// SnapCheckoutPay charges the order with the payment type from the "payment_type" form
+// value, and completes the payment right away, unless the "outcome" form value is
+// "pending". The customer is then redirected to the finish callback, or to the error
+// callback if the card was denied. An embedded checkout page reports the result to
+// snap.js instead.
+func (p *Presenter) SnapCheckoutPay(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	embedded := acceptsHTML(r) && r.FormValue("embedded") == "1"
+
+	snapTransaction, err := p.snapService.Pay(r.Context(), token, business.SnapPayRequest{
+		PaymentType:     paymentTypeMap[r.FormValue("payment_type")],
+		CardNumber:      r.FormValue("card_number"),
+		CardExpiryMonth: r.FormValue("card_exp_month"),
+		CardExpiryYear:  r.FormValue("card_exp_year"),
+		CardCVV:         r.FormValue("card_cvv"),
+		OTP:             r.FormValue("otp"),
+		Pending:         r.FormValue("outcome") == "pending",
+	})
+	if err != nil {
+		// Let the customer fix their input on the checkout page, or see that the order
+		// was already paid
+		var requestValidationError *business.RequestValidationError
+		if errors.As(err, &requestValidationError) ||
+			errors.Is(err, business.ErrInvalidCardToken) ||
+			errors.Is(err, business.ErrTransactionNotFound) ||
+			errors.Is(err, business.ErrCannotModifyStatus) {
+			p.writeSnapCheckoutError(w, r, err)
+			return
+		}
+
+		// Otherwise the payment has failed, the merchant takes it from here
+		current, e := p.snapService.GetTransaction(r.Context(), token)
+		if e == nil && embedded {
+			p.writeSnapCheckoutMessage(w, r, "error", current)
+			return
+		}
+
+		if e != nil || current.Callbacks.Error == "" || !acceptsHTML(r) {
+			p.writeSnapCheckoutError(w, r, err)
+			return
+		}
+
+		http.Redirect(w, r, snapCallbackURL(current.Callbacks.Error, current), http.StatusSeeOther)
+		return
+	}
+
+	if embedded {
+		p.writeSnapCheckoutMessage(w, r, snapCheckoutEvent(snapTransaction.TransactionStatus), snapTransaction)
+		return
+	}
+
+	if acceptsHTML(r) {
+		callbackURL := snapTransaction.Callbacks.Finish
+		if snapCheckoutEvent(snapTransaction.TransactionStatus) == "error" {
+			callbackURL = snapTransaction.Callbacks.Error
+		}
+
+		if callbackURL == "" {
+			http.Redirect(w, r, "/snap/v4/redirection/"+token, http.StatusSeeOther)
+			return
+		}
+
+		http.Redirect(w, r, snapCallbackURL(callbackURL, snapTransaction), http.StatusSeeOther)
+		return
+	}
+
+	p.writeSnapCheckoutDetail(w, r, snapTransaction, "", "Success, transaction is paid")
+}
+
+// SnapCheckoutClose sends the customer back to the merchant through the unfinish
+// callback, as if the customer closed the checkout page without paying.
+func (p *Presenter) SnapCheckoutClose(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	snapTransaction, err := p.snapService.GetTransaction(r.Context(), token)
+	if err != nil {
+		p.writeSnapCheckoutError(w, r, err)
+		return
+	}
+
+	if acceptsHTML(r) && r.FormValue("embedded") == "1" {
+		p.writeSnapCheckoutMessage(w, r, "close", snapTransaction)
+		return
+	}
+
+	if snapTransaction.Callbacks.Unfinish == "" {
+		http.Redirect(w, r, "/snap/v4/redirection/"+token, http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, snapCallbackURL(snapTransaction.Callbacks.Unfinish, snapTransaction), http.StatusSeeOther)
+}
+
+// snapCallbackURL appends the order ID and the transaction status to the merchant's
+// callback URL, the same query parameters that Snap gives on its redirects.
+func snapCallbackURL(redirectURL string, snapTransaction business.SnapTransactionResponse) string {
+	return callbackURL(redirectURL, snapTransaction.OrderId, snapTransaction.TransactionStatus)
+}
+
+// callbackURL appends the order ID and the transaction status to a merchant's redirect URL,
+// which is how Midtrans sends the customer back to the merchant.
+func callbackURL(redirectURL string, orderId string, transactionStatus primitive.TransactionStatus) string {
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return redirectURL
+	}
+
+	query := u.Query()
+	query.Set("order_id", orderId)
+	query.Set("status_code", snapStatusCode(transactionStatus))
+	if transactionStatus != primitive.TransactionStatusUnspecified {
+		query.Set("transaction_status", transactionStatus.String())
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// snapStatusCode returns the status code that Snap gives for the transaction status.
+func snapStatusCode(transactionStatus primitive.TransactionStatus) string {
+	switch transactionStatus {
+	case primitive.TransactionStatusSettlement, primitive.TransactionStatusCapture, primitive.TransactionStatusAuthorize:
+		return "200"
+	case primitive.TransactionStatusDeny, primitive.TransactionStatusFailure:
+		return "202"
+	default:
+		return "201"
+	}
+}
+
+// snapCheckoutEvent returns which snap.js callback handles the transaction status.
+func snapCheckoutEvent(transactionStatus primitive.TransactionStatus) string {
+	switch transactionStatus {
+	case primitive.TransactionStatusSettlement, primitive.TransactionStatusCapture, primitive.TransactionStatusAuthorize:
+		return "success"
+	case primitive.TransactionStatusDeny, primitive.TransactionStatusFailure:
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+type snapCheckoutPaymentType struct {
+	Value string
+	Label string
+}
+
+type snapCheckoutPageData struct {
+	Error             string
+	Token             string
+	OrderId           string
+	GrossAmount       string
+	PaymentType       string
+	TransactionStatus string
+	PaymentTypes      []snapCheckoutPaymentType
+	Payable           bool
+	Closable          bool
+	// Embedded is true if the page was opened by snap.js, which expects a Message
+	// once the customer is done.
+	Embedded bool
+	Message  *schema.SnapCheckoutMessage
+}
+
+func (p *Presenter) writeSnapCheckoutDetail(w http.ResponseWriter, r *http.Request, snapTransaction business.SnapTransactionResponse, errorMessage string, statusMessage string) {
+	log := zerolog.Ctx(r.Context())
+
+	var paymentType, transactionStatus string
+	if snapTransaction.TransactionStatus != primitive.TransactionStatusUnspecified {
+		paymentType = snapTransaction.PaymentType.ToPaymentMethod()
+		transactionStatus = snapTransaction.TransactionStatus.String()
+	}
+
+	if acceptsHTML(r) {
+		embedded := r.FormValue("embedded") == "1"
+
+		var paymentTypes []snapCheckoutPaymentType
+		for _, paymentType := range snapPaymentTypes {
+			label := paymentType.ToPaymentMethod()
+			if bank := paymentType.ToBank(); bank != "" {
+				label += " (" + bank + ")"
+			}
+
+			if store := paymentType.ToStore(); store != "" {
+				label += " (" + store + ")"
+			}
+
+			paymentTypes = append(paymentTypes, snapCheckoutPaymentType{
+				Value: paymentType.String(),
+				Label: label,
+			})
+		}
+
+		statusCode := http.StatusOK
+		if errorMessage != "" {
+			statusCode = http.StatusBadRequest
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(statusCode)
+		err := templates.ExecuteTemplate(w, "snap_checkout.html", snapCheckoutPageData{
+			Error:             errorMessage,
+			Token:             snapTransaction.Token,
+			OrderId:           snapTransaction.OrderId,
+			GrossAmount:       strconv.FormatInt(snapTransaction.TransactionAmount, 10),
+			PaymentType:       paymentType,
+			TransactionStatus: transactionStatus,
+			PaymentTypes:      paymentTypes,
+			Payable:           snapTransaction.TransactionStatus == primitive.TransactionStatusUnspecified,
+			Closable:          snapTransaction.Callbacks.Unfinish != "" || embedded,
+			Embedded:          embedded,
+		})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.SnapCheckoutResponse{
+		StatusCode:        "200",
+		StatusMessage:     statusMessage,
+		Token:             snapTransaction.Token,
+		OrderId:           snapTransaction.OrderId,
+		GrossAmount:       strconv.FormatInt(snapTransaction.TransactionAmount, 10),
+		Currency:          "IDR",
+		PaymentType:       paymentType,
+		TransactionStatus: transactionStatus,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// writeSnapCheckoutMessage renders a page that hands the result over to snap.js on the
+// merchant's page, which then calls the callback of the event.
+func (p *Presenter) writeSnapCheckoutMessage(w http.ResponseWriter, r *http.Request, event string, snapTransaction business.SnapTransactionResponse) {
+	log := zerolog.Ctx(r.Context())
+
+	var paymentType, transactionStatus string
+	if snapTransaction.TransactionStatus != primitive.TransactionStatusUnspecified {
+		paymentType = snapTransaction.PaymentType.ToPaymentMethod()
+		transactionStatus = snapTransaction.TransactionStatus.String()
+	}
+
+	statusMessage := "Success, transaction is found"
+	if event == "error" {
+		statusMessage = "Transaction is denied"
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	err := templates.ExecuteTemplate(w, "snap_checkout.html", snapCheckoutPageData{
+		Embedded: true,
+		Message: &schema.SnapCheckoutMessage{
+			Source: "mock-snap",
+			Event:  event,
+			Result: schema.SnapCheckoutResponse{
+				StatusCode:        snapStatusCode(snapTransaction.TransactionStatus),
+				StatusMessage:     statusMessage,
+				Token:             snapTransaction.Token,
+				OrderId:           snapTransaction.OrderId,
+				GrossAmount:       strconv.FormatInt(snapTransaction.TransactionAmount, 10),
+				Currency:          "IDR",
+				PaymentType:       paymentType,
+				TransactionStatus: transactionStatus,
+			},
+		},
+	})
+	if err != nil {
+		log.Err(err).Msg("executing template")
+	}
+}
+
+func (p *Presenter) writeSnapCheckoutError(w http.ResponseWriter, r *http.Request, err error) {
+	log := zerolog.Ctx(r.Context())
+
+	statusCode := http.StatusInternalServerError
+	statusMessage := "Internal server error."
+	var requestValidationError *business.RequestValidationError
+	switch {
+	case errors.Is(err, business.ErrTransactionNotFound):
+		statusCode = http.StatusNotFound
+		statusMessage = "Transaction doesn't exist, or the checkout page has expired."
+	case errors.Is(err, business.ErrCannotModifyStatus):
+		statusCode = http.StatusPreconditionFailed
+		statusMessage = "Transaction has already been paid."
+	case errors.Is(err, business.ErrInvalidCardToken):
+		statusCode = http.StatusBadRequest
+		statusMessage = "Card is invalid."
+	case errors.As(err, &requestValidationError):
+		statusCode = http.StatusBadRequest
+		statusMessage = requestValidationError.Error()
+	default:
+		log.Err(err).Msg("executing business function")
+	}
+
+	// Show the checkout page again if the customer can still fix their input
+	if statusCode == http.StatusBadRequest && acceptsHTML(r) {
+		snapTransaction, e := p.snapService.GetTransaction(r.Context(), chi.URLParam(r, "token"))
+		if e == nil {
+			p.writeSnapCheckoutDetail(w, r, snapTransaction, statusMessage, "")
+			return
+		}
+	}
+
+	if acceptsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(statusCode)
+		err := templates.ExecuteTemplate(w, "snap_checkout.html", snapCheckoutPageData{
+			Error:    statusMessage,
+			Embedded: r.FormValue("embedded") == "1",
+		})
+		if err != nil {
+			log.Err(err).Msg("executing template")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.Error{
+		StatusCode:    statusCode,
+		StatusMessage: statusMessage,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(responseBody)
+}
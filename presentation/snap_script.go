@@ -0,0 +1,24 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog"
+)
+
+// SnapJS serves a drop-in replacement of Midtrans' snap.js, which opens the checkout
+// page of this mock in an iframe, so frontend tests can run without Midtrans.
+func (p *Presenter) SnapJS(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	script, err := views.ReadFile("views/snap.js")
+	if err != nil {
+		log.Err(err).Msg("reading snap.js")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(script)
+}
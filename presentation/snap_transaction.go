@@ -0,0 +1,125 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+)
+
+// CreateSnapTransaction creates a Snap checkout session. Unlike the Core API, the Snap API
+// uses proper HTTP status codes, and returns its errors as a list of messages.
+func (p *Presenter) CreateSnapTransaction(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	var requestBody schema.SnapTransactionRequest
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	if err != nil {
+		writeSnapError(w, r, http.StatusBadRequest, "Malformed JSON")
+		return
+	}
+
+	// Snap defaults to IDR
+	currency := requestBody.TransactionDetails.Currency
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	var productItems []business.ProductItem
+	for _, item := range requestBody.ItemDetails {
+		productItems = append(productItems, business.ProductItem{
+			ID:       item.Id,
+			Price:    item.Price,
+			Quantity: item.Quantity,
+			Name:     item.Name,
+			Category: item.Category,
+		})
+	}
+
+	snapResponse, err := p.snapService.CreateTransaction(r.Context(), business.SnapRequest{
+		OrderId:             requestBody.TransactionDetails.OrderId,
+		TransactionAmount:   requestBody.TransactionDetails.GrossAmount,
+		TransactionCurrency: currencyMap[currency],
+		Customer: business.CustomerInformation{
+			FirstName:   requestBody.CustomerDetails.FirstName,
+			LastName:    requestBody.CustomerDetails.LastName,
+			Email:       requestBody.CustomerDetails.Email,
+			PhoneNumber: requestBody.CustomerDetails.PhoneNumber,
+			BillingAddress: business.Address{
+				FirstName:   requestBody.CustomerDetails.BillingAddress.FirstName,
+				LastName:    requestBody.CustomerDetails.BillingAddress.LastName,
+				Email:       requestBody.CustomerDetails.BillingAddress.Email,
+				Phone:       requestBody.CustomerDetails.BillingAddress.Phone,
+				Address:     requestBody.CustomerDetails.BillingAddress.Address,
+				PostalCode:  requestBody.CustomerDetails.BillingAddress.PostalCode,
+				CountryCode: requestBody.CustomerDetails.BillingAddress.CountryCode,
+			},
+		},
+		Seller: business.SellerInformation{
+			FirstName:   requestBody.Seller.FirstName,
+			LastName:    requestBody.Seller.LastName,
+			Email:       requestBody.Seller.Email,
+			PhoneNumber: requestBody.Seller.PhoneNumber,
+			Address:     requestBody.Seller.Address,
+		},
+		ProductItems: productItems,
+		Callbacks: business.SnapCallbacks{
+			Finish:   requestBody.Callbacks.Finish,
+			Unfinish: requestBody.Callbacks.Unfinish,
+			Error:    requestBody.Callbacks.Error,
+		},
+	})
+	if err != nil {
+		var requestValidationError *business.RequestValidationError
+		switch {
+		case errors.Is(err, business.ErrDuplicateOrderId):
+			writeSnapError(w, r, http.StatusBadRequest, "transaction_details.order_id has already been taken")
+		case errors.Is(err, business.ErrMismatchedTransactionAmount):
+			writeSnapError(w, r, http.StatusBadRequest, "transaction_details.gross_amount is not equal to the sum of item_details")
+		case errors.As(err, &requestValidationError):
+			var messages []string
+			for _, issue := range requestValidationError.Issues {
+				messages = append(messages, fmt.Sprintf("%s %s", issue.Field, issue.Message))
+			}
+
+			writeSnapError(w, r, http.StatusBadRequest, messages...)
+		default:
+			log.Err(err).Msg("executing business function")
+			writeSnapError(w, r, http.StatusInternalServerError, "Sorry, we encountered internal server error. We will fix this soon.")
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.SnapTransactionResponse{
+		Token:       snapResponse.Token,
+		RedirectURL: snapResponse.RedirectURL,
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+func writeSnapError(w http.ResponseWriter, r *http.Request, statusCode int, messages ...string) {
+	log := zerolog.Ctx(r.Context())
+
+	responseBody, err := json.Marshal(schema.SnapError{ErrorMessages: messages})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(responseBody)
+}
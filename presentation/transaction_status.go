@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -77,28 +78,60 @@ func (p *Presenter) GetTransactionStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	var refundAmount string
+	var refunds []schema.Refund
+	if len(status.Refunds) > 0 {
+		refundAmount = strconv.FormatInt(status.RefundAmount, 10)
+	}
+
+	for _, refund := range status.Refunds {
+		var refundMethod string
+		if refund.Direct {
+			refundMethod = "online"
+		}
+
+		refunds = append(refunds, schema.Refund{
+			RefundChargebackId: refund.RefundChargebackId,
+			RefundAmount:       strconv.FormatInt(refund.Amount, 10),
+			CreatedAt:          refund.CreatedAt.Format(time.DateTime),
+			Reason:             refund.Reason,
+			RefundKey:          refund.RefundKey,
+			RefundMethod:       refundMethod,
+		})
+	}
+
 	signatureKey := signature.Generate(status.OrderId, 200, status.TransactionAmount, "")
 
+	bank := status.PaymentType.ToBank()
+	if status.Bank != "" {
+		bank = status.Bank
+	}
+
 	responseBody, err := json.Marshal(schema.TransactionStatusResponse{
 		StatusCode:               "200",
 		StatusMessage:            "Success, transaction found",
 		TransactionId:            status.OrderId,
-		MaskedCard:               "",
+		MaskedCard:               status.MaskedCard,
 		OrderId:                  status.OrderId,
 		PaymentType:              status.PaymentType.ToPaymentMethod(),
 		TransactionTime:          status.TransactionTime.Format(time.DateTime),
 		TransactionStatus:        status.TransactionStatus.String(),
-		FraudStatus:              "accept",
-		ApprovalCode:             "",
+		ExpiryTime:               status.ExpiresAt.Local().Format(time.DateTime),
+		FraudStatus:              status.FraudStatus.String(),
+		ApprovalCode:             status.ApprovalCode,
 		SignatureKey:             signatureKey,
-		Bank:                     status.PaymentType.ToBank(),
+		Bank:                     bank,
 		GrossAmount:              status.TransactionAmount,
 		ChannelResponseCode:      "",
 		ChannelResponseMessage:   "",
-		CardType:                 "",
+		CardType:                 status.CardType,
 		PaymentOptionType:        "",
 		ShopeepayReferenceNumber: "",
 		ReferenceId:              "",
+		RefundAmount:             refundAmount,
+		Refunds:                  refunds,
+		RecipientName:            status.RecipientName,
+		FreeText:                 buildFreeText(status.FreeText),
 	})
 	if err != nil {
 		log.Err(err).Msg("marshaling json")
@@ -0,0 +1,100 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/business"
+	"mock-payment-provider/presentation/schema"
+	"mock-payment-provider/primitive"
+)
+
+// GetTransactionStatusHistory lists every status change of a transaction, along with
+// where it came from and who asked for it. Midtrans doesn't have this endpoint, it is
+// meant for finding out how an order reached its current status.
+func (p *Presenter) GetTransactionStatusHistory(w http.ResponseWriter, r *http.Request) {
+	log := zerolog.Ctx(r.Context())
+
+	orderId := chi.URLParam(r, "order_id")
+
+	statusHistory, err := p.transactionService.GetStatusHistory(r.Context(), orderId)
+	if err != nil {
+		if errors.Is(err, business.ErrTransactionNotFound) || orderId == "" {
+			responseBody, err := json.Marshal(schema.Error{
+				StatusCode:    404,
+				StatusMessage: "Transaction doesn't exist.",
+				Id:            uuid.NewString(),
+			})
+			if err != nil {
+				log.Err(err).Msg("marshaling json")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write(responseBody)
+			return
+		}
+
+		log.Err(err).Str("order_id", orderId).Msg("executing business function")
+
+		responseBody, err := json.Marshal(schema.Error{
+			StatusCode:    500,
+			StatusMessage: "Internal server error.",
+			Id:            uuid.NewString(),
+		})
+		if err != nil {
+			log.Err(err).Msg("marshaling json")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(responseBody)
+		return
+	}
+
+	responseBody, err := json.Marshal(schema.TransactionStatusHistoryResponse{
+		StatusCode:        "200",
+		StatusMessage:     "Success, transaction found",
+		OrderId:           statusHistory.OrderId,
+		TransactionStatus: statusHistory.TransactionStatus.String(),
+		History:           buildStatusHistory(statusHistory.History),
+	})
+	if err != nil {
+		log.Err(err).Msg("marshaling json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func buildStatusHistory(history []primitive.TransactionStatusChange) []schema.TransactionStatusChange {
+	changes := make([]schema.TransactionStatusChange, 0, len(history))
+	for _, change := range history {
+		var fromStatus string
+		if change.From != primitive.TransactionStatusUnspecified {
+			fromStatus = change.From.String()
+		}
+
+		changes = append(changes, schema.TransactionStatusChange{
+			FromStatus: fromStatus,
+			ToStatus:   change.To.String(),
+			Source:     change.Source.String(),
+			Actor:      change.Actor.String(),
+			ChangedAt:  change.ChangedAt.Format(time.DateTime),
+		})
+	}
+
+	return changes
+}
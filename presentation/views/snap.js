@@ -0,0 +1,122 @@
+/*
+ * Mock snap.js, a drop-in replacement for Midtrans' snap.js that opens the mock
+ * checkout page instead. Load it the same way:
+ *
+ *   <script src="http://localhost:3000/snap/snap.js" data-client-key="..."></script>
+ *
+ * Supports snap.pay(token, options), snap.embed(token, options) and snap.hide().
+ */
+(function (window, document) {
+    "use strict";
+
+    var script = document.currentScript;
+    var baseURL = script && script.src ? new URL(script.src).origin : window.location.origin;
+
+    var current = null;
+
+    function checkoutURL(token) {
+        return baseURL + "/snap/v4/redirection/" + encodeURIComponent(token) + "?embedded=1";
+    }
+
+    function createIframe(token) {
+        var iframe = document.createElement("iframe");
+        iframe.src = checkoutURL(token);
+        iframe.title = "Mock Snap Checkout";
+        iframe.setAttribute("data-testid", "snap-iframe");
+        iframe.style.border = "0";
+        iframe.style.width = "100%";
+        iframe.style.height = "100%";
+        return iframe;
+    }
+
+    function onMessage(event) {
+        if (current === null || event.origin !== baseURL) {
+            return;
+        }
+
+        var data = event.data;
+        if (!data || data.source !== "mock-snap") {
+            return;
+        }
+
+        var options = current.options;
+        hide();
+
+        switch (data.event) {
+            case "success":
+                if (typeof options.onSuccess === "function") {
+                    options.onSuccess(data.result);
+                }
+                break;
+            case "pending":
+                if (typeof options.onPending === "function") {
+                    options.onPending(data.result);
+                }
+                break;
+            case "error":
+                if (typeof options.onError === "function") {
+                    options.onError(data.result);
+                }
+                break;
+            case "close":
+                if (typeof options.onClose === "function") {
+                    options.onClose();
+                }
+                break;
+        }
+    }
+
+    function hide() {
+        if (current === null) {
+            return;
+        }
+
+        if (current.element.parentNode) {
+            current.element.parentNode.removeChild(current.element);
+        }
+        current = null;
+    }
+
+    function pay(token, options) {
+        options = options || {};
+        hide();
+
+        var overlay = document.createElement("div");
+        overlay.id = "snap-midtrans";
+        overlay.style.position = "fixed";
+        overlay.style.top = "0";
+        overlay.style.left = "0";
+        overlay.style.width = "100%";
+        overlay.style.height = "100%";
+        overlay.style.zIndex = "2147483647";
+        overlay.style.background = "rgba(0, 0, 0, 0.5)";
+        overlay.appendChild(createIframe(token));
+        document.body.appendChild(overlay);
+
+        current = { element: overlay, options: options };
+    }
+
+    function embed(token, options) {
+        options = options || {};
+        hide();
+
+        var container = document.getElementById(options.embedId);
+        if (container === null) {
+            throw new Error("snap.embed: element with id \"" + options.embedId + "\" was not found");
+        }
+
+        var iframe = createIframe(token);
+        iframe.style.minHeight = "600px";
+        container.appendChild(iframe);
+
+        current = { element: iframe, options: options };
+    }
+
+    window.addEventListener("message", onMessage);
+
+    window.snap = {
+        pay: pay,
+        embed: embed,
+        hide: hide
+    };
+})(window, document);
@@ -7,3 +7,7 @@ var PhoneNumberPattern = regexp.MustCompile(`^\+\d+$`)
 
 const PostalCodePattern = `^\d{5,10}$`
 const CountryCodePattern = `^\d{0,5}$`
+
+// MandiriBillerCode is the biller code of every Mandiri Bill Payment, the bill key is
+// what tells the bills apart.
+const MandiriBillerCode = "70012"
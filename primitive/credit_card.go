@@ -0,0 +1,183 @@
+package primitive
+
+import "time"
+
+// CardOutcome decides what happens to a credit card charge once the customer has
+// gone through 3-D Secure authentication.
+type CardOutcome uint8
+
+const (
+	CardOutcomeUnspecified CardOutcome = iota
+	// CardOutcomeAccept captures the charge.
+	CardOutcomeAccept
+	// CardOutcomeDenyByBank denies the charge, as if the issuing bank refused it.
+	CardOutcomeDenyByBank
+	// CardOutcomeDenyByFraud denies the charge, as if the fraud detection system refused it.
+	CardOutcomeDenyByFraud
+	// CardOutcomeChallenge captures the charge, but flags it for review by the fraud
+	// detection system.
+	CardOutcomeChallenge
+)
+
+func (c CardOutcome) String() string {
+	switch c {
+	case CardOutcomeAccept:
+		return "accept"
+	case CardOutcomeDenyByBank:
+		return "deny_by_bank"
+	case CardOutcomeDenyByFraud:
+		return "deny_by_fraud"
+	case CardOutcomeChallenge:
+		return "challenge"
+	case CardOutcomeUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// FraudStatus returns the fraud status that the fraud detection system gives to
+// a charge with this outcome.
+func (c CardOutcome) FraudStatus() FraudStatus {
+	switch c {
+	case CardOutcomeDenyByFraud:
+		return FraudStatusDeny
+	case CardOutcomeChallenge:
+		return FraudStatusChallenge
+	default:
+		return FraudStatusAccept
+	}
+}
+
+// CardTransactionType decides whether a credit card charge is captured right after
+// the 3-D Secure authentication, or only authorized to be captured later.
+type CardTransactionType uint8
+
+const (
+	CardTransactionTypeUnspecified CardTransactionType = iota
+	// CardTransactionTypeAuthorizeCapture captures the charge right away.
+	CardTransactionTypeAuthorizeCapture
+	// CardTransactionTypeAuthorize only reserves the amount on the card, the merchant
+	// must capture or cancel it later.
+	CardTransactionTypeAuthorize
+)
+
+func (c CardTransactionType) String() string {
+	switch c {
+	case CardTransactionTypeAuthorizeCapture:
+		return "authorize_capture"
+	case CardTransactionTypeAuthorize:
+		return "authorize"
+	case CardTransactionTypeUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// sandboxCardOutcomes maps Midtrans' published sandbox card numbers to their outcome.
+var sandboxCardOutcomes = map[string]CardOutcome{
+	"4811111111111114": CardOutcomeAccept,
+	"4911111111111113": CardOutcomeDenyByBank,
+	"4411111111111118": CardOutcomeDenyByFraud,
+	"4511111111111117": CardOutcomeChallenge,
+	"5211111111111117": CardOutcomeAccept,
+	"5111111111111118": CardOutcomeDenyByBank,
+	"5411111111111115": CardOutcomeDenyByFraud,
+	"5511111111111114": CardOutcomeChallenge,
+}
+
+// SandboxCardOutcome returns the outcome of a card number. Any card number other than
+// the sandbox card numbers will always be accepted.
+func SandboxCardOutcome(cardNumber string) CardOutcome {
+	if outcome, ok := sandboxCardOutcomes[cardNumber]; ok {
+		return outcome
+	}
+
+	return CardOutcomeAccept
+}
+
+// ValidCardNumber checks whether the card number consists of 12 to 19 digits, and
+// passes the Luhn algorithm.
+func ValidCardNumber(cardNumber string) bool {
+	if len(cardNumber) < 12 || len(cardNumber) > 19 {
+		return false
+	}
+
+	var sum int
+	for i := 0; i < len(cardNumber); i++ {
+		digit := cardNumber[len(cardNumber)-1-i]
+		if digit < '0' || digit > '9' {
+			return false
+		}
+
+		value := int(digit - '0')
+		if i%2 == 1 {
+			value *= 2
+			if value > 9 {
+				value -= 9
+			}
+		}
+
+		sum += value
+	}
+
+	return sum%10 == 0
+}
+
+// MaskCardNumber returns the first 6 and the last 4 digits of the card number,
+// separated by a dash. For example: 481111-1114
+func MaskCardNumber(cardNumber string) string {
+	if len(cardNumber) < 10 {
+		return ""
+	}
+
+	return cardNumber[:6] + "-" + cardNumber[len(cardNumber)-4:]
+}
+
+// CardToken represents a tokenized card. A regular token is meant to be used once,
+// shortly after the customer entered their card details. A saved token can be used
+// over and over again until the card expires, which enables one-click payments.
+type CardToken struct {
+	TokenId    string
+	MaskedCard string
+	Outcome    CardOutcome
+	Saved      bool
+	// Used is set once a regular token was charged, a saved token is never used up.
+	Used bool
+	// CardExpiresAt is the end of the card's expiry month.
+	CardExpiresAt time.Time
+	ExpiresAt     time.Time
+}
+
+func (c CardToken) Expired() bool {
+	return c.ExpiresAt.Before(time.Now())
+}
+
+// CreditCardCharge holds the card details of a credit card transaction, along with
+// the ID that is used for authenticating the charge through 3-D Secure.
+type CreditCardCharge struct {
+	Id      string
+	OrderId string
+	TokenId string
+	// MaskedCard contains the first 6 and the last 4 digits of the card number,
+	// separated by a dash. For example: 481111-1114
+	MaskedCard   string
+	Bank         string
+	CardType     string
+	ApprovalCode string
+	Outcome      CardOutcome
+	// SavedTokenId is only available if the merchant asked for the card to be saved.
+	SavedTokenId          string
+	SavedTokenIdExpiresAt time.Time
+	TransactionType       CardTransactionType
+	Amount                int64
+	// CapturedAmount is the amount that the merchant captured from an authorized charge.
+	// It is zero until the charge is captured, and may be lower than Amount.
+	CapturedAmount int64
+	ExpiresAt      time.Time
+}
+
+func (c CreditCardCharge) Expired() bool {
+	return c.ExpiresAt.Before(time.Now())
+}
@@ -0,0 +1,113 @@
+package primitive_test
+
+import (
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestValidCardNumber(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		for _, cardNumber := range []string{"4811111111111114", "5211111111111117", "378282246310005"} {
+			if !primitive.ValidCardNumber(cardNumber) {
+				t.Errorf("expecting %s to be valid", cardNumber)
+			}
+		}
+	})
+
+	t.Run("Invalid Checksum", func(t *testing.T) {
+		if primitive.ValidCardNumber("4811111111111115") {
+			t.Errorf("expecting 4811111111111115 to be invalid")
+		}
+	})
+
+	t.Run("Non Digits", func(t *testing.T) {
+		if primitive.ValidCardNumber("4811 1111 1111 1114") {
+			t.Errorf("expecting card number with spaces to be invalid")
+		}
+	})
+
+	t.Run("Too Short", func(t *testing.T) {
+		if primitive.ValidCardNumber("0") {
+			t.Errorf("expecting 0 to be invalid")
+		}
+	})
+}
+
+func TestMaskCardNumber(t *testing.T) {
+	if masked := primitive.MaskCardNumber("4811111111111114"); masked != "481111-1114" {
+		t.Errorf("expecting masked card to be 481111-1114, instead got %s", masked)
+	}
+}
+
+func TestSandboxCardOutcome(t *testing.T) {
+	t.Run("Accept", func(t *testing.T) {
+		if outcome := primitive.SandboxCardOutcome("4811111111111114"); outcome != primitive.CardOutcomeAccept {
+			t.Errorf("expecting outcome to be accept, instead got %s", outcome)
+		}
+	})
+
+	t.Run("Deny By Bank", func(t *testing.T) {
+		if outcome := primitive.SandboxCardOutcome("4911111111111113"); outcome != primitive.CardOutcomeDenyByBank {
+			t.Errorf("expecting outcome to be deny_by_bank, instead got %s", outcome)
+		}
+	})
+
+	t.Run("Challenge", func(t *testing.T) {
+		if outcome := primitive.SandboxCardOutcome("4511111111111117"); outcome != primitive.CardOutcomeChallenge {
+			t.Errorf("expecting outcome to be challenge, instead got %s", outcome)
+		}
+	})
+
+	t.Run("Unknown Card", func(t *testing.T) {
+		if outcome := primitive.SandboxCardOutcome("4111111111111111"); outcome != primitive.CardOutcomeAccept {
+			t.Errorf("expecting outcome to be accept, instead got %s", outcome)
+		}
+	})
+}
+
+func TestCardOutcome_FraudStatus(t *testing.T) {
+	t.Run("CardOutcomeAccept", func(t *testing.T) {
+		if primitive.CardOutcomeAccept.FraudStatus() != primitive.FraudStatusAccept {
+			t.Errorf("expecting fraud status to be accept, instead got %s", primitive.CardOutcomeAccept.FraudStatus())
+		}
+	})
+
+	t.Run("CardOutcomeDenyByBank", func(t *testing.T) {
+		if primitive.CardOutcomeDenyByBank.FraudStatus() != primitive.FraudStatusAccept {
+			t.Errorf("expecting fraud status to be accept, instead got %s", primitive.CardOutcomeDenyByBank.FraudStatus())
+		}
+	})
+
+	t.Run("CardOutcomeDenyByFraud", func(t *testing.T) {
+		if primitive.CardOutcomeDenyByFraud.FraudStatus() != primitive.FraudStatusDeny {
+			t.Errorf("expecting fraud status to be deny, instead got %s", primitive.CardOutcomeDenyByFraud.FraudStatus())
+		}
+	})
+
+	t.Run("CardOutcomeChallenge", func(t *testing.T) {
+		if primitive.CardOutcomeChallenge.FraudStatus() != primitive.FraudStatusChallenge {
+			t.Errorf("expecting fraud status to be challenge, instead got %s", primitive.CardOutcomeChallenge.FraudStatus())
+		}
+	})
+}
+
+func TestCardTransactionType_String(t *testing.T) {
+	t.Run("CardTransactionTypeAuthorizeCapture", func(t *testing.T) {
+		if primitive.CardTransactionTypeAuthorizeCapture.String() != "authorize_capture" {
+			t.Errorf("expecting CardTransactionTypeAuthorizeCapture.String() to be 'authorize_capture', instead got %s", primitive.CardTransactionTypeAuthorizeCapture.String())
+		}
+	})
+
+	t.Run("CardTransactionTypeAuthorize", func(t *testing.T) {
+		if primitive.CardTransactionTypeAuthorize.String() != "authorize" {
+			t.Errorf("expecting CardTransactionTypeAuthorize.String() to be 'authorize', instead got %s", primitive.CardTransactionTypeAuthorize.String())
+		}
+	})
+
+	t.Run("CardTransactionTypeUnspecified", func(t *testing.T) {
+		if primitive.CardTransactionTypeUnspecified.String() != "UNSPECIFIED" {
+			t.Errorf("expecting CardTransactionTypeUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.CardTransactionTypeUnspecified.String())
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package primitive
+
+import "time"
+
+// ExpiryUnit is the unit of a custom expiry duration on a charge request.
+type ExpiryUnit uint8
+
+const (
+	ExpiryUnitUnspecified ExpiryUnit = iota
+	ExpiryUnitSecond
+	ExpiryUnitMinute
+	ExpiryUnitHour
+	ExpiryUnitDay
+)
+
+func (e ExpiryUnit) String() string {
+	switch e {
+	case ExpiryUnitSecond:
+		return "second"
+	case ExpiryUnitMinute:
+		return "minute"
+	case ExpiryUnitHour:
+		return "hour"
+	case ExpiryUnitDay:
+		return "day"
+	case ExpiryUnitUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// Duration returns the length of a single unit. It returns zero for an unspecified unit.
+func (e ExpiryUnit) Duration() time.Duration {
+	switch e {
+	case ExpiryUnitSecond:
+		return time.Second
+	case ExpiryUnitMinute:
+		return time.Minute
+	case ExpiryUnitHour:
+		return time.Hour
+	case ExpiryUnitDay:
+		return time.Hour * 24
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,60 @@
+package primitive_test
+
+import (
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestExpiryUnit_String(t *testing.T) {
+	t.Run("ExpiryUnitSecond", func(t *testing.T) {
+		if primitive.ExpiryUnitSecond.String() != "second" {
+			t.Errorf("expecting ExpiryUnitSecond.String() to be 'second', instead got %s", primitive.ExpiryUnitSecond.String())
+		}
+	})
+
+	t.Run("ExpiryUnitMinute", func(t *testing.T) {
+		if primitive.ExpiryUnitMinute.String() != "minute" {
+			t.Errorf("expecting ExpiryUnitMinute.String() to be 'minute', instead got %s", primitive.ExpiryUnitMinute.String())
+		}
+	})
+
+	t.Run("ExpiryUnitHour", func(t *testing.T) {
+		if primitive.ExpiryUnitHour.String() != "hour" {
+			t.Errorf("expecting ExpiryUnitHour.String() to be 'hour', instead got %s", primitive.ExpiryUnitHour.String())
+		}
+	})
+
+	t.Run("ExpiryUnitDay", func(t *testing.T) {
+		if primitive.ExpiryUnitDay.String() != "day" {
+			t.Errorf("expecting ExpiryUnitDay.String() to be 'day', instead got %s", primitive.ExpiryUnitDay.String())
+		}
+	})
+
+	t.Run("ExpiryUnitUnspecified", func(t *testing.T) {
+		if primitive.ExpiryUnitUnspecified.String() != "UNSPECIFIED" {
+			t.Errorf("expecting ExpiryUnitUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.ExpiryUnitUnspecified.String())
+		}
+	})
+}
+
+func TestExpiryUnit_Duration(t *testing.T) {
+	t.Run("ExpiryUnitMinute", func(t *testing.T) {
+		if primitive.ExpiryUnitMinute.Duration() != time.Minute {
+			t.Errorf("expecting ExpiryUnitMinute.Duration() to be 1m, instead got %s", primitive.ExpiryUnitMinute.Duration())
+		}
+	})
+
+	t.Run("ExpiryUnitDay", func(t *testing.T) {
+		if primitive.ExpiryUnitDay.Duration() != time.Hour*24 {
+			t.Errorf("expecting ExpiryUnitDay.Duration() to be 24h, instead got %s", primitive.ExpiryUnitDay.Duration())
+		}
+	})
+
+	t.Run("ExpiryUnitUnspecified", func(t *testing.T) {
+		if primitive.ExpiryUnitUnspecified.Duration() != 0 {
+			t.Errorf("expecting ExpiryUnitUnspecified.Duration() to be 0, instead got %s", primitive.ExpiryUnitUnspecified.Duration())
+		}
+	})
+}
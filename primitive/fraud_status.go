@@ -0,0 +1,55 @@
+package primitive
+
+import "regexp"
+
+type FraudStatus uint8
+
+const (
+	FraudStatusUnspecified FraudStatus = iota
+	// FraudStatusAccept tells that the transaction is safe to be processed.
+	FraudStatusAccept
+	// FraudStatusChallenge tells that the transaction is suspicious, and needs to be
+	// reviewed before being processed any further.
+	FraudStatusChallenge
+	// FraudStatusDeny tells that the transaction is considered fraudulent.
+	FraudStatusDeny
+)
+
+func (f FraudStatus) String() string {
+	switch f {
+	case FraudStatusAccept:
+		return "accept"
+	case FraudStatusChallenge:
+		return "challenge"
+	case FraudStatusDeny:
+		return "deny"
+	case FraudStatusUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// FraudChallengeTrigger puts card charges into FraudStatusChallenge, so the merchant has to
+// approve or deny them. Either condition is enough, and a trigger without any condition
+// never matches.
+type FraudChallengeTrigger struct {
+	// MinimumAmount is inclusive, it is not checked if it is zero.
+	MinimumAmount int64
+	// EmailPattern matches the customer's email, such as "^review\+". It is not checked
+	// if it is nil.
+	EmailPattern *regexp.Regexp
+}
+
+// Matches tells whether a charge of the customer's email and amount should be challenged.
+func (f FraudChallengeTrigger) Matches(email string, amount int64) bool {
+	if f.MinimumAmount != 0 && amount >= f.MinimumAmount {
+		return true
+	}
+
+	if f.EmailPattern != nil && email != "" && f.EmailPattern.MatchString(email) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package primitive_test
+
+import (
+	"regexp"
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestFraudStatus_String(t *testing.T) {
+	t.Run("FraudStatusAccept", func(t *testing.T) {
+		if primitive.FraudStatusAccept.String() != "accept" {
+			t.Errorf("expecting FraudStatusAccept.String() to be 'accept', instead got %s", primitive.FraudStatusAccept.String())
+		}
+	})
+
+	t.Run("FraudStatusChallenge", func(t *testing.T) {
+		if primitive.FraudStatusChallenge.String() != "challenge" {
+			t.Errorf("expecting FraudStatusChallenge.String() to be 'challenge', instead got %s", primitive.FraudStatusChallenge.String())
+		}
+	})
+
+	t.Run("FraudStatusDeny", func(t *testing.T) {
+		if primitive.FraudStatusDeny.String() != "deny" {
+			t.Errorf("expecting FraudStatusDeny.String() to be 'deny', instead got %s", primitive.FraudStatusDeny.String())
+		}
+	})
+
+	t.Run("FraudStatusUnspecified", func(t *testing.T) {
+		if primitive.FraudStatusUnspecified.String() != "UNSPECIFIED" {
+			t.Errorf("expecting FraudStatusUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.FraudStatusUnspecified.String())
+		}
+	})
+}
+
+func TestFraudChallengeTrigger_Matches(t *testing.T) {
+	t.Run("No Condition", func(t *testing.T) {
+		if (primitive.FraudChallengeTrigger{}).Matches("a@b.com", 1_000_000) {
+			t.Errorf("expecting a trigger without any condition not to match")
+		}
+	})
+
+	t.Run("MinimumAmount", func(t *testing.T) {
+		trigger := primitive.FraudChallengeTrigger{MinimumAmount: 500_000}
+		if !trigger.Matches("a@b.com", 500_000) {
+			t.Errorf("expecting the minimum amount to match")
+		}
+
+		if trigger.Matches("a@b.com", 499_999) {
+			t.Errorf("expecting an amount below the minimum not to match")
+		}
+	})
+
+	t.Run("EmailPattern", func(t *testing.T) {
+		trigger := primitive.FraudChallengeTrigger{EmailPattern: regexp.MustCompile(`^review\+`)}
+		if !trigger.Matches("review+1@b.com", 10_000) {
+			t.Errorf("expecting review+1@b.com to match")
+		}
+
+		if trigger.Matches("a@b.com", 10_000) {
+			t.Errorf("expecting a@b.com not to match")
+		}
+
+		if trigger.Matches("", 10_000) {
+			t.Errorf("expecting an empty email not to match")
+		}
+	})
+
+	t.Run("Either Condition", func(t *testing.T) {
+		trigger := primitive.FraudChallengeTrigger{MinimumAmount: 500_000, EmailPattern: regexp.MustCompile(`^review\+`)}
+		if !trigger.Matches("review+1@b.com", 10_000) {
+			t.Errorf("expecting the email pattern alone to match")
+		}
+
+		if !trigger.Matches("a@b.com", 600_000) {
+			t.Errorf("expecting the amount alone to match")
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package primitive
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MagicValue drives a charge to a known outcome, so the merchant can test without calling
+// the internal endpoints. Every condition that is set must match the charge, and a magic
+// value has at least one condition.
+type MagicValue struct {
+	// AmountSuffix matches the last digits of the gross amount, such as "1" for 10001.
+	AmountSuffix  string
+	EmailPrefix   string
+	OrderIdPrefix string
+	// Outcome is either TransactionStatusSettlement, TransactionStatusDeny,
+	// TransactionStatusExpire or TransactionStatusFailure.
+	Outcome TransactionStatus
+	// Delay is how long the charge stays pending before the outcome.
+	Delay time.Duration
+}
+
+// Matches tells whether every condition of the magic value matches the charge.
+func (m MagicValue) Matches(orderId string, email string, amount int64) bool {
+	if m.AmountSuffix == "" && m.EmailPrefix == "" && m.OrderIdPrefix == "" {
+		return false
+	}
+
+	if m.AmountSuffix != "" && !strings.HasSuffix(strconv.FormatInt(amount, 10), m.AmountSuffix) {
+		return false
+	}
+
+	if m.EmailPrefix != "" && !strings.HasPrefix(email, m.EmailPrefix) {
+		return false
+	}
+
+	if m.OrderIdPrefix != "" && !strings.HasPrefix(orderId, m.OrderIdPrefix) {
+		return false
+	}
+
+	return true
+}
+
+// ScheduledOutcome is the outcome that a pending charge is driven to once it is due.
+type ScheduledOutcome struct {
+	OrderId string
+	Outcome TransactionStatus
+	DueAt   time.Time
+}
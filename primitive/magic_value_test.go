@@ -0,0 +1,59 @@
+package primitive_test
+
+import (
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestMagicValue_Matches(t *testing.T) {
+	t.Run("No Condition", func(t *testing.T) {
+		if (primitive.MagicValue{}).Matches("order-1", "a@b.com", 10_001) {
+			t.Errorf("expecting a magic value without any condition not to match")
+		}
+	})
+
+	t.Run("AmountSuffix", func(t *testing.T) {
+		magicValue := primitive.MagicValue{AmountSuffix: "01"}
+		if !magicValue.Matches("order-1", "a@b.com", 10_001) {
+			t.Errorf("expecting 01 to match 10001")
+		}
+
+		if magicValue.Matches("order-1", "a@b.com", 10_010) {
+			t.Errorf("expecting 01 not to match 10010")
+		}
+	})
+
+	t.Run("EmailPrefix", func(t *testing.T) {
+		magicValue := primitive.MagicValue{EmailPrefix: "deny+"}
+		if !magicValue.Matches("order-1", "deny+qa@b.com", 10_000) {
+			t.Errorf("expecting deny+ to match deny+qa@b.com")
+		}
+
+		if magicValue.Matches("order-1", "qa@b.com", 10_000) {
+			t.Errorf("expecting deny+ not to match qa@b.com")
+		}
+	})
+
+	t.Run("OrderIdPrefix", func(t *testing.T) {
+		magicValue := primitive.MagicValue{OrderIdPrefix: "expire-"}
+		if !magicValue.Matches("expire-1", "a@b.com", 10_000) {
+			t.Errorf("expecting expire- to match expire-1")
+		}
+
+		if magicValue.Matches("order-1", "a@b.com", 10_000) {
+			t.Errorf("expecting expire- not to match order-1")
+		}
+	})
+
+	t.Run("Every Condition", func(t *testing.T) {
+		magicValue := primitive.MagicValue{AmountSuffix: "1", EmailPrefix: "qa+", OrderIdPrefix: "fail-"}
+		if !magicValue.Matches("fail-1", "qa+1@b.com", 10_001) {
+			t.Errorf("expecting every condition to match")
+		}
+
+		if magicValue.Matches("fail-1", "qa+1@b.com", 10_002) {
+			t.Errorf("expecting a single mismatched condition to fail the magic value")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package primitive
+
+// MaximumNotificationURLs is the most URLs a merchant can put on either notification header.
+const MaximumNotificationURLs = 3
+
+// NotificationURLs are the webhook receivers a merchant requested for a single transaction,
+// through the X-Override-Notification and X-Append-Notification headers.
+type NotificationURLs struct {
+	// Override replaces the default webhook target.
+	Override []string
+	// Append is sent to along with the default webhook target. It is ignored
+	// if Override is set.
+	Append []string
+}
+
+// Targets returns every URL a notification should be delivered to. The default
+// webhook target is returned as an empty string.
+func (n NotificationURLs) Targets() []string {
+	if len(n.Override) > 0 {
+		return n.Override
+	}
+
+	return append([]string{""}, n.Append...)
+}
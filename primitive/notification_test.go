@@ -0,0 +1,36 @@
+package primitive_test
+
+import (
+	"reflect"
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestNotificationURLs_Targets(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		targets := primitive.NotificationURLs{}.Targets()
+		if !reflect.DeepEqual(targets, []string{""}) {
+			t.Errorf("expecting only the default target, instead got %v", targets)
+		}
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		targets := primitive.NotificationURLs{
+			Override: []string{"https://a.example.com", "https://b.example.com"},
+			Append:   []string{"https://c.example.com"},
+		}.Targets()
+		if !reflect.DeepEqual(targets, []string{"https://a.example.com", "https://b.example.com"}) {
+			t.Errorf("expecting only the override targets, instead got %v", targets)
+		}
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		targets := primitive.NotificationURLs{
+			Append: []string{"https://c.example.com"},
+		}.Targets()
+		if !reflect.DeepEqual(targets, []string{"", "https://c.example.com"}) {
+			t.Errorf("expecting the default and appended targets, instead got %v", targets)
+		}
+	})
+}
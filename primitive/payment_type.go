@@ -11,6 +11,16 @@ const (
 	PaymentTypeEMoneyQRIS
 	PaymentTypeEMoneyGopay
 	PaymentTypeEMoneyShopeePay
+	PaymentTypeCreditCard
+	PaymentTypeVirtualAccountCIMB
+	PaymentTypeVirtualAccountBSI
+	// PaymentTypeMandiriBill is Mandiri Bill Payment, which Midtrans calls echannel.
+	// It is paid with a bill key and a biller code instead of a virtual account number.
+	PaymentTypeMandiriBill
+	PaymentTypeCStoreIndomaret
+	PaymentTypeCStoreAlfamart
+	PaymentTypePaylaterAkulaku
+	PaymentTypePaylaterKredivo
 )
 
 func (p PaymentType) String() string {
@@ -29,6 +39,22 @@ func (p PaymentType) String() string {
 		return "E_MONEY_GOPAY"
 	case PaymentTypeEMoneyShopeePay:
 		return "E_MONEY_SHOPEE_PAY"
+	case PaymentTypeCreditCard:
+		return "CREDIT_CARD"
+	case PaymentTypeVirtualAccountCIMB:
+		return "VIRTUAL_ACCOUNT_CIMB"
+	case PaymentTypeVirtualAccountBSI:
+		return "VIRTUAL_ACCOUNT_BSI"
+	case PaymentTypeMandiriBill:
+		return "MANDIRI_BILL"
+	case PaymentTypeCStoreIndomaret:
+		return "CSTORE_INDOMARET"
+	case PaymentTypeCStoreAlfamart:
+		return "CSTORE_ALFAMART"
+	case PaymentTypePaylaterAkulaku:
+		return "PAYLATER_AKULAKU"
+	case PaymentTypePaylaterKredivo:
+		return "PAYLATER_KREDIVO"
 	case PaymentTypeUnspecified:
 		fallthrough
 	default:
@@ -45,13 +71,29 @@ func (p PaymentType) ToPaymentMethod() string {
 	case PaymentTypeVirtualAccountBRI:
 		fallthrough
 	case PaymentTypeVirtualAccountBNI:
+		fallthrough
+	case PaymentTypeVirtualAccountCIMB:
+		fallthrough
+	case PaymentTypeVirtualAccountBSI:
 		return "bank_transfer"
+	case PaymentTypeMandiriBill:
+		return "echannel"
+	case PaymentTypeCStoreIndomaret:
+		fallthrough
+	case PaymentTypeCStoreAlfamart:
+		return "cstore"
+	case PaymentTypePaylaterAkulaku:
+		return "akulaku"
+	case PaymentTypePaylaterKredivo:
+		return "kredivo"
 	case PaymentTypeEMoneyQRIS:
 		return "qris"
 	case PaymentTypeEMoneyGopay:
 		return "gopay"
 	case PaymentTypeEMoneyShopeePay:
 		return "shopeepay"
+	case PaymentTypeCreditCard:
+		return "credit_card"
 	case PaymentTypeUnspecified:
 		fallthrough
 	default:
@@ -69,7 +111,30 @@ func (p PaymentType) ToBank() string {
 		return "bri"
 	case PaymentTypeVirtualAccountBNI:
 		return "bni"
+	case PaymentTypeVirtualAccountCIMB:
+		return "cimb"
+	case PaymentTypeVirtualAccountBSI:
+		return "bsi"
+	case PaymentTypeMandiriBill:
+		return "mandiri"
 	default:
 		return ""
 	}
 }
+
+func (p PaymentType) ToStore() string {
+	switch p {
+	case PaymentTypeCStoreIndomaret:
+		return "indomaret"
+	case PaymentTypeCStoreAlfamart:
+		return "alfamart"
+	default:
+		return ""
+	}
+}
+
+// Paylater returns true if the customer pays for the payment type by getting approved
+// for an installment on the paylater provider's page.
+func (p PaymentType) Paylater() bool {
+	return p == PaymentTypePaylaterAkulaku || p == PaymentTypePaylaterKredivo
+}
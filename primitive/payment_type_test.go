@@ -49,9 +49,161 @@ func TestPaymentType_String(t *testing.T) {
 		}
 	})
 
+	t.Run("PaymentTypeCreditCard", func(t *testing.T) {
+		if primitive.PaymentTypeCreditCard.String() != "CREDIT_CARD" {
+			t.Errorf("expecting PaymentTypeCreditCard.String() to be 'CREDIT_CARD', instead got %s", primitive.PaymentTypeCreditCard.String())
+		}
+	})
+
+	t.Run("PaymentTypeVirtualAccountCIMB", func(t *testing.T) {
+		if primitive.PaymentTypeVirtualAccountCIMB.String() != "VIRTUAL_ACCOUNT_CIMB" {
+			t.Errorf("expecting PaymentTypeVirtualAccountCIMB.String() to be 'VIRTUAL_ACCOUNT_CIMB', instead got %s", primitive.PaymentTypeVirtualAccountCIMB.String())
+		}
+	})
+
+	t.Run("PaymentTypeVirtualAccountBSI", func(t *testing.T) {
+		if primitive.PaymentTypeVirtualAccountBSI.String() != "VIRTUAL_ACCOUNT_BSI" {
+			t.Errorf("expecting PaymentTypeVirtualAccountBSI.String() to be 'VIRTUAL_ACCOUNT_BSI', instead got %s", primitive.PaymentTypeVirtualAccountBSI.String())
+		}
+	})
+
+	t.Run("PaymentTypeMandiriBill", func(t *testing.T) {
+		if primitive.PaymentTypeMandiriBill.String() != "MANDIRI_BILL" {
+			t.Errorf("expecting PaymentTypeMandiriBill.String() to be 'MANDIRI_BILL', instead got %s", primitive.PaymentTypeMandiriBill.String())
+		}
+	})
+
+	t.Run("PaymentTypeCStoreIndomaret", func(t *testing.T) {
+		if primitive.PaymentTypeCStoreIndomaret.String() != "CSTORE_INDOMARET" {
+			t.Errorf("expecting PaymentTypeCStoreIndomaret.String() to be 'CSTORE_INDOMARET', instead got %s", primitive.PaymentTypeCStoreIndomaret.String())
+		}
+	})
+
+	t.Run("PaymentTypeCStoreAlfamart", func(t *testing.T) {
+		if primitive.PaymentTypeCStoreAlfamart.String() != "CSTORE_ALFAMART" {
+			t.Errorf("expecting PaymentTypeCStoreAlfamart.String() to be 'CSTORE_ALFAMART', instead got %s", primitive.PaymentTypeCStoreAlfamart.String())
+		}
+	})
+
+	t.Run("PaymentTypePaylaterAkulaku", func(t *testing.T) {
+		if primitive.PaymentTypePaylaterAkulaku.String() != "PAYLATER_AKULAKU" {
+			t.Errorf("expecting PaymentTypePaylaterAkulaku.String() to be 'PAYLATER_AKULAKU', instead got %s", primitive.PaymentTypePaylaterAkulaku.String())
+		}
+	})
+
+	t.Run("PaymentTypePaylaterKredivo", func(t *testing.T) {
+		if primitive.PaymentTypePaylaterKredivo.String() != "PAYLATER_KREDIVO" {
+			t.Errorf("expecting PaymentTypePaylaterKredivo.String() to be 'PAYLATER_KREDIVO', instead got %s", primitive.PaymentTypePaylaterKredivo.String())
+		}
+	})
+
 	t.Run("PaymentTypeUnspecified", func(t *testing.T) {
 		if primitive.PaymentTypeUnspecified.String() != "UNSPECIFIED" {
 			t.Errorf("expecting PaymentTypeUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.PaymentTypeUnspecified.String())
 		}
 	})
 }
+
+func TestPaymentType_ToPaymentMethod(t *testing.T) {
+	t.Run("PaymentTypeVirtualAccountCIMB", func(t *testing.T) {
+		if primitive.PaymentTypeVirtualAccountCIMB.ToPaymentMethod() != "bank_transfer" {
+			t.Errorf("expecting PaymentTypeVirtualAccountCIMB.ToPaymentMethod() to be 'bank_transfer', instead got %s", primitive.PaymentTypeVirtualAccountCIMB.ToPaymentMethod())
+		}
+	})
+
+	t.Run("PaymentTypeVirtualAccountBSI", func(t *testing.T) {
+		if primitive.PaymentTypeVirtualAccountBSI.ToPaymentMethod() != "bank_transfer" {
+			t.Errorf("expecting PaymentTypeVirtualAccountBSI.ToPaymentMethod() to be 'bank_transfer', instead got %s", primitive.PaymentTypeVirtualAccountBSI.ToPaymentMethod())
+		}
+	})
+
+	t.Run("PaymentTypeMandiriBill", func(t *testing.T) {
+		if primitive.PaymentTypeMandiriBill.ToPaymentMethod() != "echannel" {
+			t.Errorf("expecting PaymentTypeMandiriBill.ToPaymentMethod() to be 'echannel', instead got %s", primitive.PaymentTypeMandiriBill.ToPaymentMethod())
+		}
+	})
+
+	t.Run("PaymentTypeCStoreIndomaret", func(t *testing.T) {
+		if primitive.PaymentTypeCStoreIndomaret.ToPaymentMethod() != "cstore" {
+			t.Errorf("expecting PaymentTypeCStoreIndomaret.ToPaymentMethod() to be 'cstore', instead got %s", primitive.PaymentTypeCStoreIndomaret.ToPaymentMethod())
+		}
+	})
+
+	t.Run("PaymentTypeCStoreAlfamart", func(t *testing.T) {
+		if primitive.PaymentTypeCStoreAlfamart.ToPaymentMethod() != "cstore" {
+			t.Errorf("expecting PaymentTypeCStoreAlfamart.ToPaymentMethod() to be 'cstore', instead got %s", primitive.PaymentTypeCStoreAlfamart.ToPaymentMethod())
+		}
+	})
+
+	t.Run("PaymentTypePaylaterAkulaku", func(t *testing.T) {
+		if primitive.PaymentTypePaylaterAkulaku.ToPaymentMethod() != "akulaku" {
+			t.Errorf("expecting PaymentTypePaylaterAkulaku.ToPaymentMethod() to be 'akulaku', instead got %s", primitive.PaymentTypePaylaterAkulaku.ToPaymentMethod())
+		}
+	})
+
+	t.Run("PaymentTypePaylaterKredivo", func(t *testing.T) {
+		if primitive.PaymentTypePaylaterKredivo.ToPaymentMethod() != "kredivo" {
+			t.Errorf("expecting PaymentTypePaylaterKredivo.ToPaymentMethod() to be 'kredivo', instead got %s", primitive.PaymentTypePaylaterKredivo.ToPaymentMethod())
+		}
+	})
+}
+
+func TestPaymentType_ToBank(t *testing.T) {
+	t.Run("PaymentTypeVirtualAccountCIMB", func(t *testing.T) {
+		if primitive.PaymentTypeVirtualAccountCIMB.ToBank() != "cimb" {
+			t.Errorf("expecting PaymentTypeVirtualAccountCIMB.ToBank() to be 'cimb', instead got %s", primitive.PaymentTypeVirtualAccountCIMB.ToBank())
+		}
+	})
+
+	t.Run("PaymentTypeVirtualAccountBSI", func(t *testing.T) {
+		if primitive.PaymentTypeVirtualAccountBSI.ToBank() != "bsi" {
+			t.Errorf("expecting PaymentTypeVirtualAccountBSI.ToBank() to be 'bsi', instead got %s", primitive.PaymentTypeVirtualAccountBSI.ToBank())
+		}
+	})
+
+	t.Run("PaymentTypeMandiriBill", func(t *testing.T) {
+		if primitive.PaymentTypeMandiriBill.ToBank() != "mandiri" {
+			t.Errorf("expecting PaymentTypeMandiriBill.ToBank() to be 'mandiri', instead got %s", primitive.PaymentTypeMandiriBill.ToBank())
+		}
+	})
+}
+
+func TestPaymentType_ToStore(t *testing.T) {
+	t.Run("PaymentTypeCStoreIndomaret", func(t *testing.T) {
+		if primitive.PaymentTypeCStoreIndomaret.ToStore() != "indomaret" {
+			t.Errorf("expecting PaymentTypeCStoreIndomaret.ToStore() to be 'indomaret', instead got %s", primitive.PaymentTypeCStoreIndomaret.ToStore())
+		}
+	})
+
+	t.Run("PaymentTypeCStoreAlfamart", func(t *testing.T) {
+		if primitive.PaymentTypeCStoreAlfamart.ToStore() != "alfamart" {
+			t.Errorf("expecting PaymentTypeCStoreAlfamart.ToStore() to be 'alfamart', instead got %s", primitive.PaymentTypeCStoreAlfamart.ToStore())
+		}
+	})
+
+	t.Run("PaymentTypeVirtualAccountBCA", func(t *testing.T) {
+		if primitive.PaymentTypeVirtualAccountBCA.ToStore() != "" {
+			t.Errorf("expecting PaymentTypeVirtualAccountBCA.ToStore() to be empty, instead got %s", primitive.PaymentTypeVirtualAccountBCA.ToStore())
+		}
+	})
+}
+
+func TestPaymentType_Paylater(t *testing.T) {
+	t.Run("PaymentTypePaylaterAkulaku", func(t *testing.T) {
+		if !primitive.PaymentTypePaylaterAkulaku.Paylater() {
+			t.Errorf("expecting PaymentTypePaylaterAkulaku to be paylater")
+		}
+	})
+
+	t.Run("PaymentTypePaylaterKredivo", func(t *testing.T) {
+		if !primitive.PaymentTypePaylaterKredivo.Paylater() {
+			t.Errorf("expecting PaymentTypePaylaterKredivo to be paylater")
+		}
+	})
+
+	t.Run("PaymentTypeEMoneyGopay", func(t *testing.T) {
+		if primitive.PaymentTypeEMoneyGopay.Paylater() {
+			t.Errorf("expecting PaymentTypeEMoneyGopay to not be paylater")
+		}
+	})
+}
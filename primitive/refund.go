@@ -0,0 +1,14 @@
+package primitive
+
+import "time"
+
+type Refund struct {
+	RefundChargebackId int64
+	OrderId            string
+	RefundKey          string
+	Amount             int64
+	Reason             string
+	// Direct tells whether the refund was requested through the direct (online) refund API.
+	Direct    bool
+	CreatedAt time.Time
+}
@@ -0,0 +1,113 @@
+package primitive
+
+import (
+	"path"
+	"regexp"
+	"time"
+)
+
+// RuleAction is how this service misbehaves once a rule matches.
+// The values are persisted, new actions must be appended at the end.
+type RuleAction uint8
+
+const (
+	RuleActionUnspecified RuleAction = iota
+	// RuleActionLatency holds the response back for Rule.Latency.
+	RuleActionLatency
+	// RuleActionRespond replaces the response with Rule.HTTPStatus and a Midtrans error body.
+	RuleActionRespond
+	// RuleActionDeny denies the charge, whatever the payment type is.
+	RuleActionDeny
+	// RuleActionDropWebhook never sends the notification to the merchant.
+	RuleActionDropWebhook
+)
+
+func (a RuleAction) String() string {
+	switch a {
+	case RuleActionLatency:
+		return "latency"
+	case RuleActionRespond:
+		return "respond"
+	case RuleActionDeny:
+		return "deny"
+	case RuleActionDropWebhook:
+		return "drop_webhook"
+	case RuleActionUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// RuleSubject is the request, charge or notification that rules are matched against.
+// Whatever is not known where the rules are applied is left empty.
+type RuleSubject struct {
+	Path        string
+	OrderId     string
+	PaymentType PaymentType
+	Amount      int64
+}
+
+// Rule makes this service misbehave on demand, so the merchant can test how they handle
+// a payment provider that is slow, failing or losing notifications. Every condition that
+// is set must match the subject, and a rule without any condition matches everything.
+type Rule struct {
+	Id string
+	// Path is a pattern of path.Match, such as "/*/status".
+	Path string
+	// OrderIdPattern is a regular expression, such as "^fail-".
+	OrderIdPattern string
+	PaymentType    PaymentType
+	// MinimumAmount and MaximumAmount are inclusive, they are not checked if they are zero.
+	MinimumAmount int64
+	MaximumAmount int64
+	Action        RuleAction
+	// Latency is only used by RuleActionLatency.
+	Latency time.Duration
+	// HTTPStatus, StatusCode and StatusMessage are only used by RuleActionRespond. StatusCode
+	// and StatusMessage go into the Midtrans error body, they default to the HTTP status.
+	HTTPStatus    int
+	StatusCode    int
+	StatusMessage string
+	// OneShot rules are removed once they are applied, the other ones stay until deleted.
+	OneShot   bool
+	CreatedAt time.Time
+}
+
+// Matches tells whether every condition of the rule matches the subject. A condition can't
+// match if the subject doesn't know about it.
+func (r Rule) Matches(subject RuleSubject) bool {
+	if r.Path != "" {
+		if ok, err := path.Match(r.Path, subject.Path); err != nil || !ok {
+			return false
+		}
+	}
+
+	if r.OrderIdPattern != "" {
+		if subject.OrderId == "" {
+			return false
+		}
+
+		if ok, err := regexp.MatchString(r.OrderIdPattern, subject.OrderId); err != nil || !ok {
+			return false
+		}
+	}
+
+	if r.PaymentType != PaymentTypeUnspecified && r.PaymentType != subject.PaymentType {
+		return false
+	}
+
+	if (r.MinimumAmount != 0 || r.MaximumAmount != 0) && subject.Amount == 0 {
+		return false
+	}
+
+	if r.MinimumAmount != 0 && subject.Amount < r.MinimumAmount {
+		return false
+	}
+
+	if r.MaximumAmount != 0 && subject.Amount > r.MaximumAmount {
+		return false
+	}
+
+	return true
+}
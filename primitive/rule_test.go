@@ -0,0 +1,127 @@
+package primitive_test
+
+import (
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestRuleAction_String(t *testing.T) {
+	t.Run("RuleActionLatency", func(t *testing.T) {
+		if primitive.RuleActionLatency.String() != "latency" {
+			t.Errorf("expecting RuleActionLatency.String() to be 'latency', instead got %s", primitive.RuleActionLatency.String())
+		}
+	})
+
+	t.Run("RuleActionRespond", func(t *testing.T) {
+		if primitive.RuleActionRespond.String() != "respond" {
+			t.Errorf("expecting RuleActionRespond.String() to be 'respond', instead got %s", primitive.RuleActionRespond.String())
+		}
+	})
+
+	t.Run("RuleActionDeny", func(t *testing.T) {
+		if primitive.RuleActionDeny.String() != "deny" {
+			t.Errorf("expecting RuleActionDeny.String() to be 'deny', instead got %s", primitive.RuleActionDeny.String())
+		}
+	})
+
+	t.Run("RuleActionDropWebhook", func(t *testing.T) {
+		if primitive.RuleActionDropWebhook.String() != "drop_webhook" {
+			t.Errorf("expecting RuleActionDropWebhook.String() to be 'drop_webhook', instead got %s", primitive.RuleActionDropWebhook.String())
+		}
+	})
+
+	t.Run("RuleActionUnspecified", func(t *testing.T) {
+		if primitive.RuleActionUnspecified.String() != "UNSPECIFIED" {
+			t.Errorf("expecting RuleActionUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.RuleActionUnspecified.String())
+		}
+	})
+}
+
+func TestRule_Matches(t *testing.T) {
+	subject := primitive.RuleSubject{
+		Path:        "/charge",
+		OrderId:     "fail-001",
+		PaymentType: primitive.PaymentTypeEMoneyGopay,
+		Amount:      50_000,
+	}
+
+	t.Run("No Condition", func(t *testing.T) {
+		if !(primitive.Rule{}).Matches(subject) {
+			t.Errorf("expecting a rule without any condition to match")
+		}
+	})
+
+	t.Run("Path", func(t *testing.T) {
+		if !(primitive.Rule{Path: "/charge"}).Matches(subject) {
+			t.Errorf("expecting /charge to match")
+		}
+
+		if !(primitive.Rule{Path: "/*/status"}).Matches(primitive.RuleSubject{Path: "/order-1/status"}) {
+			t.Errorf("expecting /*/status to match /order-1/status")
+		}
+
+		if (primitive.Rule{Path: "/*/status"}).Matches(subject) {
+			t.Errorf("expecting /*/status not to match /charge")
+		}
+	})
+
+	t.Run("OrderIdPattern", func(t *testing.T) {
+		if !(primitive.Rule{OrderIdPattern: "^fail-"}).Matches(subject) {
+			t.Errorf("expecting ^fail- to match fail-001")
+		}
+
+		if (primitive.Rule{OrderIdPattern: "^ok-"}).Matches(subject) {
+			t.Errorf("expecting ^ok- not to match fail-001")
+		}
+
+		if (primitive.Rule{OrderIdPattern: ".*"}).Matches(primitive.RuleSubject{Path: "/charge"}) {
+			t.Errorf("expecting an order id pattern not to match a subject without order id")
+		}
+	})
+
+	t.Run("PaymentType", func(t *testing.T) {
+		if !(primitive.Rule{PaymentType: primitive.PaymentTypeEMoneyGopay}).Matches(subject) {
+			t.Errorf("expecting gopay to match")
+		}
+
+		if (primitive.Rule{PaymentType: primitive.PaymentTypeVirtualAccountBCA}).Matches(subject) {
+			t.Errorf("expecting bca virtual account not to match gopay")
+		}
+	})
+
+	t.Run("Amount", func(t *testing.T) {
+		if !(primitive.Rule{MinimumAmount: 50_000, MaximumAmount: 50_000}).Matches(subject) {
+			t.Errorf("expecting the inclusive amount range to match")
+		}
+
+		if (primitive.Rule{MinimumAmount: 50_001}).Matches(subject) {
+			t.Errorf("expecting an amount below the minimum not to match")
+		}
+
+		if (primitive.Rule{MaximumAmount: 49_999}).Matches(subject) {
+			t.Errorf("expecting an amount above the maximum not to match")
+		}
+
+		if (primitive.Rule{MaximumAmount: 100_000}).Matches(primitive.RuleSubject{Path: "/charge"}) {
+			t.Errorf("expecting an amount range not to match a subject without amount")
+		}
+	})
+
+	t.Run("Every Condition", func(t *testing.T) {
+		rule := primitive.Rule{
+			Path:           "/charge",
+			OrderIdPattern: "^fail-",
+			PaymentType:    primitive.PaymentTypeEMoneyGopay,
+			MinimumAmount:  10_000,
+		}
+		if !rule.Matches(subject) {
+			t.Errorf("expecting every condition to match")
+		}
+
+		rule.Path = "/snap/v1/transactions"
+		if rule.Matches(subject) {
+			t.Errorf("expecting a single mismatched condition to fail the rule")
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package primitive
+
+import "time"
+
+// SnapTransaction is a Snap checkout session. The order is only charged once the
+// customer picks a payment type on the checkout page.
+type SnapTransaction struct {
+	Token   string
+	OrderId string
+	Amount  int64
+	// Details holds the rest of the Snap request (customer, seller and items), encoded
+	// as JSON. It is only meant to be read back by the business layer when the order
+	// is charged.
+	Details []byte
+	// FinishURL, UnfinishURL and ErrorURL are the merchant's redirect callbacks. Any of
+	// them may be empty.
+	FinishURL   string
+	UnfinishURL string
+	ErrorURL    string
+	ExpiresAt   time.Time
+}
+
+func (s SnapTransaction) Expired() bool {
+	return s.ExpiresAt.Before(time.Now())
+}
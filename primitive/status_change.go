@@ -0,0 +1,85 @@
+package primitive
+
+import "time"
+
+// StatusChangeSource tells which part of the system changed the status of a transaction.
+// The values are persisted, new sources must be appended at the end.
+type StatusChangeSource uint8
+
+const (
+	StatusChangeSourceUnspecified StatusChangeSource = iota
+	// StatusChangeSourceCharge is the charge itself, including the 3-D Secure authentication
+	// that completes a card charge.
+	StatusChangeSourceCharge
+	// StatusChangeSourceInternalMarkAsPaid is a payment that was completed through the
+	// mark as paid flow, either from the internal API or from the customer-facing pages.
+	StatusChangeSourceInternalMarkAsPaid
+	// StatusChangeSourceCancel is a cancellation, from either the merchant or the customer,
+	// including the customer rejecting a paylater charge.
+	StatusChangeSourceCancel
+	// StatusChangeSourceExpireTimer is the timer that expires unpaid transactions.
+	StatusChangeSourceExpireTimer
+	// StatusChangeSourceAPI is any other merchant API call, such as expire, capture and refund.
+	StatusChangeSourceAPI
+)
+
+func (s StatusChangeSource) String() string {
+	switch s {
+	case StatusChangeSourceCharge:
+		return "charge"
+	case StatusChangeSourceInternalMarkAsPaid:
+		return "internal_mark_as_paid"
+	case StatusChangeSourceCancel:
+		return "cancel"
+	case StatusChangeSourceExpireTimer:
+		return "expire_timer"
+	case StatusChangeSourceAPI:
+		return "api"
+	case StatusChangeSourceUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// StatusChangeActor tells who asked for the status change of a transaction.
+// The values are persisted, new actors must be appended at the end.
+type StatusChangeActor uint8
+
+const (
+	StatusChangeActorUnspecified StatusChangeActor = iota
+	// StatusChangeActorMerchant is the merchant, through the authenticated API.
+	StatusChangeActorMerchant
+	// StatusChangeActorCustomer is the customer, through the customer-facing pages or the
+	// internal API that simulates them.
+	StatusChangeActorCustomer
+	// StatusChangeActorSystem is this service on its own, such as the expire timer.
+	StatusChangeActorSystem
+)
+
+func (a StatusChangeActor) String() string {
+	switch a {
+	case StatusChangeActorMerchant:
+		return "merchant"
+	case StatusChangeActorCustomer:
+		return "customer"
+	case StatusChangeActorSystem:
+		return "system"
+	case StatusChangeActorUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// TransactionStatusChange is an entry of the status history of a transaction. The first
+// entry of every transaction moves from TransactionStatusUnspecified, as it records the
+// creation of the transaction.
+type TransactionStatusChange struct {
+	OrderId   string
+	From      TransactionStatus
+	To        TransactionStatus
+	Source    StatusChangeSource
+	Actor     StatusChangeActor
+	ChangedAt time.Time
+}
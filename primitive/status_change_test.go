@@ -0,0 +1,71 @@
+package primitive_test
+
+import (
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestStatusChangeSource_String(t *testing.T) {
+	t.Run("StatusChangeSourceCharge", func(t *testing.T) {
+		if primitive.StatusChangeSourceCharge.String() != "charge" {
+			t.Errorf("expecting StatusChangeSourceCharge.String() to be 'charge', instead got %s", primitive.StatusChangeSourceCharge.String())
+		}
+	})
+
+	t.Run("StatusChangeSourceInternalMarkAsPaid", func(t *testing.T) {
+		if primitive.StatusChangeSourceInternalMarkAsPaid.String() != "internal_mark_as_paid" {
+			t.Errorf("expecting StatusChangeSourceInternalMarkAsPaid.String() to be 'internal_mark_as_paid', instead got %s", primitive.StatusChangeSourceInternalMarkAsPaid.String())
+		}
+	})
+
+	t.Run("StatusChangeSourceCancel", func(t *testing.T) {
+		if primitive.StatusChangeSourceCancel.String() != "cancel" {
+			t.Errorf("expecting StatusChangeSourceCancel.String() to be 'cancel', instead got %s", primitive.StatusChangeSourceCancel.String())
+		}
+	})
+
+	t.Run("StatusChangeSourceExpireTimer", func(t *testing.T) {
+		if primitive.StatusChangeSourceExpireTimer.String() != "expire_timer" {
+			t.Errorf("expecting StatusChangeSourceExpireTimer.String() to be 'expire_timer', instead got %s", primitive.StatusChangeSourceExpireTimer.String())
+		}
+	})
+
+	t.Run("StatusChangeSourceAPI", func(t *testing.T) {
+		if primitive.StatusChangeSourceAPI.String() != "api" {
+			t.Errorf("expecting StatusChangeSourceAPI.String() to be 'api', instead got %s", primitive.StatusChangeSourceAPI.String())
+		}
+	})
+
+	t.Run("StatusChangeSourceUnspecified", func(t *testing.T) {
+		if primitive.StatusChangeSourceUnspecified.String() != "UNSPECIFIED" {
+			t.Errorf("expecting StatusChangeSourceUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.StatusChangeSourceUnspecified.String())
+		}
+	})
+}
+
+func TestStatusChangeActor_String(t *testing.T) {
+	t.Run("StatusChangeActorMerchant", func(t *testing.T) {
+		if primitive.StatusChangeActorMerchant.String() != "merchant" {
+			t.Errorf("expecting StatusChangeActorMerchant.String() to be 'merchant', instead got %s", primitive.StatusChangeActorMerchant.String())
+		}
+	})
+
+	t.Run("StatusChangeActorCustomer", func(t *testing.T) {
+		if primitive.StatusChangeActorCustomer.String() != "customer" {
+			t.Errorf("expecting StatusChangeActorCustomer.String() to be 'customer', instead got %s", primitive.StatusChangeActorCustomer.String())
+		}
+	})
+
+	t.Run("StatusChangeActorSystem", func(t *testing.T) {
+		if primitive.StatusChangeActorSystem.String() != "system" {
+			t.Errorf("expecting StatusChangeActorSystem.String() to be 'system', instead got %s", primitive.StatusChangeActorSystem.String())
+		}
+	})
+
+	t.Run("StatusChangeActorUnspecified", func(t *testing.T) {
+		if primitive.StatusChangeActorUnspecified.String() != "UNSPECIFIED" {
+			t.Errorf("expecting StatusChangeActorUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.StatusChangeActorUnspecified.String())
+		}
+	})
+}
@@ -2,6 +2,7 @@ package primitive
 
 type TransactionStatus uint8
 
+// The values are persisted, new statuses must be appended at the end.
 const (
 	// TransactionStatusUnspecified sets the zero value. If this is ever read, it means
 	// something is wrong with the code.
@@ -9,31 +10,118 @@ const (
 	// TransactionStatusPending states that transaction is in progress, and it's pending for verification
 	// and/or settlement from the bank.
 	TransactionStatusPending
-	// TransactionStatusDenied tells that the transaction has been denied by the payment provider.
-	TransactionStatusDenied
-	// TransactionStatusSettled tells that the transaction has been settled and successful. No further steps are needed.
-	TransactionStatusSettled
-	// TransactionStatusExpired tells that the transaction has exceeds the time limit that the user is allowed to pay.
-	TransactionStatusExpired
-	// TransactionStatusCanceled indicates that the transaction is canceled.
-	TransactionStatusCanceled
+	// TransactionStatusDeny tells that the transaction has been denied by the payment provider,
+	// the bank, or the fraud detection system.
+	TransactionStatusDeny
+	// TransactionStatusSettlement tells that the transaction has been settled and successful. No further steps are needed.
+	TransactionStatusSettlement
+	// TransactionStatusExpire tells that the transaction has exceeds the time limit that the user is allowed to pay.
+	TransactionStatusExpire
+	// TransactionStatusCancel indicates that the transaction is canceled.
+	TransactionStatusCancel
+	// TransactionStatusRefund tells that the whole amount of a settled transaction has been refunded.
+	TransactionStatusRefund
+	// TransactionStatusPartialRefund tells that some, but not all, of the amount of a settled
+	// transaction has been refunded.
+	TransactionStatusPartialRefund
+	// TransactionStatusCapture tells that a card transaction has been authenticated and
+	// successfully charged to the card.
+	TransactionStatusCapture
+	// TransactionStatusAuthorize tells that a card transaction has been authenticated, and the
+	// amount is reserved on the card until the merchant captures or cancels it.
+	TransactionStatusAuthorize
+	// TransactionStatusFailure tells that the transaction failed unexpectedly while it was
+	// being processed.
+	TransactionStatusFailure
+	// TransactionStatusChargeback tells that the customer disputed a settled transaction,
+	// and the amount has been returned to them by their bank.
+	TransactionStatusChargeback
 )
 
 func (t TransactionStatus) String() string {
 	switch t {
 	case TransactionStatusPending:
 		return "pending"
-	case TransactionStatusDenied:
-		return "denied"
-	case TransactionStatusSettled:
-		return "settled"
-	case TransactionStatusExpired:
-		return "expired"
-	case TransactionStatusCanceled:
-		return "canceled"
+	case TransactionStatusDeny:
+		return "deny"
+	case TransactionStatusSettlement:
+		return "settlement"
+	case TransactionStatusExpire:
+		return "expire"
+	case TransactionStatusCancel:
+		return "cancel"
+	case TransactionStatusRefund:
+		return "refund"
+	case TransactionStatusPartialRefund:
+		return "partial_refund"
+	case TransactionStatusCapture:
+		return "capture"
+	case TransactionStatusAuthorize:
+		return "authorize"
+	case TransactionStatusFailure:
+		return "failure"
+	case TransactionStatusChargeback:
+		return "chargeback"
 	case TransactionStatusUnspecified:
 		fallthrough
 	default:
 		return "UNSPECIFIED"
 	}
 }
+
+// transactionStatusTransitions lists the statuses that a transaction can move to from
+// each status, following the Midtrans transaction status flow. Statuses that are not
+// listed are final.
+var transactionStatusTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionStatusPending: {
+		TransactionStatusAuthorize,
+		TransactionStatusCapture,
+		TransactionStatusSettlement,
+		TransactionStatusDeny,
+		TransactionStatusCancel,
+		TransactionStatusExpire,
+		TransactionStatusFailure,
+	},
+	TransactionStatusAuthorize: {
+		TransactionStatusCapture,
+		TransactionStatusDeny,
+		TransactionStatusCancel,
+		TransactionStatusExpire,
+	},
+	TransactionStatusCapture: {
+		TransactionStatusSettlement,
+		// A challenged card transaction is denied once the merchant reviewed it
+		TransactionStatusDeny,
+		TransactionStatusCancel,
+		TransactionStatusRefund,
+		TransactionStatusPartialRefund,
+		TransactionStatusChargeback,
+	},
+	TransactionStatusSettlement: {
+		TransactionStatusRefund,
+		TransactionStatusPartialRefund,
+		TransactionStatusChargeback,
+	},
+	TransactionStatusPartialRefund: {
+		TransactionStatusPartialRefund,
+		TransactionStatusRefund,
+		TransactionStatusChargeback,
+	},
+}
+
+// CanTransitionTo checks whether a transaction with this status is allowed to move
+// to the next status.
+func (t TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, status := range transactionStatusTransitions[t] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Final returns true if the transaction can no longer move to any other status.
+func (t TransactionStatus) Final() bool {
+	return len(transactionStatusTransitions[t]) == 0
+}
@@ -13,21 +13,63 @@ func TestTransactionStatus_String(t *testing.T) {
 		}
 	})
 
-	t.Run("TransactionStatusDenied", func(t *testing.T) {
-		if primitive.TransactionStatusDenied.String() != "denied" {
-			t.Errorf("expecting TransactionStatusDenied.String() to be 'denied', instead got %s", primitive.TransactionStatusDenied.String())
+	t.Run("TransactionStatusDeny", func(t *testing.T) {
+		if primitive.TransactionStatusDeny.String() != "deny" {
+			t.Errorf("expecting TransactionStatusDeny.String() to be 'deny', instead got %s", primitive.TransactionStatusDeny.String())
 		}
 	})
 
-	t.Run("TransactionStatusSettled", func(t *testing.T) {
-		if primitive.TransactionStatusSettled.String() != "settled" {
-			t.Errorf("expecting TransactionStatusSettled.String() to be 'settled', instead got %s", primitive.TransactionStatusSettled.String())
+	t.Run("TransactionStatusSettlement", func(t *testing.T) {
+		if primitive.TransactionStatusSettlement.String() != "settlement" {
+			t.Errorf("expecting TransactionStatusSettlement.String() to be 'settlement', instead got %s", primitive.TransactionStatusSettlement.String())
 		}
 	})
 
-	t.Run("TransactionStatusExpired", func(t *testing.T) {
-		if primitive.TransactionStatusExpired.String() != "expired" {
-			t.Errorf("expecting TransactionStatusExpired.String() to be 'expired', instead got %s", primitive.TransactionStatusExpired.String())
+	t.Run("TransactionStatusExpire", func(t *testing.T) {
+		if primitive.TransactionStatusExpire.String() != "expire" {
+			t.Errorf("expecting TransactionStatusExpire.String() to be 'expire', instead got %s", primitive.TransactionStatusExpire.String())
+		}
+	})
+
+	t.Run("TransactionStatusRefund", func(t *testing.T) {
+		if primitive.TransactionStatusRefund.String() != "refund" {
+			t.Errorf("expecting TransactionStatusRefund.String() to be 'refund', instead got %s", primitive.TransactionStatusRefund.String())
+		}
+	})
+
+	t.Run("TransactionStatusPartialRefund", func(t *testing.T) {
+		if primitive.TransactionStatusPartialRefund.String() != "partial_refund" {
+			t.Errorf("expecting TransactionStatusPartialRefund.String() to be 'partial_refund', instead got %s", primitive.TransactionStatusPartialRefund.String())
+		}
+	})
+
+	t.Run("TransactionStatusCapture", func(t *testing.T) {
+		if primitive.TransactionStatusCapture.String() != "capture" {
+			t.Errorf("expecting TransactionStatusCapture.String() to be 'capture', instead got %s", primitive.TransactionStatusCapture.String())
+		}
+	})
+
+	t.Run("TransactionStatusAuthorize", func(t *testing.T) {
+		if primitive.TransactionStatusAuthorize.String() != "authorize" {
+			t.Errorf("expecting TransactionStatusAuthorize.String() to be 'authorize', instead got %s", primitive.TransactionStatusAuthorize.String())
+		}
+	})
+
+	t.Run("TransactionStatusCancel", func(t *testing.T) {
+		if primitive.TransactionStatusCancel.String() != "cancel" {
+			t.Errorf("expecting TransactionStatusCancel.String() to be 'cancel', instead got %s", primitive.TransactionStatusCancel.String())
+		}
+	})
+
+	t.Run("TransactionStatusFailure", func(t *testing.T) {
+		if primitive.TransactionStatusFailure.String() != "failure" {
+			t.Errorf("expecting TransactionStatusFailure.String() to be 'failure', instead got %s", primitive.TransactionStatusFailure.String())
+		}
+	})
+
+	t.Run("TransactionStatusChargeback", func(t *testing.T) {
+		if primitive.TransactionStatusChargeback.String() != "chargeback" {
+			t.Errorf("expecting TransactionStatusChargeback.String() to be 'chargeback', instead got %s", primitive.TransactionStatusChargeback.String())
 		}
 	})
 
@@ -37,3 +79,35 @@ func TestTransactionStatus_String(t *testing.T) {
 		}
 	})
 }
+
+func TestTransactionStatus_CanTransitionTo(t *testing.T) {
+	testCases := []struct {
+		from     primitive.TransactionStatus
+		to       primitive.TransactionStatus
+		expected bool
+	}{
+		{primitive.TransactionStatusPending, primitive.TransactionStatusSettlement, true},
+		{primitive.TransactionStatusPending, primitive.TransactionStatusCapture, true},
+		{primitive.TransactionStatusPending, primitive.TransactionStatusExpire, true},
+		{primitive.TransactionStatusPending, primitive.TransactionStatusRefund, false},
+		{primitive.TransactionStatusAuthorize, primitive.TransactionStatusCapture, true},
+		{primitive.TransactionStatusAuthorize, primitive.TransactionStatusSettlement, false},
+		{primitive.TransactionStatusCapture, primitive.TransactionStatusCancel, true},
+		{primitive.TransactionStatusCapture, primitive.TransactionStatusDeny, true},
+		{primitive.TransactionStatusSettlement, primitive.TransactionStatusDeny, false},
+		{primitive.TransactionStatusSettlement, primitive.TransactionStatusPartialRefund, true},
+		{primitive.TransactionStatusSettlement, primitive.TransactionStatusCancel, false},
+		{primitive.TransactionStatusPartialRefund, primitive.TransactionStatusPartialRefund, true},
+		{primitive.TransactionStatusRefund, primitive.TransactionStatusPartialRefund, false},
+		{primitive.TransactionStatusExpire, primitive.TransactionStatusSettlement, false},
+		{primitive.TransactionStatusCancel, primitive.TransactionStatusCancel, false},
+		{primitive.TransactionStatusUnspecified, primitive.TransactionStatusPending, false},
+	}
+
+	for _, testCase := range testCases {
+		got := testCase.from.CanTransitionTo(testCase.to)
+		if got != testCase.expected {
+			t.Errorf("expecting %s.CanTransitionTo(%s) to be %t, instead got %t", testCase.from, testCase.to, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package primitive
+
+// MaximumFreeTextLines is the most lines a merchant can put on either the inquiry or
+// the payment free text.
+const MaximumFreeTextLines = 10
+
+// MaximumFreeTextLength is the most characters a merchant can put on a single line of
+// free text, for each language.
+const MaximumFreeTextLength = 50
+
+// FreeTextLine is a single line of free text, in both Indonesian and English.
+type FreeTextLine struct {
+	Indonesian string
+	English    string
+}
+
+// FreeText is the merchant's message that the bank shows to the customer on a virtual
+// account payment.
+type FreeText struct {
+	// Inquiry is shown before the customer pays, after entering the virtual account number.
+	Inquiry []FreeTextLine
+	// Payment is shown on the receipt, after the customer pays.
+	Payment []FreeTextLine
+}
+
+// Empty returns true if there is no line of free text at all.
+func (f FreeText) Empty() bool {
+	return len(f.Inquiry) == 0 && len(f.Payment) == 0
+}
+
+// VirtualAccountDetail is what the merchant put on a virtual account charge to be shown to
+// the customer, other than the amount.
+type VirtualAccountDetail struct {
+	// RecipientName replaces the merchant name that the bank shows as the recipient.
+	RecipientName string
+	FreeText      FreeText
+}
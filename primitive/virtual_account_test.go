@@ -0,0 +1,33 @@
+package primitive_test
+
+import (
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestFreeText_Empty(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		if !(primitive.FreeText{}).Empty() {
+			t.Errorf("expecting free text to be empty")
+		}
+	})
+
+	t.Run("Inquiry", func(t *testing.T) {
+		freeText := primitive.FreeText{
+			Inquiry: []primitive.FreeTextLine{{Indonesian: "Bayar tagihan", English: "Pay the bill"}},
+		}
+		if freeText.Empty() {
+			t.Errorf("expecting free text to be not empty")
+		}
+	})
+
+	t.Run("Payment", func(t *testing.T) {
+		freeText := primitive.FreeText{
+			Payment: []primitive.FreeTextLine{{Indonesian: "Terima kasih", English: "Thank you"}},
+		}
+		if freeText.Empty() {
+			t.Errorf("expecting free text to be not empty")
+		}
+	})
+}
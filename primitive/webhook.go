@@ -0,0 +1,71 @@
+package primitive
+
+import "time"
+
+// WebhookStatus tells where a webhook notification is in its delivery.
+// The values are persisted, new statuses must be appended at the end.
+type WebhookStatus uint8
+
+const (
+	WebhookStatusUnspecified WebhookStatus = iota
+	// WebhookStatusPending is a notification that is waiting for its next delivery attempt.
+	WebhookStatusPending
+	// WebhookStatusDelivered is a notification that the merchant has accepted.
+	WebhookStatusDelivered
+	// WebhookStatusFailed is a notification that ran out of retries. It won't be sent again.
+	WebhookStatusFailed
+)
+
+func (w WebhookStatus) String() string {
+	switch w {
+	case WebhookStatusPending:
+		return "pending"
+	case WebhookStatusDelivered:
+		return "delivered"
+	case WebhookStatusFailed:
+		return "failed"
+	case WebhookStatusUnspecified:
+		fallthrough
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// WebhookNotification is a webhook payload that is stored in the outbox, so it survives
+// restarts until it is delivered to the merchant.
+type WebhookNotification struct {
+	Id      string
+	OrderId string
+	// TargetURL is empty if the notification goes to the default webhook target.
+	TargetURL string
+	Payload   []byte
+	Status    WebhookStatus
+	// Attempts counts every delivery attempt, including the first one.
+	Attempts      int
+	NextAttemptAt time.Time
+	// LastStatusCode is zero if the last attempt did not receive any response.
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookAttempt is a single delivery attempt of a webhook notification, kept so the
+// merchant can find out what was sent and why a delivery failed.
+type WebhookAttempt struct {
+	Id        string
+	WebhookId string
+	OrderId   string
+	// TargetURL is the URL the payload was actually sent to.
+	TargetURL string
+	Payload   []byte
+	// Status is the status of the notification right after this attempt.
+	Status WebhookStatus
+	// StatusCode is zero if the attempt did not receive any response.
+	StatusCode int
+	// ResponseBody is only the beginning of the response body.
+	ResponseBody string
+	Latency      time.Duration
+	Error        string
+	AttemptedAt  time.Time
+}
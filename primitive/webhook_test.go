@@ -0,0 +1,33 @@
+package primitive_test
+
+import (
+	"testing"
+
+	"mock-payment-provider/primitive"
+)
+
+func TestWebhookStatus_String(t *testing.T) {
+	t.Run("WebhookStatusPending", func(t *testing.T) {
+		if primitive.WebhookStatusPending.String() != "pending" {
+			t.Errorf("expecting WebhookStatusPending.String() to be 'pending', instead got %s", primitive.WebhookStatusPending.String())
+		}
+	})
+
+	t.Run("WebhookStatusDelivered", func(t *testing.T) {
+		if primitive.WebhookStatusDelivered.String() != "delivered" {
+			t.Errorf("expecting WebhookStatusDelivered.String() to be 'delivered', instead got %s", primitive.WebhookStatusDelivered.String())
+		}
+	})
+
+	t.Run("WebhookStatusFailed", func(t *testing.T) {
+		if primitive.WebhookStatusFailed.String() != "failed" {
+			t.Errorf("expecting WebhookStatusFailed.String() to be 'failed', instead got %s", primitive.WebhookStatusFailed.String())
+		}
+	})
+
+	t.Run("WebhookStatusUnspecified", func(t *testing.T) {
+		if primitive.WebhookStatusUnspecified.String() != "UNSPECIFIED" {
+			t.Errorf("expecting WebhookStatusUnspecified.String() to be 'UNSPECIFIED', instead got %s", primitive.WebhookStatusUnspecified.String())
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package card_token
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewCardTokenRepository(db *sql.DB) (*Repository, error) {
+	if db == nil {
+		return &Repository{}, fmt.Errorf("db is nil")
+	}
+
+	return &Repository{db: db}, nil
+}
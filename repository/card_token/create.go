@@ -0,0 +1,96 @@
+package card_token
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) Create(ctx context.Context, params repository.CreateCardTokenParam) (primitive.CardToken, error) {
+	first6, last4, ok := strings.Cut(params.MaskedCard, "-")
+	if !ok || len(first6) != 6 || len(last4) != 4 {
+		return primitive.CardToken{}, fmt.Errorf("invalid masked card")
+	}
+
+	token := primitive.CardToken{
+		TokenId:       params.MaskedCard + "-" + uuid.NewString(),
+		MaskedCard:    params.MaskedCard,
+		Outcome:       params.Outcome,
+		Saved:         params.Saved,
+		CardExpiresAt: params.CardExpiresAt,
+		ExpiresAt:     params.ExpiresAt,
+	}
+	if params.Saved {
+		token.TokenId = first6 + strings.ReplaceAll(uuid.NewString(), "-", "") + last4
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.CardToken{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return primitive.CardToken{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			card_tokens
+			(
+			 	token_id,
+			 	masked_card,
+			 	outcome,
+			 	saved,
+			 	card_expired_at,
+			 	expired_at,
+			 	created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?)`,
+		token.TokenId,
+		token.MaskedCard,
+		token.Outcome,
+		token.Saved,
+		token.CardExpiresAt,
+		token.ExpiresAt,
+		time.Now(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CardToken{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.CardToken{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CardToken{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.CardToken{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return token, nil
+}
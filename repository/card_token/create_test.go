@@ -0,0 +1,62 @@
+package card_token_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/card_token"
+)
+
+func TestRepository_Create(t *testing.T) {
+	cardTokenRepository, err := card_token.NewCardTokenRepository(db)
+	if err != nil {
+		t.Fatalf("creating card token repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Invalid MaskedCard", func(t *testing.T) {
+		_, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{MaskedCard: "4811111111111114"})
+		if err.Error() != "invalid masked card" {
+			t.Errorf("expecting an error of 'invalid masked card', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Regular Token", func(t *testing.T) {
+		token, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "481111-1114",
+			Outcome:       primitive.CardOutcomeAccept,
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if !strings.HasPrefix(token.TokenId, "481111-1114-") {
+			t.Errorf("expecting token id to start with 481111-1114-, instead got %s", token.TokenId)
+		}
+	})
+
+	t.Run("Saved Token", func(t *testing.T) {
+		token, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "481111-1114",
+			Outcome:       primitive.CardOutcomeAccept,
+			Saved:         true,
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().AddDate(1, 0, 0),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if !strings.HasPrefix(token.TokenId, "481111") || !strings.HasSuffix(token.TokenId, "1114") || strings.Contains(token.TokenId, "-") {
+			t.Errorf("expecting token id to be formatted as 481111<random>1114, instead got %s", token.TokenId)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package card_token
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) GetByTokenId(ctx context.Context, tokenId string) (primitive.CardToken, error) {
+	if tokenId == "" {
+		return primitive.CardToken{}, fmt.Errorf("tokenId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.CardToken{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return primitive.CardToken{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var token primitive.CardToken
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT
+			token_id,
+			masked_card,
+			outcome,
+			saved,
+			used,
+			card_expired_at,
+			expired_at
+		FROM
+			card_tokens
+		WHERE
+			token_id = ?`,
+		tokenId,
+	).Scan(
+		&token.TokenId,
+		&token.MaskedCard,
+		&token.Outcome,
+		&token.Saved,
+		&token.Used,
+		&token.CardExpiresAt,
+		&token.ExpiresAt,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CardToken{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return primitive.CardToken{}, repository.ErrNotFound
+		}
+
+		return primitive.CardToken{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CardToken{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.CardToken{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	if token.Expired() {
+		return primitive.CardToken{}, repository.ErrExpired
+	}
+
+	return token, nil
+}
@@ -0,0 +1,77 @@
+package card_token_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/card_token"
+)
+
+func TestRepository_GetByTokenId(t *testing.T) {
+	cardTokenRepository, err := card_token.NewCardTokenRepository(db)
+	if err != nil {
+		t.Fatalf("creating card token repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty TokenId", func(t *testing.T) {
+		_, err := cardTokenRepository.GetByTokenId(ctx, "")
+		if err.Error() != "tokenId is empty" {
+			t.Errorf("expecting an error of 'tokenId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := cardTokenRepository.GetByTokenId(ctx, "not-exists")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		created, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard: "481111-1114",
+			ExpiresAt:  time.Now().Add(-time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		_, err = cardTokenRepository.GetByTokenId(ctx, created.TokenId)
+		if !errors.Is(err, repository.ErrExpired) {
+			t.Errorf("expecting an error of repository.ErrExpired, instead got %v", err)
+		}
+	})
+
+	t.Run("Normal Integration", func(t *testing.T) {
+		created, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "451111-1117",
+			Outcome:       primitive.CardOutcomeChallenge,
+			Saved:         true,
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().AddDate(1, 0, 0),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		token, err := cardTokenRepository.GetByTokenId(ctx, created.TokenId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if token.Outcome != primitive.CardOutcomeChallenge {
+			t.Errorf("expecting outcome to be challenge, instead got %s", token.Outcome)
+		}
+
+		if !token.Saved {
+			t.Errorf("expecting saved to be true")
+		}
+	})
+}
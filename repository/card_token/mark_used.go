@@ -0,0 +1,79 @@
+package card_token
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) MarkUsed(ctx context.Context, tokenId string) error {
+	if tokenId == "" {
+		return fmt.Errorf("tokenId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	// Only an unused token is updated, so two charges can't both use the same token
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE card_tokens SET used = TRUE WHERE token_id = ? AND saved = FALSE AND used = FALSE`,
+		tokenId,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("acquiring affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return repository.ErrNotFound
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return nil
+}
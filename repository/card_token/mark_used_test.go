@@ -0,0 +1,82 @@
+package card_token_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/card_token"
+)
+
+func TestRepository_MarkUsed(t *testing.T) {
+	cardTokenRepository, err := card_token.NewCardTokenRepository(db)
+	if err != nil {
+		t.Fatalf("creating card token repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty TokenId", func(t *testing.T) {
+		err := cardTokenRepository.MarkUsed(ctx, "")
+		if err.Error() != "tokenId is empty" {
+			t.Errorf("expecting an error of 'tokenId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := cardTokenRepository.MarkUsed(ctx, "not-exists")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Saved Token", func(t *testing.T) {
+		created, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "481111-1114",
+			Saved:         true,
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().AddDate(1, 0, 0),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = cardTokenRepository.MarkUsed(ctx, created.TokenId)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Used Twice", func(t *testing.T) {
+		created, err := cardTokenRepository.Create(ctx, repository.CreateCardTokenParam{
+			MaskedCard:    "481111-1114",
+			CardExpiresAt: time.Now().AddDate(1, 0, 0),
+			ExpiresAt:     time.Now().Add(time.Minute * 10),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = cardTokenRepository.MarkUsed(ctx, created.TokenId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		token, err := cardTokenRepository.GetByTokenId(ctx, created.TokenId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if !token.Used {
+			t.Errorf("expecting used to be true")
+		}
+
+		err = cardTokenRepository.MarkUsed(ctx, created.TokenId)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package card_token
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+func (r *Repository) Migrate(ctx context.Context) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS card_tokens (
+			token_id TEXT PRIMARY KEY,
+			masked_card TEXT NOT NULL,
+			outcome INT NOT NULL,
+			saved BOOLEAN NOT NULL,
+			used BOOLEAN NOT NULL DEFAULT FALSE,
+			card_expired_at DATETIME NOT NULL,
+			expired_at DATETIME NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package card_token_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository/card_token"
+)
+
+func TestRepository_Migrate(t *testing.T) {
+	cardTokenRepository, err := card_token.NewCardTokenRepository(db)
+	if err != nil {
+		t.Fatalf("creating card token repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err = cardTokenRepository.Migrate(ctx)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
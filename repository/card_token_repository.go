@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"mock-payment-provider/primitive"
+)
+
+type CardTokenRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+
+	// Create saves a new card token and generates its token ID. Regular tokens are formatted
+	// like Midtrans' sandbox tokens, e.g. 481111-1114-<uuid>, while saved tokens have
+	// the random part in between the first 6 and the last 4 digits of the card number.
+	Create(ctx context.Context, params CreateCardTokenParam) (primitive.CardToken, error)
+
+	// GetByTokenId acquires the card token of the token ID, including a used one.
+	// It returns ErrNotFound if the token was not found.
+	// It returns ErrExpired if the token is expired.
+	GetByTokenId(ctx context.Context, tokenId string) (primitive.CardToken, error)
+
+	// MarkUsed uses up a regular token, so it can't be charged again.
+	// It returns ErrNotFound if the token was not found, was used before, or is a saved token.
+	MarkUsed(ctx context.Context, tokenId string) error
+}
+
+type CreateCardTokenParam struct {
+	MaskedCard    string
+	Outcome       primitive.CardOutcome
+	Saved         bool
+	CardExpiresAt time.Time
+	ExpiresAt     time.Time
+}
@@ -0,0 +1,84 @@
+package credit_card
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) ApproveChallenge(ctx context.Context, orderId string) error {
+	if orderId == "" {
+		return fmt.Errorf("orderId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	// The outcome is only changed while it is still challenged, so a challenge is never resolved twice
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE credit_card_charges SET outcome = ?, updated_at = ? WHERE order_id = ? AND outcome = ?`,
+		primitive.CardOutcomeAccept,
+		time.Now(),
+		orderId,
+		primitive.CardOutcomeChallenge,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("acquiring affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return repository.ErrNotFound
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return nil
+}
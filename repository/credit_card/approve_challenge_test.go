@@ -0,0 +1,76 @@
+package credit_card_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/credit_card"
+)
+
+func TestRepository_ApproveChallenge(t *testing.T) {
+	creditCardRepository, err := credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		t.Fatalf("creating credit card repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		err := creditCardRepository.ApproveChallenge(ctx, "")
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := creditCardRepository.ApproveChallenge(ctx, uuid.NewString())
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		orderId := uuid.NewString()
+		_, err := creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{
+			OrderId:         orderId,
+			TokenId:         "481111-1114-" + uuid.NewString(),
+			MaskedCard:      "481111-1114",
+			Bank:            "bni",
+			CardType:        "credit",
+			ApprovalCode:    "1234567890123",
+			Outcome:         primitive.CardOutcomeChallenge,
+			TransactionType: primitive.CardTransactionTypeAuthorizeCapture,
+			Amount:          50000,
+			ExpiresAt:       time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("creating charge: %s", err.Error())
+		}
+
+		err = creditCardRepository.ApproveChallenge(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		charge, err := creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring charge: %s", err.Error())
+		}
+
+		if charge.Outcome != primitive.CardOutcomeAccept {
+			t.Errorf("expecting outcome to be accept, instead got %v", charge.Outcome)
+		}
+
+		// The challenge is already resolved
+		err = creditCardRepository.ApproveChallenge(ctx, orderId)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+	})
+}
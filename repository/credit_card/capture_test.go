@@ -0,0 +1,157 @@
+package credit_card_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/credit_card"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_Capture(t *testing.T) {
+	creditCardRepository, err := credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		t.Fatalf("creating credit card repository: %s", err.Error())
+	}
+
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("creating transaction repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	createAuthorized := func(t *testing.T, status primitive.TransactionStatus) string {
+		orderId := uuid.NewString()
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     orderId,
+			Amount:      50000,
+			PaymentType: primitive.PaymentTypeCreditCard,
+			Status:      status,
+			ExpiredAt:   time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("creating transaction: %s", err.Error())
+		}
+
+		_, err = creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{
+			OrderId:         orderId,
+			TokenId:         "481111-1114-" + uuid.NewString(),
+			MaskedCard:      "481111-1114",
+			Bank:            "bni",
+			CardType:        "credit",
+			ApprovalCode:    "1234567890123",
+			TransactionType: primitive.CardTransactionTypeAuthorize,
+			Amount:          50000,
+			ExpiresAt:       time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("creating charge: %s", err.Error())
+		}
+
+		return orderId
+	}
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		err := creditCardRepository.Capture(ctx, "", 50000, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Zero Amount", func(t *testing.T) {
+		err := creditCardRepository.Capture(ctx, "a", 0, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if err.Error() != "amount must be positive" {
+			t.Errorf("expecting an error of 'amount must be positive', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := creditCardRepository.Capture(ctx, uuid.NewString(), 50000, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Invalid Transition", func(t *testing.T) {
+		orderId := createAuthorized(t, primitive.TransactionStatusCancel)
+
+		err := creditCardRepository.Capture(ctx, orderId, 30000, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		var invalidTransitionError *repository.InvalidTransitionError
+		if !errors.As(err, &invalidTransitionError) {
+			t.Fatalf("expecting an *InvalidTransitionError, instead got %v", err)
+		}
+
+		// Nothing is changed if the transaction can't be captured
+		charge, err := creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring charge: %s", err.Error())
+		}
+
+		if charge.CapturedAmount != 0 {
+			t.Errorf("expecting captured amount to stay 0, instead got %d", charge.CapturedAmount)
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		orderId := createAuthorized(t, primitive.TransactionStatusAuthorize)
+
+		err := creditCardRepository.Capture(ctx, orderId, 30000, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		charge, err := creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring charge: %s", err.Error())
+		}
+
+		if charge.CapturedAmount != 30000 {
+			t.Errorf("expecting captured amount to be 30000, instead got %d", charge.CapturedAmount)
+		}
+
+		if charge.TransactionType != primitive.CardTransactionTypeAuthorize {
+			t.Errorf("expecting transaction type to be authorize, instead got %s", charge.TransactionType)
+		}
+
+		entry, err := transactionRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring transaction: %s", err.Error())
+		}
+
+		if entry.TransactionStatus != primitive.TransactionStatusCapture {
+			t.Errorf("expecting status to be %s, instead got %s", primitive.TransactionStatusCapture, entry.TransactionStatus)
+		}
+
+		history, err := transactionRepository.GetStatusHistory(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring status history: %s", err.Error())
+		}
+
+		last := history[len(history)-1]
+		if last.From != primitive.TransactionStatusAuthorize || last.To != primitive.TransactionStatusCapture || last.Actor != primitive.StatusChangeActorMerchant {
+			t.Errorf("expecting the last status change to be a capture by the merchant, instead got %+v", last)
+		}
+
+		// The charge is already captured, the first amount stays
+		err = creditCardRepository.Capture(ctx, orderId, 50000, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+
+		charge, err = creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring charge: %s", err.Error())
+		}
+
+		if charge.CapturedAmount != 30000 {
+			t.Errorf("expecting captured amount to stay 30000, instead got %d", charge.CapturedAmount)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package credit_card
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) CreateCharge(ctx context.Context, params repository.CreateCreditCardChargeParam) (primitive.CreditCardCharge, error) {
+	if params.OrderId == "" {
+		return primitive.CreditCardCharge{}, fmt.Errorf("orderId is empty")
+	}
+
+	if params.TokenId == "" {
+		return primitive.CreditCardCharge{}, fmt.Errorf("tokenId is empty")
+	}
+
+	charge := primitive.CreditCardCharge{
+		Id:                    uuid.NewString(),
+		OrderId:               params.OrderId,
+		TokenId:               params.TokenId,
+		MaskedCard:            params.MaskedCard,
+		Bank:                  params.Bank,
+		CardType:              params.CardType,
+		ApprovalCode:          params.ApprovalCode,
+		Outcome:               params.Outcome,
+		Amount:                params.Amount,
+		ExpiresAt:             params.ExpiresAt,
+		SavedTokenId:          params.SavedTokenId,
+		SavedTokenIdExpiresAt: params.SavedTokenIdExpiresAt,
+		TransactionType:       params.TransactionType,
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.CreditCardCharge{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return primitive.CreditCardCharge{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			credit_card_charges
+			(
+			 	order_id,
+			 	id,
+			 	token_id,
+			 	masked_card,
+			 	bank,
+			 	card_type,
+			 	approval_code,
+			 	outcome,
+			 	saved_token_id,
+			 	saved_token_id_expired_at,
+			 	transaction_type,
+			 	amount,
+			 	captured_amount,
+			 	expired_at,
+			 	created_at,
+			 	updated_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		charge.OrderId,
+		charge.Id,
+		charge.TokenId,
+		charge.MaskedCard,
+		charge.Bank,
+		charge.CardType,
+		charge.ApprovalCode,
+		charge.Outcome,
+		charge.SavedTokenId,
+		charge.SavedTokenIdExpiresAt,
+		charge.TransactionType,
+		charge.Amount,
+		charge.CapturedAmount,
+		charge.ExpiresAt,
+		time.Now(),
+		time.Now(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CreditCardCharge{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.CreditCardCharge{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CreditCardCharge{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.CreditCardCharge{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return charge, nil
+}
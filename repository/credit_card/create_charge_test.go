@@ -0,0 +1,59 @@
+package credit_card_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/credit_card"
+)
+
+func TestRepository_CreateCharge(t *testing.T) {
+	creditCardRepository, err := credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		t.Fatalf("creating credit card repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{TokenId: "a"})
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Empty TokenId", func(t *testing.T) {
+		_, err := creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{OrderId: "a"})
+		if err.Error() != "tokenId is empty" {
+			t.Errorf("expecting an error of 'tokenId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		charge, err := creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{
+			OrderId:      uuid.NewString(),
+			TokenId:      "481111-1114-" + uuid.NewString(),
+			MaskedCard:   "481111-1114",
+			Bank:         "bni",
+			CardType:     "credit",
+			ApprovalCode: "1234567890123",
+			Amount:       50000,
+			ExpiresAt:    time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if charge.Id == "" {
+			t.Errorf("expecting id to be not empty")
+		}
+
+		if charge.MaskedCard != "481111-1114" {
+			t.Errorf("expecting masked card to be 481111-1114, instead got %s", charge.MaskedCard)
+		}
+	})
+}
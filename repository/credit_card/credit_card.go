@@ -0,0 +1,18 @@
+package credit_card
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewCreditCardRepository(db *sql.DB) (*Repository, error) {
+	if db == nil {
+		return &Repository{}, fmt.Errorf("db is nil")
+	}
+
+	return &Repository{db: db}, nil
+}
@@ -0,0 +1,79 @@
+package credit_card_test
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"mock-payment-provider/repository/credit_card"
+	"mock-payment-provider/repository/transaction"
+)
+
+var db *sql.DB
+
+func TestMain(m *testing.M) {
+	var err error = nil
+	db, err = sql.Open("sqlite3", ":memory:?_txlock=exclusive&_foreign_keys=1&")
+	if err != nil {
+		log.Fatalf("Opening sql database: %s", err.Error())
+	}
+
+	db.SetMaxOpenConns(1)
+
+	setupCtx, setupCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer setupCancel()
+
+	creditCardRepository, err := credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		log.Fatalf("Creating credit card repository: %s", err.Error())
+	}
+
+	err = creditCardRepository.Migrate(setupCtx)
+	if err != nil {
+		log.Fatalf("migrating database: %s", err.Error())
+	}
+
+	// DenyChallenge changes the status of the transaction as well
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		log.Fatalf("Creating transaction repository: %s", err.Error())
+	}
+
+	err = transactionRepository.Migrate(setupCtx)
+	if err != nil {
+		log.Fatalf("migrating database: %s", err.Error())
+	}
+
+	exitCode := m.Run()
+
+	err = db.Close()
+	if err != nil {
+		log.Printf("Closing database: %s", err.Error())
+	}
+
+	os.Exit(exitCode)
+}
+
+func TestNewCreditCardRepository(t *testing.T) {
+	t.Run("Nil Database", func(t *testing.T) {
+		_, err := credit_card.NewCreditCardRepository(nil)
+		if err.Error() != "db is nil" {
+			t.Errorf("expecting an error of 'db is nil', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		repository, err := credit_card.NewCreditCardRepository(&sql.DB{})
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if repository == nil {
+			t.Errorf("nil repository")
+		}
+	})
+}
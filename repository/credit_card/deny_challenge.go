@@ -0,0 +1,155 @@
+package credit_card
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) DenyChallenge(ctx context.Context, orderId string, source primitive.StatusChangeSource, actor primitive.StatusChangeActor) error {
+	if orderId == "" {
+		return fmt.Errorf("orderId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	// The outcome and the transaction status must change together, otherwise a concurrent
+	// approval could leave a denied transaction with an accepted fraud status
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE credit_card_charges SET outcome = ?, updated_at = ? WHERE order_id = ? AND outcome = ?`,
+		primitive.CardOutcomeDenyByFraud,
+		time.Now(),
+		orderId,
+		primitive.CardOutcomeChallenge,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("acquiring affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return repository.ErrNotFound
+	}
+
+	var currentStatus primitive.TransactionStatus
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT status FROM transaction_log WHERE order_id = ?`,
+		orderId,
+	).Scan(&currentStatus)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrNotFound
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	if !currentStatus.CanTransitionTo(primitive.TransactionStatusDeny) {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return &repository.InvalidTransitionError{From: currentStatus, To: primitive.TransactionStatusDeny}
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE transaction_log SET status = ?, updated_at = ? WHERE order_id = ?`,
+		primitive.TransactionStatusDeny,
+		time.Now(),
+		orderId,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			transaction_status_history
+			(
+				 order_id,
+				 from_status,
+				 to_status,
+				 source,
+				 actor,
+				 created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?)`,
+		orderId,
+		currentStatus,
+		primitive.TransactionStatusDeny,
+		source,
+		actor,
+		time.Now(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package credit_card_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/credit_card"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_DenyChallenge(t *testing.T) {
+	creditCardRepository, err := credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		t.Fatalf("creating credit card repository: %s", err.Error())
+	}
+
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("creating transaction repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	createChallenged := func(t *testing.T, status primitive.TransactionStatus) string {
+		orderId := uuid.NewString()
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     orderId,
+			Amount:      50000,
+			PaymentType: primitive.PaymentTypeCreditCard,
+			Status:      status,
+			ExpiredAt:   time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("creating transaction: %s", err.Error())
+		}
+
+		_, err = creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{
+			OrderId:         orderId,
+			TokenId:         "481111-1114-" + uuid.NewString(),
+			MaskedCard:      "481111-1114",
+			Bank:            "bni",
+			CardType:        "credit",
+			ApprovalCode:    "1234567890123",
+			Outcome:         primitive.CardOutcomeChallenge,
+			TransactionType: primitive.CardTransactionTypeAuthorizeCapture,
+			Amount:          50000,
+			ExpiresAt:       time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("creating charge: %s", err.Error())
+		}
+
+		return orderId
+	}
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		err := creditCardRepository.DenyChallenge(ctx, "", primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := creditCardRepository.DenyChallenge(ctx, uuid.NewString(), primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Invalid Transition", func(t *testing.T) {
+		orderId := createChallenged(t, primitive.TransactionStatusSettlement)
+
+		err := creditCardRepository.DenyChallenge(ctx, orderId, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		var invalidTransitionError *repository.InvalidTransitionError
+		if !errors.As(err, &invalidTransitionError) {
+			t.Fatalf("expecting an *InvalidTransitionError, instead got %v", err)
+		}
+
+		// Nothing is changed if the transaction can't be denied
+		charge, err := creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring charge: %s", err.Error())
+		}
+
+		if charge.Outcome != primitive.CardOutcomeChallenge {
+			t.Errorf("expecting outcome to stay challenge, instead got %v", charge.Outcome)
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		orderId := createChallenged(t, primitive.TransactionStatusCapture)
+
+		err := creditCardRepository.DenyChallenge(ctx, orderId, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		charge, err := creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring charge: %s", err.Error())
+		}
+
+		if charge.Outcome != primitive.CardOutcomeDenyByFraud {
+			t.Errorf("expecting outcome to be deny by fraud, instead got %v", charge.Outcome)
+		}
+
+		entry, err := transactionRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring transaction: %s", err.Error())
+		}
+
+		if entry.TransactionStatus != primitive.TransactionStatusDeny {
+			t.Errorf("expecting status to be %s, instead got %s", primitive.TransactionStatusDeny, entry.TransactionStatus)
+		}
+
+		history, err := transactionRepository.GetStatusHistory(ctx, orderId)
+		if err != nil {
+			t.Fatalf("acquiring status history: %s", err.Error())
+		}
+
+		last := history[len(history)-1]
+		if last.From != primitive.TransactionStatusCapture || last.To != primitive.TransactionStatusDeny || last.Actor != primitive.StatusChangeActorMerchant {
+			t.Errorf("expecting the last status change to be a deny by the merchant, instead got %+v", last)
+		}
+
+		// The challenge is already resolved
+		err = creditCardRepository.DenyChallenge(ctx, orderId, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+	})
+}
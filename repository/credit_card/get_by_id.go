@@ -0,0 +1,100 @@
+package credit_card
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) GetByID(ctx context.Context, id string) (primitive.CreditCardCharge, error) {
+	if id == "" {
+		return primitive.CreditCardCharge{}, fmt.Errorf("id is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.CreditCardCharge{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return primitive.CreditCardCharge{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var charge primitive.CreditCardCharge
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT
+			order_id,
+			id,
+			token_id,
+			masked_card,
+			bank,
+			card_type,
+			approval_code,
+			outcome,
+			saved_token_id,
+			saved_token_id_expired_at,
+			transaction_type,
+			amount,
+			captured_amount,
+			expired_at
+		FROM
+			credit_card_charges
+		WHERE
+			id = ?`,
+		id,
+	).Scan(
+		&charge.OrderId,
+		&charge.Id,
+		&charge.TokenId,
+		&charge.MaskedCard,
+		&charge.Bank,
+		&charge.CardType,
+		&charge.ApprovalCode,
+		&charge.Outcome,
+		&charge.SavedTokenId,
+		&charge.SavedTokenIdExpiresAt,
+		&charge.TransactionType,
+		&charge.Amount,
+		&charge.CapturedAmount,
+		&charge.ExpiresAt,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CreditCardCharge{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return primitive.CreditCardCharge{}, repository.ErrNotFound
+		}
+
+		return primitive.CreditCardCharge{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.CreditCardCharge{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.CreditCardCharge{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return charge, nil
+}
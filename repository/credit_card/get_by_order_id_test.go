@@ -0,0 +1,70 @@
+package credit_card_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/credit_card"
+)
+
+func TestRepository_GetByOrderId(t *testing.T) {
+	creditCardRepository, err := credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		t.Fatalf("creating credit card repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := creditCardRepository.GetByOrderId(ctx, "")
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := creditCardRepository.GetByOrderId(ctx, "not-exists")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Normal Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+		created, err := creditCardRepository.CreateCharge(ctx, repository.CreateCreditCardChargeParam{
+			OrderId:      orderId,
+			TokenId:      "481111-1114-" + uuid.NewString(),
+			MaskedCard:   "481111-1114",
+			Bank:         "bni",
+			CardType:     "credit",
+			ApprovalCode: "1234567890123",
+			Amount:       50000,
+			ExpiresAt:    time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		charge, err := creditCardRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if charge.Id != created.Id {
+			t.Errorf("expecting id to be %s, instead got %s", created.Id, charge.Id)
+		}
+
+		if charge.ApprovalCode != "1234567890123" {
+			t.Errorf("expecting approval code to be 1234567890123, instead got %s", charge.ApprovalCode)
+		}
+
+		if charge.Amount != 50000 {
+			t.Errorf("expecting amount to be 50000, instead got %d", charge.Amount)
+		}
+	})
+}
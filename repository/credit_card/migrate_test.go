@@ -0,0 +1,24 @@
+package credit_card_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository/credit_card"
+)
+
+func TestRepository_Migrate(t *testing.T) {
+	creditCardRepository, err := credit_card.NewCreditCardRepository(db)
+	if err != nil {
+		t.Fatalf("creating credit card repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err = creditCardRepository.Migrate(ctx)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
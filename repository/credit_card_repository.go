@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"mock-payment-provider/primitive"
+)
+
+type CreditCardRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+
+	// CreateCharge saves the card details of a charge request and create a new unique ID.
+	// This unique ID will be used for authenticating the charge through 3-D Secure.
+	CreateCharge(ctx context.Context, params CreateCreditCardChargeParam) (primitive.CreditCardCharge, error)
+
+	// GetByID acquires the card charge of the specified ID.
+	// It returns ErrNotFound if the entry was not found.
+	GetByID(ctx context.Context, id string) (primitive.CreditCardCharge, error)
+
+	// GetByOrderId acquires the card charge of the order ID.
+	// It returns ErrNotFound if the entry was not found.
+	GetByOrderId(ctx context.Context, orderId string) (primitive.CreditCardCharge, error)
+
+	// Capture records the amount that was captured from an authorized card charge, and
+	// moves its transaction to the capture status along with the status history in the
+	// same transaction. It returns ErrNotFound if the entry was not found or was captured
+	// before, and an *InvalidTransitionError if the transaction can't be captured.
+	Capture(ctx context.Context, orderId string, amount int64, source primitive.StatusChangeSource, actor primitive.StatusChangeActor) error
+
+	// ApproveChallenge accepts a card charge that was challenged by the fraud detection system.
+	// It returns ErrNotFound if the entry was not found or is not challenged anymore.
+	ApproveChallenge(ctx context.Context, orderId string) error
+
+	// DenyChallenge denies a card charge that was challenged by the fraud detection system,
+	// and moves its transaction to the deny status along with the status history in the
+	// same transaction. It returns ErrNotFound if the entry was not found or is not
+	// challenged anymore, and an *InvalidTransitionError if the transaction can't be denied.
+	DenyChallenge(ctx context.Context, orderId string, source primitive.StatusChangeSource, actor primitive.StatusChangeActor) error
+}
+
+type CreateCreditCardChargeParam struct {
+	OrderId      string
+	TokenId      string
+	MaskedCard   string
+	Bank         string
+	CardType     string
+	ApprovalCode string
+	Outcome      primitive.CardOutcome
+	// SavedTokenId and SavedTokenIdExpiresAt are only filled if the merchant asked
+	// for the card to be saved.
+	SavedTokenId          string
+	SavedTokenIdExpiresAt time.Time
+	TransactionType       primitive.CardTransactionType
+	Amount                int64
+	ExpiresAt             time.Time
+}
@@ -0,0 +1,109 @@
+package cstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func (r *Repository) CreateCharge(ctx context.Context, orderId string, amount int64, expiresAt time.Time) (paymentCode string, err error) {
+	if orderId == "" {
+		return "", fmt.Errorf("orderId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return "", fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating transaction: %w", err)
+	}
+
+	// Find a payment code that has never been given out before
+	for attempt := 0; attempt < maximumGenerateAttempts; attempt++ {
+		candidate := generatePaymentCode()
+
+		var existing int64
+		err = tx.QueryRowContext(
+			ctx,
+			`SELECT COUNT(*) FROM cstore_entries WHERE payment_code = ?`,
+			candidate,
+		).Scan(&existing)
+		if err != nil {
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return "", fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return "", fmt.Errorf("executing query: %w", err)
+		}
+
+		if existing == 0 {
+			paymentCode = candidate
+			break
+		}
+	}
+
+	if paymentCode == "" {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return "", fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return "", fmt.Errorf("generating unique payment code after %d attempts", maximumGenerateAttempts)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			cstore_entries
+			(
+			 	order_id,
+			 	payment_code,
+			 	amount,
+			 	expired_at,
+			 	created_at,
+			 	updated_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?)`,
+		orderId,
+		paymentCode,
+		amount,
+		expiresAt.UTC(),
+		time.Now(),
+		time.Now(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return "", fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return "", fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return "", fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return "", fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return paymentCode, nil
+}
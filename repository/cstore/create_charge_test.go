@@ -0,0 +1,56 @@
+package cstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository/cstore"
+)
+
+func TestRepository_CreateCharge(t *testing.T) {
+	cstoreRepository, err := cstore.NewCStoreRepository(db)
+	if err != nil {
+		t.Fatalf("creating cstore repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := cstoreRepository.CreateCharge(ctx, "", 12345, time.Now().Add(time.Minute))
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		orderId := uuid.NewString()
+
+		paymentCode, err := cstoreRepository.CreateCharge(ctx, orderId, 12345, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if len(paymentCode) != 14 {
+			t.Errorf("expecting payment code to be 14 digits long, instead got %s", paymentCode)
+		}
+	})
+
+	t.Run("Unique Payment Code", func(t *testing.T) {
+		paymentCodes := make(map[string]struct{})
+		for i := 0; i < 20; i++ {
+			paymentCode, err := cstoreRepository.CreateCharge(ctx, uuid.NewString(), 12345, time.Now().Add(time.Minute))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if _, ok := paymentCodes[paymentCode]; ok {
+				t.Errorf("payment code %s was given out twice", paymentCode)
+			}
+
+			paymentCodes[paymentCode] = struct{}{}
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package cstore
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewCStoreRepository(db *sql.DB) (*Repository, error) {
+	if db == nil {
+		return &Repository{}, fmt.Errorf("db is nil")
+	}
+	return &Repository{db: db}, nil
+}
@@ -0,0 +1,56 @@
+package cstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository/cstore"
+)
+
+func TestRepository_DeductCharge(t *testing.T) {
+	cstoreRepository, err := cstore.NewCStoreRepository(db)
+	if err != nil {
+		t.Fatalf("creating cstore repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		err := cstoreRepository.DeductCharge(ctx, "")
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		err := cstoreRepository.DeductCharge(ctx, "any")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("Normal Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+		_, err := cstoreRepository.CreateCharge(ctx, orderId, 50000, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		err = cstoreRepository.DeductCharge(ctx, orderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		entry, err := cstoreRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if !entry.Expired() {
+			t.Errorf("expecting entry to be expired after deducting the charge")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package cstore
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maximumGenerateAttempts limits how many times a colliding payment code is regenerated.
+const maximumGenerateAttempts = 10
+
+func generatePaymentCode() string {
+	// Payment code is a set number with length of 14. The first 6 digits are the current
+	// date, so a code is very unlikely to be reused within the lifetime of a charge.
+	var builder strings.Builder
+
+	builder.WriteString(time.Now().Format("060102"))
+	for i := 0; i < 8; i++ {
+		builder.WriteString(strconv.Itoa(rand.Intn(10)))
+	}
+
+	return builder.String()
+}
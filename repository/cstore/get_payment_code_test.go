@@ -0,0 +1,65 @@
+package cstore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/cstore"
+)
+
+func TestRepository_GetByPaymentCode(t *testing.T) {
+	cstoreRepository, err := cstore.NewCStoreRepository(db)
+	if err != nil {
+		t.Fatalf("creating cstore repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty PaymentCode", func(t *testing.T) {
+		_, err := cstoreRepository.GetByPaymentCode(ctx, "")
+		if err.Error() != "paymentCode is empty" {
+			t.Errorf("expecting an error of 'paymentCode is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := cstoreRepository.GetByPaymentCode(ctx, "not-exists")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Normal Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+		paymentCode, err := cstoreRepository.CreateCharge(ctx, orderId, 50000, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		entry, err := cstoreRepository.GetByPaymentCode(ctx, paymentCode)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if entry.OrderId != orderId {
+			t.Errorf("expecting orderId to be %s, instead got %s", orderId, entry.OrderId)
+		}
+
+		if entry.PaymentCode != paymentCode {
+			t.Errorf("expecting payment code to be %s, instead got %s", paymentCode, entry.PaymentCode)
+		}
+
+		if entry.ChargedAmount != 50000 {
+			t.Errorf("expecting charged amount to be 50000, instead got %d", entry.ChargedAmount)
+		}
+
+		if entry.Expired() {
+			t.Errorf("expecting entry to not be expired")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package cstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository/cstore"
+)
+
+func TestRepository_Migrate(t *testing.T) {
+	cstoreRepository, err := cstore.NewCStoreRepository(db)
+	if err != nil {
+		t.Fatalf("creating cstore repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err = cstoreRepository.Migrate(ctx)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
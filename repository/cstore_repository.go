@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type CStoreRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+
+	// CreateCharge saves the charge request and creates a new unique payment code, which
+	// the customer shows to the cashier of the convenience store to pay for the order.
+	CreateCharge(ctx context.Context, orderId string, amount int64, expiresAt time.Time) (paymentCode string, err error)
+
+	// GetByPaymentCode acquires the current entry of the payment code.
+	// It returns ErrNotFound if the entry was not found.
+	GetByPaymentCode(ctx context.Context, paymentCode string) (Entry, error)
+
+	// GetByOrderId acquires the current entry of the order ID.
+	// It returns ErrNotFound if the entry was not found.
+	GetByOrderId(ctx context.Context, orderId string) (Entry, error)
+
+	// CancelCharge cancels the charge of the order ID by expiring its entry right away,
+	// so the customer can no longer pay for it.
+	CancelCharge(ctx context.Context, orderId string) error
+
+	// DeductCharge marks the charge of the order ID as paid. A payment code can only be
+	// paid once, so its entry is expired right away.
+	DeductCharge(ctx context.Context, orderId string) error
+}
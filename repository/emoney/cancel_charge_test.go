@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"mock-payment-provider/repository/emoney"
 )
 
@@ -17,8 +18,39 @@ func TestRepository_CancelCharge(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	err = emoneyRepository.CancelCharge(ctx, "any")
-	if err != nil {
-		t.Errorf("unexpected error: %s", err.Error())
-	}
+	t.Run("Empty OrderId", func(t *testing.T) {
+		err := emoneyRepository.CancelCharge(ctx, "")
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		err := emoneyRepository.CancelCharge(ctx, "any")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("Normal Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+		_, err := emoneyRepository.CreateCharge(ctx, orderId, 50000, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		err = emoneyRepository.CancelCharge(ctx, orderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		entry, err := emoneyRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if !entry.Expired() {
+			t.Errorf("expecting entry to be expired after canceling the charge")
+		}
+	})
 }
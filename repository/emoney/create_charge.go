@@ -51,7 +51,7 @@ func (r *Repository) CreateCharge(ctx context.Context, orderId string, amount in
 		orderId,
 		id,
 		amount,
-		expiresAt,
+		expiresAt.UTC(),
 		time.Now(),
 		time.Now(),
 	)
@@ -23,7 +23,8 @@ type EMoneyRepository interface {
 	// It returns ErrExpired if the ID is expired
 	GetByOrderId(ctx context.Context, orderId string) (Entry, error)
 
-	// CancelCharge cancels a charge for the specified ID.
+	// CancelCharge cancels the charge of the order ID by expiring its entry right away,
+	// so the customer can no longer pay for it.
 	CancelCharge(ctx context.Context, orderId string) error
 
 	// DeductCharge will free the id of any charge and mark is as paid
@@ -5,6 +5,7 @@ import "time"
 type Entry struct {
 	VirtualAccountNumber string
 	EMoneyID             string
+	PaymentCode          string
 	OrderId              string
 	ChargedAmount        int64
 	ExpiresAt            time.Time
@@ -1,7 +1,24 @@
 package repository
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"mock-payment-provider/primitive"
+)
 
 var ErrDuplicate = errors.New("duplicate")
 var ErrNotFound = errors.New("not found")
 var ErrExpired = errors.New("expired")
+var ErrAmountExceeded = errors.New("amount exceeded")
+
+// InvalidTransitionError is returned when a transaction is asked to move to a status
+// that is not allowed from its current status.
+type InvalidTransitionError struct {
+	From primitive.TransactionStatus
+	To   primitive.TransactionStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("invalid transaction status transition from %s to %s", e.From.String(), e.To.String())
+}
@@ -0,0 +1,64 @@
+package outcome_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/outcome"
+)
+
+func TestRepository_Delete(t *testing.T) {
+	outcomeRepository, err := outcome.NewOutcomeRepository(db)
+	if err != nil {
+		t.Fatalf("creating outcome repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Order ID", func(t *testing.T) {
+		err := outcomeRepository.Delete(ctx, "")
+		if err == nil {
+			t.Fatalf("expecting an error, got nil")
+		}
+
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting error to be 'orderId is empty', instead got '%s'", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := outcomeRepository.Delete(ctx, uuid.NewString())
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting error to be ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		orderId := uuid.NewString()
+		err := outcomeRepository.Schedule(ctx, primitive.ScheduledOutcome{
+			OrderId: orderId,
+			Outcome: primitive.TransactionStatusFailure,
+			DueAt:   time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = outcomeRepository.Delete(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		// The outcome can only be applied once
+		err = outcomeRepository.Delete(ctx, orderId)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting error to be ErrNotFound, instead got %v", err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package outcome
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) ListDue(ctx context.Context) ([]primitive.ScheduledOutcome, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+			order_id,
+			outcome,
+			due_at
+		FROM
+			scheduled_outcomes
+		WHERE
+			due_at <= ?
+		ORDER BY
+			due_at ASC`,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	outcomes := []primitive.ScheduledOutcome{}
+	for rows.Next() {
+		var outcome primitive.ScheduledOutcome
+		err := rows.Scan(
+			&outcome.OrderId,
+			&outcome.Outcome,
+			&outcome.DueAt,
+		)
+		if err != nil {
+			_ = rows.Close()
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		_ = rows.Close()
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("closing rows: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return outcomes, nil
+}
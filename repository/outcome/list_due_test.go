@@ -0,0 +1,69 @@
+package outcome_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/outcome"
+)
+
+func TestRepository_ListDue(t *testing.T) {
+	outcomeRepository, err := outcome.NewOutcomeRepository(db)
+	if err != nil {
+		t.Fatalf("creating outcome repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	due := primitive.ScheduledOutcome{
+		OrderId: uuid.NewString(),
+		Outcome: primitive.TransactionStatusExpire,
+		DueAt:   time.Now().Add(-time.Second),
+	}
+	err = outcomeRepository.Schedule(ctx, due)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	notDue := primitive.ScheduledOutcome{
+		OrderId: uuid.NewString(),
+		Outcome: primitive.TransactionStatusSettlement,
+		DueAt:   time.Now().Add(time.Hour),
+	}
+	err = outcomeRepository.Schedule(ctx, notDue)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	outcomes, err := outcomeRepository.ListDue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var found bool
+	for _, scheduledOutcome := range outcomes {
+		if scheduledOutcome.OrderId == notDue.OrderId {
+			t.Errorf("expecting %s not to be due yet", notDue.OrderId)
+		}
+
+		if scheduledOutcome.OrderId == due.OrderId {
+			found = true
+
+			if scheduledOutcome.Outcome != due.Outcome {
+				t.Errorf("expecting outcome to be %s, instead got %s", due.Outcome, scheduledOutcome.Outcome)
+			}
+
+			if !scheduledOutcome.DueAt.Equal(due.DueAt) {
+				t.Errorf("expecting due at to be %s, instead got %s", due.DueAt, scheduledOutcome.DueAt)
+			}
+		}
+	}
+
+	if !found {
+		t.Errorf("expecting %s to be due", due.OrderId)
+	}
+}
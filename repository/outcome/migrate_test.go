@@ -0,0 +1,24 @@
+package outcome_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository/outcome"
+)
+
+func TestRepository_Migrate(t *testing.T) {
+	outcomeRepository, err := outcome.NewOutcomeRepository(db)
+	if err != nil {
+		t.Fatalf("creating outcome repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err = outcomeRepository.Migrate(ctx)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
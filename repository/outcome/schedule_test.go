@@ -0,0 +1,52 @@
+package outcome_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/outcome"
+)
+
+func TestRepository_Schedule(t *testing.T) {
+	outcomeRepository, err := outcome.NewOutcomeRepository(db)
+	if err != nil {
+		t.Fatalf("creating outcome repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Order ID", func(t *testing.T) {
+		err := outcomeRepository.Schedule(ctx, primitive.ScheduledOutcome{Outcome: primitive.TransactionStatusSettlement})
+		if err == nil {
+			t.Fatalf("expecting an error, got nil")
+		}
+
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting error to be 'orderId is empty', instead got '%s'", err.Error())
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		scheduledOutcome := primitive.ScheduledOutcome{
+			OrderId: uuid.NewString(),
+			Outcome: primitive.TransactionStatusDeny,
+			DueAt:   time.Now().Add(time.Hour),
+		}
+
+		err := outcomeRepository.Schedule(ctx, scheduledOutcome)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = outcomeRepository.Schedule(ctx, scheduledOutcome)
+		if !errors.Is(err, repository.ErrDuplicate) {
+			t.Errorf("expecting error to be ErrDuplicate, instead got %v", err)
+		}
+	})
+}
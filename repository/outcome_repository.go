@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"mock-payment-provider/primitive"
+)
+
+type OutcomeRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+
+	// Schedule stores the outcome of a charge. It returns ErrDuplicate if the charge
+	// already has one.
+	Schedule(ctx context.Context, outcome primitive.ScheduledOutcome) error
+
+	// ListDue returns the outcomes that are due by now, from the earliest one.
+	ListDue(ctx context.Context) ([]primitive.ScheduledOutcome, error)
+
+	// Delete removes the outcome of a charge once it is applied. It returns ErrNotFound if
+	// the charge has none.
+	Delete(ctx context.Context, orderId string) error
+}
@@ -0,0 +1,199 @@
+package qris
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GlobalUniqueIdentifier identifies this mock as the acquirer inside the merchant account
+// information template (tag 26). Real acquirers use their reverse domain name, so do we.
+const GlobalUniqueIdentifier = "ID.CO.MOCKPAYMENT.WWW"
+
+// NationalMerchantId is the fake QRIS NMID that is put under the QRIS template (tag 51).
+const NationalMerchantId = "ID1020000000001"
+
+const (
+	MerchantId           = "MOCK"
+	MerchantName         = "MOCK"
+	MerchantCity         = "JAKARTA"
+	MerchantPostalCode   = "10110"
+	MerchantCategoryCode = "5999"
+)
+
+// ErrMalformed is returned when the payload does not follow the EMVCo TLV format.
+var ErrMalformed = errors.New("malformed qris payload")
+
+// ErrInvalidChecksum is returned when the CRC16 of the payload does not match.
+var ErrInvalidChecksum = errors.New("invalid qris checksum")
+
+// ErrUnknownAcquirer is returned when the payload is a valid QRIS payload, but it
+// was not generated by this mock.
+var ErrUnknownAcquirer = errors.New("unknown qris acquirer")
+
+// Payload contains the information that we put into (and read from) a QRIS payload.
+type Payload struct {
+	EMoneyID string
+	Amount   int64
+}
+
+// Generate creates a dynamic merchant-presented QRIS payload string, following the
+// EMVCo QR Code Specification for Payment Systems. The e-money ID is stored as the
+// merchant PAN of our own merchant account information template.
+func Generate(payload Payload) (string, error) {
+	if payload.EMoneyID == "" {
+		return "", fmt.Errorf("empty e-money id")
+	}
+
+	if payload.Amount <= 0 {
+		return "", fmt.Errorf("amount must be greater than 0")
+	}
+
+	merchantAccount, err := encode(
+		field{"00", GlobalUniqueIdentifier},
+		field{"01", payload.EMoneyID},
+		field{"02", MerchantId},
+		field{"03", "UMI"},
+	)
+	if err != nil {
+		return "", fmt.Errorf("encoding merchant account information: %w", err)
+	}
+
+	qrisMerchantAccount, err := encode(
+		field{"00", "ID.CO.QRIS.WWW"},
+		field{"02", NationalMerchantId},
+		field{"03", "UMI"},
+	)
+	if err != nil {
+		return "", fmt.Errorf("encoding qris merchant account information: %w", err)
+	}
+
+	body, err := encode(
+		field{"00", "01"}, // Payload format indicator
+		field{"01", "12"}, // Point of initiation method, 12 means dynamic
+		field{"26", merchantAccount},
+		field{"51", qrisMerchantAccount},
+		field{"52", MerchantCategoryCode},
+		field{"53", "360"}, // ISO 4217 numeric code for IDR
+		field{"54", strconv.FormatInt(payload.Amount, 10)},
+		field{"58", "ID"},
+		field{"59", MerchantName},
+		field{"60", MerchantCity},
+		field{"61", MerchantPostalCode},
+	)
+	if err != nil {
+		return "", fmt.Errorf("encoding payload: %w", err)
+	}
+
+	// The CRC is calculated over the whole payload, including the ID and length of the CRC itself
+	body += "6304"
+
+	return body + fmt.Sprintf("%04X", checksum(body)), nil
+}
+
+// Parse validates the checksum of a QRIS payload and extracts the information that
+// was put by Generate.
+func Parse(s string) (Payload, error) {
+	if len(s) < 8 || s[len(s)-8:len(s)-4] != "6304" {
+		return Payload{}, ErrMalformed
+	}
+
+	if fmt.Sprintf("%04X", checksum(s[:len(s)-4])) != strings.ToUpper(s[len(s)-4:]) {
+		return Payload{}, ErrInvalidChecksum
+	}
+
+	fields, err := decode(s[:len(s)-8])
+	if err != nil {
+		return Payload{}, err
+	}
+
+	if fields["00"] != "01" {
+		return Payload{}, ErrMalformed
+	}
+
+	merchantAccount, err := decode(fields["26"])
+	if err != nil {
+		return Payload{}, err
+	}
+
+	if merchantAccount["00"] != GlobalUniqueIdentifier || merchantAccount["01"] == "" {
+		return Payload{}, ErrUnknownAcquirer
+	}
+
+	amount, err := strconv.ParseInt(fields["54"], 10, 64)
+	if err != nil {
+		return Payload{}, ErrMalformed
+	}
+
+	return Payload{
+		EMoneyID: merchantAccount["01"],
+		Amount:   amount,
+	}, nil
+}
+
+type field struct {
+	id    string
+	value string
+}
+
+func encode(fields ...field) (string, error) {
+	var builder strings.Builder
+	for _, f := range fields {
+		if len(f.value) > 99 {
+			return "", fmt.Errorf("value of %s exceeds 99 characters", f.id)
+		}
+
+		builder.WriteString(f.id)
+		builder.WriteString(fmt.Sprintf("%02d", len(f.value)))
+		builder.WriteString(f.value)
+	}
+
+	return builder.String(), nil
+}
+
+func decode(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for i := 0; i < len(s); {
+		if i+4 > len(s) {
+			return nil, ErrMalformed
+		}
+
+		// The length is always two digits, strconv.Atoi would let a sign through
+		if !isDigit(s[i+2]) || !isDigit(s[i+3]) {
+			return nil, ErrMalformed
+		}
+
+		length := int(s[i+2]-'0')*10 + int(s[i+3]-'0')
+		if i+4+length > len(s) {
+			return nil, ErrMalformed
+		}
+
+		fields[s[i:i+2]] = s[i+4 : i+4+length]
+		i += 4 + length
+	}
+
+	return fields, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// checksum calculates CRC16/CCITT-FALSE (polynomial 0x1021, initial value 0xFFFF)
+// as required by the EMVCo specification.
+func checksum(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
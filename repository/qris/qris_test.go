@@ -0,0 +1,109 @@
+package qris_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"mock-payment-provider/repository/qris"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("Empty e-money id", func(t *testing.T) {
+		_, err := qris.Generate(qris.Payload{Amount: 10_000})
+		if err == nil {
+			t.Errorf("expecting an error, got nil")
+		}
+	})
+
+	t.Run("Zero amount", func(t *testing.T) {
+		_, err := qris.Generate(qris.Payload{EMoneyID: "8b3c0a2e-6a4f-4a4e-9a55-0c1f1d7f8a10"})
+		if err == nil {
+			t.Errorf("expecting an error, got nil")
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		payload, err := qris.Generate(qris.Payload{EMoneyID: "8b3c0a2e-6a4f-4a4e-9a55-0c1f1d7f8a10", Amount: 50_000})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		expect := "00020101021226800021ID.CO.MOCKPAYMENT.WWW01368b3c0a2e-6a4f-4a4e-9a55-0c1f1d7f8a10" +
+			"0204MOCK0303UMI51440014ID.CO.QRIS.WWW0215ID10200000000010303UMI520459995303360540550000" +
+			"5802ID5904MOCK6007JAKARTA6105101106304"
+		if !strings.HasPrefix(payload, expect) {
+			t.Errorf("expecting payload to start with '%s', instead got '%s'", expect, payload)
+		}
+
+		if payload[len(payload)-4:] != "17A6" {
+			t.Errorf("expecting checksum to be '17A6', instead got '%s'", payload[len(payload)-4:])
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	payload, err := qris.Generate(qris.Payload{EMoneyID: "8b3c0a2e-6a4f-4a4e-9a55-0c1f1d7f8a10", Amount: 50_000})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	t.Run("Happy", func(t *testing.T) {
+		result, err := qris.Parse(payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if result.EMoneyID != "8b3c0a2e-6a4f-4a4e-9a55-0c1f1d7f8a10" {
+			t.Errorf("expecting e-money id to be '8b3c0a2e-6a4f-4a4e-9a55-0c1f1d7f8a10', instead got '%s'", result.EMoneyID)
+		}
+
+		if result.Amount != 50_000 {
+			t.Errorf("expecting amount to be 50000, instead got %d", result.Amount)
+		}
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		_, err := qris.Parse(strings.Replace(payload, "540550000", "540510000", 1))
+		if !errors.Is(err, qris.ErrInvalidChecksum) {
+			t.Errorf("expecting qris.ErrInvalidChecksum, instead got %v", err)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := qris.Parse("hello world")
+		if !errors.Is(err, qris.ErrMalformed) {
+			t.Errorf("expecting qris.ErrMalformed, instead got %v", err)
+		}
+	})
+
+	t.Run("Signed Length", func(t *testing.T) {
+		// The checksum is valid, only the length of the second field is not
+		for _, length := range []string{"-1", "+5"} {
+			_, err := qris.Parse(withChecksum("000201" + "01" + length + "12"))
+			if !errors.Is(err, qris.ErrMalformed) {
+				t.Errorf("expecting qris.ErrMalformed for a length of %s, instead got %v", length, err)
+			}
+		}
+	})
+}
+
+// withChecksum appends the CRC field to the payload, the same way qris.Generate does.
+func withChecksum(payload string) string {
+	payload += "6304"
+
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(payload); i++ {
+		crc ^= uint16(payload[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s%04X", payload, crc)
+}
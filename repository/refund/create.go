@@ -0,0 +1,153 @@
+package refund
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) Create(ctx context.Context, params repository.CreateRefundParam) (primitive.Refund, error) {
+	if params.OrderId == "" {
+		return primitive.Refund{}, fmt.Errorf("orderId is empty")
+	}
+
+	if params.RefundKey == "" {
+		return primitive.Refund{}, fmt.Errorf("refundKey is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.Refund{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	// The previous refunds must not change until the new one is stored, otherwise two
+	// concurrent refunds could both fit and refund more than the transaction amount
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return primitive.Refund{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var existing int64
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM refunds WHERE order_id = ? AND refund_key = ?`,
+		params.OrderId,
+		params.RefundKey,
+	).Scan(&existing)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Refund{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.Refund{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	if existing > 0 {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Refund{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.Refund{}, repository.ErrDuplicate
+	}
+
+	var refundedAmount int64
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE order_id = ?`,
+		params.OrderId,
+	).Scan(&refundedAmount)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Refund{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.Refund{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	amount := params.Amount
+	if amount == 0 {
+		amount = params.RefundableAmount - refundedAmount
+	}
+
+	if amount <= 0 || refundedAmount+amount > params.RefundableAmount {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Refund{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.Refund{}, repository.ErrAmountExceeded
+	}
+
+	refund := primitive.Refund{
+		OrderId:   params.OrderId,
+		RefundKey: params.RefundKey,
+		Amount:    amount,
+		Reason:    params.Reason,
+		Direct:    params.Direct,
+		CreatedAt: time.Now(),
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			refunds
+			(
+			 	order_id,
+			 	refund_key,
+			 	amount,
+			 	reason,
+			 	direct,
+			 	created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?)`,
+		refund.OrderId,
+		refund.RefundKey,
+		refund.Amount,
+		refund.Reason,
+		refund.Direct,
+		refund.CreatedAt,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Refund{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.Refund{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	refund.RefundChargebackId, err = result.LastInsertId()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Refund{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.Refund{}, fmt.Errorf("acquiring last insert id: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Refund{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.Refund{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return refund, nil
+}
@@ -0,0 +1,180 @@
+package refund_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/refund"
+)
+
+func TestRepository_Create(t *testing.T) {
+	refundRepository, err := refund.NewRefundRepository(db)
+	if err != nil {
+		t.Fatalf("creating refund repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := refundRepository.Create(ctx, repository.CreateRefundParam{RefundKey: "a"})
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Empty RefundKey", func(t *testing.T) {
+		_, err := refundRepository.Create(ctx, repository.CreateRefundParam{OrderId: "a"})
+		if err.Error() != "refundKey is empty" {
+			t.Errorf("expecting an error of 'refundKey is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		orderId := uuid.NewString()
+
+		result, err := refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-1",
+			Amount:           5000,
+			RefundableAmount: 10000,
+			Reason:           "customer request",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if result.RefundChargebackId == 0 {
+			t.Errorf("expecting refund chargeback id to be not zero")
+		}
+
+		if result.Amount != 5000 {
+			t.Errorf("expecting amount to be 5000, instead got %d", result.Amount)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		orderId := uuid.NewString()
+
+		_, err := refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-1",
+			Amount:           5000,
+			RefundableAmount: 10000,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		_, err = refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-1",
+			Amount:           5000,
+			RefundableAmount: 10000,
+		})
+		if !errors.Is(err, repository.ErrDuplicate) {
+			t.Errorf("expecting an error of repository.ErrDuplicate, instead got %v", err)
+		}
+	})
+
+	t.Run("Amount Exceeded", func(t *testing.T) {
+		orderId := uuid.NewString()
+
+		_, err := refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-1",
+			Amount:           6000,
+			RefundableAmount: 10000,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		_, err = refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-2",
+			Amount:           6000,
+			RefundableAmount: 10000,
+		})
+		if !errors.Is(err, repository.ErrAmountExceeded) {
+			t.Errorf("expecting an error of repository.ErrAmountExceeded, instead got %v", err)
+		}
+	})
+
+	t.Run("Remaining Amount", func(t *testing.T) {
+		orderId := uuid.NewString()
+
+		_, err := refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-1",
+			Amount:           4000,
+			RefundableAmount: 10000,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		result, err := refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-2",
+			RefundableAmount: 10000,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if result.Amount != 6000 {
+			t.Errorf("expecting amount to be 6000, instead got %d", result.Amount)
+		}
+
+		_, err = refundRepository.Create(ctx, repository.CreateRefundParam{
+			OrderId:          orderId,
+			RefundKey:        "reference-3",
+			RefundableAmount: 10000,
+		})
+		if !errors.Is(err, repository.ErrAmountExceeded) {
+			t.Errorf("expecting an error of repository.ErrAmountExceeded, instead got %v", err)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		orderId := uuid.NewString()
+
+		var wg sync.WaitGroup
+		errs := make([]error, 5)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = refundRepository.Create(ctx, repository.CreateRefundParam{
+					OrderId:          orderId,
+					RefundKey:        fmt.Sprintf("reference-%d", i),
+					Amount:           3000,
+					RefundableAmount: 10000,
+				})
+			}(i)
+		}
+		wg.Wait()
+
+		refunds, err := refundRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(refunds) != 3 {
+			t.Errorf("expecting 3 refunds, instead got %d", len(refunds))
+		}
+
+		for _, err := range errs {
+			if err != nil && !errors.Is(err, repository.ErrAmountExceeded) {
+				t.Errorf("expecting an error of repository.ErrAmountExceeded, instead got %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package refund
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) GetByOrderId(ctx context.Context, orderId string) ([]primitive.Refund, error) {
+	if orderId == "" {
+		return nil, fmt.Errorf("orderId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+			refund_chargeback_id,
+			order_id,
+			refund_key,
+			amount,
+			reason,
+			direct,
+			created_at
+		FROM
+			refunds
+		WHERE
+			order_id = ?
+		ORDER BY
+			refund_chargeback_id ASC`,
+		orderId,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("closing rows")
+		}
+	}()
+
+	refunds := []primitive.Refund{}
+	for rows.Next() {
+		var refund primitive.Refund
+		err := rows.Scan(
+			&refund.RefundChargebackId,
+			&refund.OrderId,
+			&refund.RefundKey,
+			&refund.Amount,
+			&refund.Reason,
+			&refund.Direct,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		refunds = append(refunds, refund)
+	}
+
+	if err := rows.Err(); err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return refunds, nil
+}
@@ -0,0 +1,73 @@
+package refund_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/refund"
+)
+
+func TestRepository_GetByOrderId(t *testing.T) {
+	refundRepository, err := refund.NewRefundRepository(db)
+	if err != nil {
+		t.Fatalf("creating refund repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := refundRepository.GetByOrderId(ctx, "")
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Never refunded", func(t *testing.T) {
+		refunds, err := refundRepository.GetByOrderId(ctx, uuid.NewString())
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if len(refunds) != 0 {
+			t.Errorf("expecting refunds to be empty, instead got %d entries", len(refunds))
+		}
+	})
+
+	t.Run("Normal Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+
+		for _, refundKey := range []string{"first", "second"} {
+			_, err := refundRepository.Create(ctx, repository.CreateRefundParam{
+				OrderId:          orderId,
+				RefundKey:        refundKey,
+				Amount:           1000,
+				RefundableAmount: 2000,
+				Direct:           true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+		}
+
+		refunds, err := refundRepository.GetByOrderId(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(refunds) != 2 {
+			t.Fatalf("expecting 2 refunds, instead got %d", len(refunds))
+		}
+
+		if refunds[0].RefundKey != "first" || refunds[1].RefundKey != "second" {
+			t.Errorf("expecting refunds to be ordered from the oldest one, instead got %s, %s", refunds[0].RefundKey, refunds[1].RefundKey)
+		}
+
+		if !refunds[0].Direct {
+			t.Errorf("expecting direct to be true")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package refund_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository/refund"
+)
+
+func TestRepository_Migrate(t *testing.T) {
+	refundRepository, err := refund.NewRefundRepository(db)
+	if err != nil {
+		t.Fatalf("creating refund repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err = refundRepository.Migrate(ctx)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
@@ -0,0 +1,18 @@
+package refund
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewRefundRepository(db *sql.DB) (*Repository, error) {
+	if db == nil {
+		return &Repository{}, fmt.Errorf("db is nil")
+	}
+
+	return &Repository{db: db}, nil
+}
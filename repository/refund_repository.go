@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"mock-payment-provider/primitive"
+)
+
+type RefundRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+	// Create creates a new refund entry for a transaction. If the refund key was used
+	// before for the same order ID, it will return ErrDuplicate. The previous refunds are
+	// summed up within the same transaction, and it will return ErrAmountExceeded if the
+	// refund doesn't fit in what is left of RefundableAmount.
+	Create(ctx context.Context, params CreateRefundParam) (primitive.Refund, error)
+	// GetByOrderId acquires every refund of an order ID, ordered from the oldest one.
+	// It returns an empty slice if the order has never been refunded.
+	GetByOrderId(ctx context.Context, orderId string) ([]primitive.Refund, error)
+}
+
+type CreateRefundParam struct {
+	OrderId   string
+	RefundKey string
+	// Amount is whatever is left of RefundableAmount if it is zero.
+	Amount int64
+	// RefundableAmount is the most that can be refunded from the transaction in total.
+	RefundableAmount int64
+	Reason           string
+	Direct           bool
+}
@@ -0,0 +1,102 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) Create(ctx context.Context, rule primitive.Rule) (primitive.Rule, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.Rule{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return primitive.Rule{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rule, err = insert(ctx, tx, rule)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Rule{}, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return primitive.Rule{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.Rule{}, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return primitive.Rule{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return rule, nil
+}
+
+// insert gives the rule a new ID and creation time, then stores it.
+func insert(ctx context.Context, tx *sql.Tx, rule primitive.Rule) (primitive.Rule, error) {
+	rule.Id = uuid.NewString()
+	rule.CreatedAt = time.Now().UTC()
+
+	_, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			rules
+			(
+				id,
+				path,
+				order_id_pattern,
+				payment_type,
+				minimum_amount,
+				maximum_amount,
+				action,
+				latency_ms,
+				http_status,
+				status_code,
+				status_message,
+				one_shot,
+				created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.Id,
+		rule.Path,
+		rule.OrderIdPattern,
+		rule.PaymentType,
+		rule.MinimumAmount,
+		rule.MaximumAmount,
+		rule.Action,
+		rule.Latency.Milliseconds(),
+		rule.HTTPStatus,
+		rule.StatusCode,
+		rule.StatusMessage,
+		rule.OneShot,
+		rule.CreatedAt,
+	)
+	if err != nil {
+		return primitive.Rule{}, err
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,73 @@
+package rule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/rule"
+)
+
+func TestRepository_Create(t *testing.T) {
+	ruleRepository, err := rule.NewRuleRepository(db)
+	if err != nil {
+		t.Fatalf("creating rule repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Happy Case", func(t *testing.T) {
+		created, err := ruleRepository.Create(ctx, primitive.Rule{
+			Path:           "/charge",
+			OrderIdPattern: "^fail-",
+			PaymentType:    primitive.PaymentTypeEMoneyGopay,
+			MinimumAmount:  10_000,
+			MaximumAmount:  100_000,
+			Action:         primitive.RuleActionLatency,
+			Latency:        time.Millisecond * 1500,
+			OneShot:        true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if created.Id == "" {
+			t.Errorf("expecting id to be generated, got empty string")
+		}
+
+		if created.CreatedAt.IsZero() {
+			t.Errorf("expecting created at to be set, got zero time")
+		}
+
+		rules, err := ruleRepository.List(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		var found bool
+		for _, r := range rules {
+			if r.Id != created.Id {
+				continue
+			}
+
+			found = true
+			if r.Path != "/charge" || r.OrderIdPattern != "^fail-" || r.PaymentType != primitive.PaymentTypeEMoneyGopay {
+				t.Errorf("expecting the conditions to be stored, instead got %v", r)
+			}
+
+			if r.MinimumAmount != 10_000 || r.MaximumAmount != 100_000 {
+				t.Errorf("expecting the amount range to be stored, instead got %d to %d", r.MinimumAmount, r.MaximumAmount)
+			}
+
+			if r.Action != primitive.RuleActionLatency || r.Latency != time.Millisecond*1500 || !r.OneShot {
+				t.Errorf("expecting the action to be stored, instead got %v", r)
+			}
+		}
+
+		if !found {
+			t.Errorf("expecting the created rule to be listed")
+		}
+	})
+}
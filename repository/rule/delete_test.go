@@ -0,0 +1,59 @@
+package rule_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/rule"
+)
+
+func TestRepository_Delete(t *testing.T) {
+	ruleRepository, err := rule.NewRuleRepository(db)
+	if err != nil {
+		t.Fatalf("creating rule repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty ID", func(t *testing.T) {
+		err := ruleRepository.Delete(ctx, "")
+		if err == nil {
+			t.Fatalf("expecting an error, got nil")
+		}
+
+		if err.Error() != "id is empty" {
+			t.Errorf("expecting error to be 'id is empty', instead got '%s'", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := ruleRepository.Delete(ctx, uuid.NewString())
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting error to be ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		created, err := ruleRepository.Create(ctx, primitive.Rule{Action: primitive.RuleActionDeny, OneShot: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = ruleRepository.Delete(ctx, created.Id)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		// The rule can only be deleted once
+		err = ruleRepository.Delete(ctx, created.Id)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting error to be ErrNotFound, instead got %v", err)
+		}
+	})
+}
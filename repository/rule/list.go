@@ -0,0 +1,126 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) List(ctx context.Context) ([]primitive.Rule, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+			id,
+			path,
+			order_id_pattern,
+			payment_type,
+			minimum_amount,
+			maximum_amount,
+			action,
+			latency_ms,
+			http_status,
+			status_code,
+			status_message,
+			one_shot,
+			created_at
+		FROM
+			rules
+		ORDER BY
+			created_at ASC,
+			rowid ASC`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	rules := []primitive.Rule{}
+	for rows.Next() {
+		var rule primitive.Rule
+		var latencyMs int64
+		err := rows.Scan(
+			&rule.Id,
+			&rule.Path,
+			&rule.OrderIdPattern,
+			&rule.PaymentType,
+			&rule.MinimumAmount,
+			&rule.MaximumAmount,
+			&rule.Action,
+			&latencyMs,
+			&rule.HTTPStatus,
+			&rule.StatusCode,
+			&rule.StatusMessage,
+			&rule.OneShot,
+			&rule.CreatedAt,
+		)
+		if err != nil {
+			_ = rows.Close()
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		rule.Latency = time.Duration(latencyMs) * time.Millisecond
+		rules = append(rules, rule)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		_ = rows.Close()
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("closing rows: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,61 @@
+package rule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/rule"
+)
+
+func TestRepository_List(t *testing.T) {
+	ruleRepository, err := rule.NewRuleRepository(db)
+	if err != nil {
+		t.Fatalf("creating rule repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ruleRepository.Replace(ctx, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		rules, err := ruleRepository.List(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if rules == nil || len(rules) != 0 {
+			t.Errorf("expecting an empty slice, instead got %v", rules)
+		}
+	})
+
+	t.Run("Oldest First", func(t *testing.T) {
+		first, err := ruleRepository.Create(ctx, primitive.Rule{Action: primitive.RuleActionDeny})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		second, err := ruleRepository.Create(ctx, primitive.Rule{Action: primitive.RuleActionDropWebhook})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		rules, err := ruleRepository.List(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(rules) != 2 {
+			t.Fatalf("expecting 2 rules, instead got %d", len(rules))
+		}
+
+		if rules[0].Id != first.Id || rules[1].Id != second.Id {
+			t.Errorf("expecting rules to be ordered from the oldest one, instead got %v", rules)
+		}
+	})
+}
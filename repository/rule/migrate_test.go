@@ -0,0 +1,24 @@
+package rule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository/rule"
+)
+
+func TestRepository_Migrate(t *testing.T) {
+	ruleRepository, err := rule.NewRuleRepository(db)
+	if err != nil {
+		t.Fatalf("creating rule repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err = ruleRepository.Migrate(ctx)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
@@ -0,0 +1,67 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) Replace(ctx context.Context, rules []primitive.Rule) ([]primitive.Rule, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM rules`)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	created := []primitive.Rule{}
+	for _, rule := range rules {
+		rule, err = insert(ctx, tx, rule)
+		if err != nil {
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return nil, fmt.Errorf("rolling back transaction: %w", err)
+			}
+
+			return nil, fmt.Errorf("executing query: %w", err)
+		}
+
+		created = append(created, rule)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return created, nil
+}
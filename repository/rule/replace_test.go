@@ -0,0 +1,56 @@
+package rule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/rule"
+)
+
+func TestRepository_Replace(t *testing.T) {
+	ruleRepository, err := rule.NewRuleRepository(db)
+	if err != nil {
+		t.Fatalf("creating rule repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Happy Case", func(t *testing.T) {
+		_, err := ruleRepository.Create(ctx, primitive.Rule{Action: primitive.RuleActionDeny})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		replaced, err := ruleRepository.Replace(ctx, []primitive.Rule{
+			{Path: "/charge", Action: primitive.RuleActionRespond, HTTPStatus: 500},
+			{OrderIdPattern: "^slow-", Action: primitive.RuleActionLatency, Latency: time.Second},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(replaced) != 2 || replaced[0].Id == "" || replaced[1].Id == "" {
+			t.Fatalf("expecting 2 rules with ids, instead got %v", replaced)
+		}
+
+		rules, err := ruleRepository.List(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(rules) != 2 {
+			t.Fatalf("expecting only the 2 replacing rules, instead got %d", len(rules))
+		}
+
+		if rules[0].Id != replaced[0].Id || rules[1].Id != replaced[1].Id {
+			t.Errorf("expecting rules to keep the given order, instead got %v", rules)
+		}
+
+		if rules[0].HTTPStatus != 500 || rules[1].Latency != time.Second {
+			t.Errorf("expecting the actions to be stored, instead got %v", rules)
+		}
+	})
+}
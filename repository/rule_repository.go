@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"mock-payment-provider/primitive"
+)
+
+type RuleRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+
+	// Create stores a new rule, with a new ID and creation time.
+	Create(ctx context.Context, rule primitive.Rule) (primitive.Rule, error)
+
+	// List returns every rule, from the oldest one. It returns an empty slice if there
+	// is no rule.
+	List(ctx context.Context) ([]primitive.Rule, error)
+
+	// Delete removes a rule. It returns ErrNotFound if the rule was not found, which
+	// also tells that a one-shot rule was already applied by someone else.
+	Delete(ctx context.Context, id string) error
+
+	// Replace removes every rule and stores the given ones instead, in the same order.
+	Replace(ctx context.Context, rules []primitive.Rule) ([]primitive.Rule, error)
+}
@@ -0,0 +1,122 @@
+package snap
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) Create(ctx context.Context, params repository.CreateSnapTransactionParam) (primitive.SnapTransaction, error) {
+	if params.OrderId == "" {
+		return primitive.SnapTransaction{}, fmt.Errorf("orderId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.SnapTransaction{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return primitive.SnapTransaction{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var existing int64
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM snap_transactions WHERE order_id = ?`,
+		params.OrderId,
+	).Scan(&existing)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.SnapTransaction{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.SnapTransaction{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	if existing > 0 {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.SnapTransaction{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.SnapTransaction{}, repository.ErrDuplicate
+	}
+
+	snapTransaction := primitive.SnapTransaction{
+		Token:       uuid.NewString(),
+		OrderId:     params.OrderId,
+		Amount:      params.Amount,
+		Details:     params.Details,
+		FinishURL:   params.FinishURL,
+		UnfinishURL: params.UnfinishURL,
+		ErrorURL:    params.ErrorURL,
+		ExpiresAt:   params.ExpiresAt,
+	}
+	if snapTransaction.Details == nil {
+		snapTransaction.Details = []byte{}
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			snap_transactions
+			(
+			 	token,
+			 	order_id,
+			 	amount,
+			 	details,
+			 	finish_url,
+			 	unfinish_url,
+			 	error_url,
+			 	expired_at,
+			 	created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		snapTransaction.Token,
+		snapTransaction.OrderId,
+		snapTransaction.Amount,
+		snapTransaction.Details,
+		snapTransaction.FinishURL,
+		snapTransaction.UnfinishURL,
+		snapTransaction.ErrorURL,
+		snapTransaction.ExpiresAt,
+		time.Now(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.SnapTransaction{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.SnapTransaction{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.SnapTransaction{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.SnapTransaction{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return snapTransaction, nil
+}
@@ -0,0 +1,67 @@
+package snap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/snap"
+)
+
+func TestRepository_Create(t *testing.T) {
+	snapRepository, err := snap.NewSnapRepository(db)
+	if err != nil {
+		t.Fatalf("creating snap repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := snapRepository.Create(ctx, repository.CreateSnapTransactionParam{})
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Duplicate OrderId", func(t *testing.T) {
+		orderId := uuid.NewString()
+		_, err := snapRepository.Create(ctx, repository.CreateSnapTransactionParam{
+			OrderId:   orderId,
+			Amount:    50000,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		_, err = snapRepository.Create(ctx, repository.CreateSnapTransactionParam{
+			OrderId:   orderId,
+			Amount:    50000,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if !errors.Is(err, repository.ErrDuplicate) {
+			t.Errorf("expecting an error of repository.ErrDuplicate, instead got %v", err)
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		snapTransaction, err := snapRepository.Create(ctx, repository.CreateSnapTransactionParam{
+			OrderId:   uuid.NewString(),
+			Amount:    50000,
+			Details:   []byte(`{}`),
+			FinishURL: "https://example.com/finish",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if snapTransaction.Token == "" {
+			t.Errorf("expecting token to be not empty")
+		}
+	})
+}
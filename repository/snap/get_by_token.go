@@ -0,0 +1,92 @@
+package snap
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) GetByToken(ctx context.Context, token string) (primitive.SnapTransaction, error) {
+	if token == "" {
+		return primitive.SnapTransaction{}, fmt.Errorf("token is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.SnapTransaction{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return primitive.SnapTransaction{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var snapTransaction primitive.SnapTransaction
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT
+			token,
+			order_id,
+			amount,
+			details,
+			finish_url,
+			unfinish_url,
+			error_url,
+			expired_at
+		FROM
+			snap_transactions
+		WHERE
+			token = ?`,
+		token,
+	).Scan(
+		&snapTransaction.Token,
+		&snapTransaction.OrderId,
+		&snapTransaction.Amount,
+		&snapTransaction.Details,
+		&snapTransaction.FinishURL,
+		&snapTransaction.UnfinishURL,
+		&snapTransaction.ErrorURL,
+		&snapTransaction.ExpiresAt,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.SnapTransaction{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return primitive.SnapTransaction{}, repository.ErrNotFound
+		}
+
+		return primitive.SnapTransaction{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.SnapTransaction{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.SnapTransaction{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	if snapTransaction.Expired() {
+		return primitive.SnapTransaction{}, repository.ErrExpired
+	}
+
+	return snapTransaction, nil
+}
@@ -0,0 +1,85 @@
+package snap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/snap"
+)
+
+func TestRepository_GetByToken(t *testing.T) {
+	snapRepository, err := snap.NewSnapRepository(db)
+	if err != nil {
+		t.Fatalf("creating snap repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Token", func(t *testing.T) {
+		_, err := snapRepository.GetByToken(ctx, "")
+		if err.Error() != "token is empty" {
+			t.Errorf("expecting an error of 'token is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := snapRepository.GetByToken(ctx, "not-exists")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		created, err := snapRepository.Create(ctx, repository.CreateSnapTransactionParam{
+			OrderId:   uuid.NewString(),
+			Amount:    50000,
+			ExpiresAt: time.Now().Add(-time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		_, err = snapRepository.GetByToken(ctx, created.Token)
+		if !errors.Is(err, repository.ErrExpired) {
+			t.Errorf("expecting an error of repository.ErrExpired, instead got %v", err)
+		}
+	})
+
+	t.Run("Normal Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+		created, err := snapRepository.Create(ctx, repository.CreateSnapTransactionParam{
+			OrderId:     orderId,
+			Amount:      50000,
+			Details:     []byte(`{"customer":{}}`),
+			FinishURL:   "https://example.com/finish",
+			UnfinishURL: "https://example.com/unfinish",
+			ErrorURL:    "https://example.com/error",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		snapTransaction, err := snapRepository.GetByToken(ctx, created.Token)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if snapTransaction.OrderId != orderId {
+			t.Errorf("expecting order id to be %s, instead got %s", orderId, snapTransaction.OrderId)
+		}
+
+		if string(snapTransaction.Details) != `{"customer":{}}` {
+			t.Errorf("expecting details to be preserved, instead got %s", snapTransaction.Details)
+		}
+
+		if snapTransaction.UnfinishURL != "https://example.com/unfinish" {
+			t.Errorf("expecting unfinish url to be preserved, instead got %s", snapTransaction.UnfinishURL)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package snap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository/snap"
+)
+
+func TestRepository_Migrate(t *testing.T) {
+	snapRepository, err := snap.NewSnapRepository(db)
+	if err != nil {
+		t.Fatalf("creating snap repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err = snapRepository.Migrate(ctx)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
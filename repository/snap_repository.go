@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"mock-payment-provider/primitive"
+)
+
+type SnapRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+
+	// Create saves a new Snap checkout session and generates its token.
+	// It returns ErrDuplicate if a session for the order ID already exists.
+	Create(ctx context.Context, params CreateSnapTransactionParam) (primitive.SnapTransaction, error)
+
+	// GetByToken acquires the Snap checkout session of the token.
+	// It returns ErrNotFound if the session was not found.
+	// It returns ErrExpired if the session is expired.
+	GetByToken(ctx context.Context, token string) (primitive.SnapTransaction, error)
+}
+
+type CreateSnapTransactionParam struct {
+	OrderId     string
+	Amount      int64
+	Details     []byte
+	FinishURL   string
+	UnfinishURL string
+	ErrorURL    string
+	ExpiresAt   time.Time
+}
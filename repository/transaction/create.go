@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
 	"mock-payment-provider/repository"
 )
 
@@ -32,6 +34,29 @@ func (r *Repository) Create(ctx context.Context, params repository.CreateTransac
 		return fmt.Errorf("creating transaction: %w", err)
 	}
 
+	var existing int64
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM transaction_log WHERE order_id = ?`,
+		params.OrderID,
+	).Scan(&existing)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	if existing > 0 {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return repository.ErrDuplicate
+	}
+
+	// The expiry is stored in UTC, so it can be compared as it is within the query
 	_, err = tx.ExecContext(
 		ctx,
 		`INSERT INTO
@@ -51,7 +76,7 @@ func (r *Repository) Create(ctx context.Context, params repository.CreateTransac
 		params.Amount,
 		params.PaymentType,
 		params.Status,
-		params.ExpiredAt,
+		params.ExpiredAt.UTC(),
 		time.Now(),
 		time.Now(),
 	)
@@ -63,6 +88,61 @@ func (r *Repository) Create(ctx context.Context, params repository.CreateTransac
 		return fmt.Errorf("executing insert statement: %w", err)
 	}
 
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			transaction_status_history
+			(
+				 order_id,
+				 from_status,
+				 to_status,
+				 source,
+				 actor,
+				 created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?)`,
+		params.OrderID,
+		primitive.TransactionStatusUnspecified,
+		params.Status,
+		params.Source,
+		params.Actor,
+		time.Now(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing insert statement: %w", err)
+	}
+
+	// Most transactions don't override their notification URLs, there is no need to store those
+	if len(params.NotificationURLs.Override) > 0 || len(params.NotificationURLs.Append) > 0 {
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO
+				transaction_notification
+				(
+					 order_id,
+					 override_urls,
+					 append_urls
+				)
+			VALUES
+				(?, ?, ?)`,
+			params.OrderID,
+			strings.Join(params.NotificationURLs.Override, ","),
+			strings.Join(params.NotificationURLs.Append, ","),
+		)
+		if err != nil {
+			if e := tx.Rollback(); e != nil {
+				return fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return fmt.Errorf("executing insert statement: %w", err)
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
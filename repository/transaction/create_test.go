@@ -2,6 +2,7 @@ package transaction_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -53,10 +54,8 @@ func TestRepository_Create(t *testing.T) {
 			Status:      1,
 			ExpiredAt:   time.Now().Add(time.Hour * 3),
 		})
-		if err == nil {
-			t.Errorf("Expecting an error, got nil instead")
+		if !errors.Is(err, repository.ErrDuplicate) {
+			t.Errorf("Expecting an error of repository.ErrDuplicate, got %v instead", err)
 		}
-
-		t.Logf(err.Error())
 	})
 }
@@ -0,0 +1,188 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) ExpireOverdue(ctx context.Context, limit int) ([]primitive.Transaction, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than zero")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("closing connection")
+		}
+	}()
+
+	// Selecting and expiring must be serializable, so a payment that comes in between
+	// is never overwritten, and no transaction is expired twice
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+    		order_id,
+    		amount,
+    		payment_type,
+    		status,
+    		expired_at,
+    		created_at
+		FROM
+			transaction_log
+		WHERE
+			status = ?
+			AND expired_at <= ?
+		ORDER BY
+			expired_at ASC
+		LIMIT ?`,
+		primitive.TransactionStatusPending,
+		now,
+		limit,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	var transactions []primitive.Transaction
+	for rows.Next() {
+		var transaction primitive.Transaction
+		err := rows.Scan(
+			&transaction.OrderId,
+			&transaction.TransactionAmount,
+			&transaction.PaymentType,
+			&transaction.TransactionStatus,
+			&transaction.ExpiresAt,
+			&transaction.TransactionTime,
+		)
+		if err != nil {
+			_ = rows.Close()
+			if e := tx.Rollback(); e != nil {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		_ = rows.Close()
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("closing rows: %w", err)
+	}
+
+	for i := range transactions {
+		_, err := tx.ExecContext(
+			ctx,
+			`UPDATE transaction_log SET status = ?, updated_at = ? WHERE order_id = ?`,
+			primitive.TransactionStatusExpire,
+			now,
+			transactions[i].OrderId,
+		)
+		if err != nil {
+			if e := tx.Rollback(); e != nil {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("executing update statement: %w", err)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO
+				transaction_status_history
+				(
+					 order_id,
+					 from_status,
+					 to_status,
+					 source,
+					 actor,
+					 created_at
+				)
+			VALUES
+				(?, ?, ?, ?, ?, ?)`,
+			transactions[i].OrderId,
+			transactions[i].TransactionStatus,
+			primitive.TransactionStatusExpire,
+			primitive.StatusChangeSourceExpireTimer,
+			primitive.StatusChangeActorSystem,
+			now,
+		)
+		if err != nil {
+			if e := tx.Rollback(); e != nil {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("executing insert statement: %w", err)
+		}
+
+		// The charge is released and notified after this transaction, the entry is kept
+		// until then so a crash in between is picked up again
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO transaction_unreleased_expiry (order_id, created_at) VALUES (?, ?)`,
+			transactions[i].OrderId,
+			now,
+		)
+		if err != nil {
+			if e := tx.Rollback(); e != nil {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("executing insert statement: %w", err)
+		}
+
+		transactions[i].TransactionStatus = primitive.TransactionStatusExpire
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return transactions, nil
+}
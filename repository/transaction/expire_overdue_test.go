@@ -0,0 +1,137 @@
+package transaction_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_ExpireOverdue(t *testing.T) {
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("Creating transaction repository: %s", err.Error())
+	}
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		_, err := transactionRepository.ExpireOverdue(context.Background(), 0)
+		if err == nil {
+			t.Fatalf("expecting an error, got nil instead")
+		}
+
+		if err.Error() != "limit must be greater than zero" {
+			t.Errorf("expecting an error of 'limit must be greater than zero', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy Integration", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		defer cancel()
+
+		entries := []repository.CreateTransactionParam{
+			{
+				OrderID:     "overdue-pending",
+				Amount:      100_000,
+				PaymentType: primitive.PaymentTypeVirtualAccountBNI,
+				Status:      primitive.TransactionStatusPending,
+				ExpiredAt:   time.Now().Add(-time.Minute),
+			},
+			{
+				OrderID:     "overdue-settled",
+				Amount:      100_000,
+				PaymentType: primitive.PaymentTypeVirtualAccountBNI,
+				Status:      primitive.TransactionStatusSettlement,
+				ExpiredAt:   time.Now().Add(-time.Minute),
+			},
+			{
+				OrderID:     "not-yet-overdue",
+				Amount:      100_000,
+				PaymentType: primitive.PaymentTypeEMoneyGopay,
+				Status:      primitive.TransactionStatusPending,
+				ExpiredAt:   time.Now().Add(time.Hour),
+			},
+		}
+		for _, entry := range entries {
+			err := transactionRepository.Create(ctx, entry)
+			if err != nil {
+				t.Fatalf("creating an entry: %s", err.Error())
+			}
+		}
+
+		expired, err := transactionRepository.ExpireOverdue(ctx, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(expired) != 1 {
+			t.Fatalf("expecting 1 expired transaction, instead got %d", len(expired))
+		}
+
+		if expired[0].OrderId != "overdue-pending" {
+			t.Errorf("expecting overdue-pending to be expired, instead got %s", expired[0].OrderId)
+		}
+
+		if expired[0].TransactionStatus != primitive.TransactionStatusExpire {
+			t.Errorf("expecting status to be %s, instead got %s", primitive.TransactionStatusExpire, expired[0].TransactionStatus)
+		}
+
+		for _, orderId := range []string{"overdue-settled", "not-yet-overdue"} {
+			entry, err := transactionRepository.GetByOrderId(ctx, orderId)
+			if err != nil {
+				t.Fatalf("getting %s: %s", orderId, err.Error())
+			}
+
+			if entry.TransactionStatus == primitive.TransactionStatusExpire {
+				t.Errorf("expecting %s to not be expired", orderId)
+			}
+		}
+
+		history, err := transactionRepository.GetStatusHistory(ctx, "overdue-pending")
+		if err != nil {
+			t.Fatalf("getting status history: %s", err.Error())
+		}
+
+		last := history[len(history)-1]
+		if last.To != primitive.TransactionStatusExpire || last.Source != primitive.StatusChangeSourceExpireTimer || last.Actor != primitive.StatusChangeActorSystem {
+			t.Errorf("expecting the last status change to be an expiry by the system, instead got %+v", last)
+		}
+
+		// Already expired transactions are never returned again
+		expired, err = transactionRepository.ExpireOverdue(ctx, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(expired) != 0 {
+			t.Errorf("expecting no expired transaction, instead got %d", len(expired))
+		}
+	})
+	t.Run("Non-UTC Expiry", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		defer cancel()
+
+		// An order_time of +0700 keeps its offset, it must still expire on time on a server of any zone
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     "overdue-wib",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeVirtualAccountBNI,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(-time.Minute).In(time.FixedZone("WIB", 7*60*60)),
+		})
+		if err != nil {
+			t.Fatalf("creating an entry: %s", err.Error())
+		}
+
+		expired, err := transactionRepository.ExpireOverdue(ctx, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(expired) != 1 || expired[0].OrderId != "overdue-wib" {
+			t.Fatalf("expecting overdue-wib to be expired, instead got %v", expired)
+		}
+	})
+}
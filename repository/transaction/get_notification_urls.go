@@ -0,0 +1,81 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) GetNotificationURLs(ctx context.Context, orderId string) (primitive.NotificationURLs, error) {
+	if orderId == "" {
+		return primitive.NotificationURLs{}, fmt.Errorf("empty order id")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.NotificationURLs{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("closing connection")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return primitive.NotificationURLs{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var overrideUrls, appendUrls string
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT
+    		override_urls,
+    		append_urls
+		FROM
+			transaction_notification
+		WHERE
+			order_id = ?`,
+		orderId,
+	).Scan(
+		&overrideUrls,
+		&appendUrls,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		if e := tx.Rollback(); e != nil {
+			return primitive.NotificationURLs{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.NotificationURLs{}, fmt.Errorf("querying row: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.NotificationURLs{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.NotificationURLs{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	var notificationUrls primitive.NotificationURLs
+	if overrideUrls != "" {
+		notificationUrls.Override = strings.Split(overrideUrls, ",")
+	}
+
+	if appendUrls != "" {
+		notificationUrls.Append = strings.Split(appendUrls, ",")
+	}
+
+	return notificationUrls, nil
+}
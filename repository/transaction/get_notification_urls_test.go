@@ -0,0 +1,89 @@
+package transaction_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_GetNotificationURLs(t *testing.T) {
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("Creating new transaction repository: %s", err.Error())
+	}
+
+	t.Run("Empty Order ID", func(t *testing.T) {
+		_, err := transactionRepository.GetNotificationURLs(context.Background(), "")
+		if err == nil {
+			t.Errorf("expecting an error, got nil")
+		}
+
+		if err.Error() != "empty order id" {
+			t.Errorf("expecting .Error() to be 'empty order id', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Set", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		defer cancel()
+
+		orderId := uuid.NewString()
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     orderId,
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeEMoneyGopay,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("creating transaction: %s", err.Error())
+		}
+
+		notificationUrls, err := transactionRepository.GetNotificationURLs(ctx, orderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if len(notificationUrls.Override) != 0 || len(notificationUrls.Append) != 0 {
+			t.Errorf("expecting empty notification urls, instead got %v", notificationUrls)
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		defer cancel()
+
+		expected := primitive.NotificationURLs{
+			Override: []string{"https://a.example.com/notify", "https://b.example.com/notify"},
+			Append:   []string{"https://c.example.com/notify"},
+		}
+
+		orderId := uuid.NewString()
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:          orderId,
+			Amount:           100_000,
+			PaymentType:      primitive.PaymentTypeEMoneyGopay,
+			Status:           primitive.TransactionStatusPending,
+			ExpiredAt:        time.Now().Add(time.Hour),
+			NotificationURLs: expected,
+		})
+		if err != nil {
+			t.Fatalf("creating transaction: %s", err.Error())
+		}
+
+		notificationUrls, err := transactionRepository.GetNotificationURLs(ctx, orderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(notificationUrls, expected) {
+			t.Errorf("expecting notification urls to be %v, instead got %v", expected, notificationUrls)
+		}
+	})
+}
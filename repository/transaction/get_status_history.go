@@ -0,0 +1,116 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) GetStatusHistory(ctx context.Context, orderId string) ([]primitive.TransactionStatusChange, error) {
+	if orderId == "" {
+		return nil, fmt.Errorf("empty order id")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("closing connection")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+    		order_id,
+    		from_status,
+    		to_status,
+    		source,
+    		actor,
+    		created_at
+		FROM
+			transaction_status_history
+		WHERE
+			order_id = ?
+		ORDER BY
+			id ASC`,
+		orderId,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("closing rows")
+		}
+	}()
+
+	var history []primitive.TransactionStatusChange
+	for rows.Next() {
+		var change primitive.TransactionStatusChange
+		err := rows.Scan(
+			&change.OrderId,
+			&change.From,
+			&change.To,
+			&change.Source,
+			&change.Actor,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			if e := tx.Rollback(); e != nil {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		history = append(history, change)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	if len(history) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	return history, nil
+}
@@ -0,0 +1,94 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_GetStatusHistory(t *testing.T) {
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("Creating new transaction repository: %s", err.Error())
+	}
+
+	t.Run("Empty Order ID", func(t *testing.T) {
+		_, err := transactionRepository.GetStatusHistory(context.Background(), "")
+		if err == nil {
+			t.Errorf("expecting an error, got nil")
+		}
+
+		if err.Error() != "empty order id" {
+			t.Errorf("expecting .Error() to be 'empty order id', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		_, err := transactionRepository.GetStatusHistory(ctx, "NOT-FOUND")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		defer cancel()
+
+		orderId := uuid.NewString()
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     orderId,
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeEMoneyGopay,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(time.Hour),
+			Source:      primitive.StatusChangeSourceCharge,
+			Actor:       primitive.StatusChangeActorMerchant,
+		})
+		if err != nil {
+			t.Fatalf("creating an entry: %s", err.Error())
+		}
+
+		err = transactionRepository.UpdateStatus(ctx, orderId, primitive.TransactionStatusSettlement, primitive.StatusChangeSourceInternalMarkAsPaid, primitive.StatusChangeActorCustomer)
+		if err != nil {
+			t.Fatalf("updating status: %s", err.Error())
+		}
+
+		history, err := transactionRepository.GetStatusHistory(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(history) != 2 {
+			t.Fatalf("expecting 2 entries, instead got %d", len(history))
+		}
+
+		if history[0].From != primitive.TransactionStatusUnspecified || history[0].To != primitive.TransactionStatusPending {
+			t.Errorf("expecting the first entry to move from UNSPECIFIED to pending, instead got %s to %s", history[0].From, history[0].To)
+		}
+
+		if history[0].Source != primitive.StatusChangeSourceCharge || history[0].Actor != primitive.StatusChangeActorMerchant {
+			t.Errorf("expecting the first entry to come from charge by merchant, instead got %s by %s", history[0].Source, history[0].Actor)
+		}
+
+		if history[1].From != primitive.TransactionStatusPending || history[1].To != primitive.TransactionStatusSettlement {
+			t.Errorf("expecting the second entry to move from pending to settlement, instead got %s to %s", history[1].From, history[1].To)
+		}
+
+		if history[1].Source != primitive.StatusChangeSourceInternalMarkAsPaid || history[1].Actor != primitive.StatusChangeActorCustomer {
+			t.Errorf("expecting the second entry to come from internal_mark_as_paid by customer, instead got %s by %s", history[1].Source, history[1].Actor)
+		}
+
+		if history[1].ChangedAt.IsZero() {
+			t.Errorf("expecting ChangedAt to be filled")
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) ListPending(ctx context.Context, createdBefore time.Time) ([]primitive.Transaction, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("closing connection")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+    		order_id,
+    		amount,
+    		payment_type,
+    		status,
+    		expired_at,
+    		created_at
+		FROM
+			transaction_log
+		WHERE
+			status = ?
+			AND created_at <= ?
+			AND expired_at > ?
+		ORDER BY
+			created_at ASC`,
+		primitive.TransactionStatusPending,
+		createdBefore,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	transactions := []primitive.Transaction{}
+	for rows.Next() {
+		var transaction primitive.Transaction
+		err := rows.Scan(
+			&transaction.OrderId,
+			&transaction.TransactionAmount,
+			&transaction.PaymentType,
+			&transaction.TransactionStatus,
+			&transaction.ExpiresAt,
+			&transaction.TransactionTime,
+		)
+		if err != nil {
+			_ = rows.Close()
+			if e := tx.Rollback(); e != nil {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		_ = rows.Close()
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("closing rows: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,100 @@
+package transaction_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_ListPending(t *testing.T) {
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("Creating transaction repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	entries := []repository.CreateTransactionParam{
+		{
+			OrderID:     "list-pending",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeVirtualAccountBCA,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(time.Hour),
+		},
+		{
+			OrderID:     "list-pending-settled",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeVirtualAccountBCA,
+			Status:      primitive.TransactionStatusSettlement,
+			ExpiredAt:   time.Now().Add(time.Hour),
+		},
+		{
+			OrderID:     "list-pending-overdue",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeEMoneyGopay,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(-time.Minute),
+		},
+		{
+			OrderID:     "list-pending-overdue-wib",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeEMoneyGopay,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(-time.Minute).In(time.FixedZone("WIB", 7*60*60)),
+		},
+	}
+	for _, entry := range entries {
+		err := transactionRepository.Create(ctx, entry)
+		if err != nil {
+			t.Fatalf("creating an entry: %s", err.Error())
+		}
+	}
+
+	t.Run("Created Later", func(t *testing.T) {
+		transactions, err := transactionRepository.ListPending(ctx, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		for _, transaction := range transactions {
+			if transaction.OrderId == "list-pending" {
+				t.Errorf("expecting list-pending not to be listed before it was created")
+			}
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		transactions, err := transactionRepository.ListPending(ctx, time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		var found bool
+		for _, transaction := range transactions {
+			switch transaction.OrderId {
+			case "list-pending":
+				found = true
+
+				if transaction.PaymentType != primitive.PaymentTypeVirtualAccountBCA {
+					t.Errorf("expecting payment type to be %s, instead got %s", primitive.PaymentTypeVirtualAccountBCA, transaction.PaymentType)
+				}
+			case "list-pending-settled", "list-pending-overdue", "list-pending-overdue-wib":
+				t.Errorf("expecting %s not to be listed", transaction.OrderId)
+			}
+
+			if transaction.TransactionStatus != primitive.TransactionStatusPending {
+				t.Errorf("expecting only pending transactions, instead got %s", transaction.TransactionStatus)
+			}
+		}
+
+		if !found {
+			t.Errorf("expecting list-pending to be listed")
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) ListUnreleasedExpired(ctx context.Context, limit int) ([]primitive.Transaction, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than zero")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("closing connection")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+    		transaction_log.order_id,
+    		transaction_log.amount,
+    		transaction_log.payment_type,
+    		transaction_log.status,
+    		transaction_log.expired_at,
+    		transaction_log.created_at
+		FROM
+			transaction_unreleased_expiry
+			JOIN transaction_log ON transaction_log.order_id = transaction_unreleased_expiry.order_id
+		ORDER BY
+			transaction_unreleased_expiry.created_at ASC
+		LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	transactions := []primitive.Transaction{}
+	for rows.Next() {
+		var transaction primitive.Transaction
+		err := rows.Scan(
+			&transaction.OrderId,
+			&transaction.TransactionAmount,
+			&transaction.PaymentType,
+			&transaction.TransactionStatus,
+			&transaction.ExpiresAt,
+			&transaction.TransactionTime,
+		)
+		if err != nil {
+			_ = rows.Close()
+			if e := tx.Rollback(); e != nil {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		_ = rows.Close()
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("closing rows: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return transactions, nil
+}
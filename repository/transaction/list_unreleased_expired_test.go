@@ -0,0 +1,89 @@
+package transaction_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_ListUnreleasedExpired(t *testing.T) {
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("Creating transaction repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		_, err := transactionRepository.ListUnreleasedExpired(ctx, 0)
+		if err == nil {
+			t.Fatalf("expecting an error, got nil instead")
+		}
+
+		if err.Error() != "limit must be greater than zero" {
+			t.Errorf("expecting an error of 'limit must be greater than zero', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     "unreleased-expired",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeVirtualAccountBRI,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(-time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("creating an entry: %s", err.Error())
+		}
+
+		_, err = transactionRepository.ExpireOverdue(ctx, 100)
+		if err != nil {
+			t.Fatalf("expiring overdue transactions: %s", err.Error())
+		}
+
+		// Every expired transaction stays listed until it is marked as released
+		for i := 0; i < 2; i++ {
+			transactions, err := transactionRepository.ListUnreleasedExpired(ctx, 100)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var found bool
+			for _, transaction := range transactions {
+				if transaction.OrderId == "unreleased-expired" {
+					found = true
+
+					if transaction.TransactionStatus != primitive.TransactionStatusExpire {
+						t.Errorf("expecting status to be %s, instead got %s", primitive.TransactionStatusExpire, transaction.TransactionStatus)
+					}
+				}
+			}
+
+			if !found {
+				t.Fatalf("expecting unreleased-expired to be listed")
+			}
+		}
+
+		err = transactionRepository.MarkExpiryReleased(ctx, "unreleased-expired")
+		if err != nil {
+			t.Fatalf("marking expiry as released: %s", err.Error())
+		}
+
+		transactions, err := transactionRepository.ListUnreleasedExpired(ctx, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		for _, transaction := range transactions {
+			if transaction.OrderId == "unreleased-expired" {
+				t.Errorf("expecting unreleased-expired not to be listed once it is released")
+			}
+		}
+	})
+}
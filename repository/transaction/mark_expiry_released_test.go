@@ -0,0 +1,68 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/transaction"
+)
+
+func TestRepository_MarkExpiryReleased(t *testing.T) {
+	transactionRepository, err := transaction.NewTransactionRepository(db)
+	if err != nil {
+		t.Fatalf("Creating transaction repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	t.Run("Empty Order ID", func(t *testing.T) {
+		err := transactionRepository.MarkExpiryReleased(ctx, "")
+		if err == nil {
+			t.Fatalf("expecting an error, got nil instead")
+		}
+
+		if err.Error() != "empty order id" {
+			t.Errorf("expecting an error of 'empty order id', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := transactionRepository.MarkExpiryReleased(ctx, "never-expired")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Released Twice", func(t *testing.T) {
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     "mark-expiry-released",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeEMoneyGopay,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(-time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("creating an entry: %s", err.Error())
+		}
+
+		_, err = transactionRepository.ExpireOverdue(ctx, 100)
+		if err != nil {
+			t.Fatalf("expiring overdue transactions: %s", err.Error())
+		}
+
+		err = transactionRepository.MarkExpiryReleased(ctx, "mark-expiry-released")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = transactionRepository.MarkExpiryReleased(ctx, "mark-expiry-released")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of ErrNotFound, instead got %v", err)
+		}
+	})
+}
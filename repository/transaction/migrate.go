@@ -49,6 +49,78 @@ func (r *Repository) Migrate(ctx context.Context) error {
 		return fmt.Errorf("executing create table transaction log: %w", err)
 	}
 
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS transaction_status_history (
+    		id INTEGER PRIMARY KEY AUTOINCREMENT,
+    		order_id TEXT NOT NULL,
+    		from_status INT NOT NULL,
+    		to_status INT NOT NULL,
+    		source INT NOT NULL,
+    		actor INT NOT NULL,
+    		created_at DATETIME NOT NULL
+		)`)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create table transaction status history: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS transaction_notification (
+    		order_id TEXT PRIMARY KEY,
+    		override_urls TEXT NOT NULL,
+    		append_urls TEXT NOT NULL
+		)`)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create table transaction notification: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS transaction_unreleased_expiry (
+    		order_id TEXT PRIMARY KEY,
+    		created_at DATETIME NOT NULL
+		)`)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create table transaction unreleased expiry: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE INDEX IF NOT EXISTS idx_transaction_status_history_order_id ON transaction_status_history (order_id)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create index transaction status history: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE INDEX IF NOT EXISTS idx_transaction_log_status_expired_at ON transaction_log (status, expired_at)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create index transaction log: %w", err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog"
 	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
 )
 
-func (r *Repository) UpdateStatus(ctx context.Context, orderId string, status primitive.TransactionStatus) error {
+func (r *Repository) UpdateStatus(ctx context.Context, orderId string, status primitive.TransactionStatus, source primitive.StatusChangeSource, actor primitive.StatusChangeActor) error {
 	if orderId == "" {
 		return fmt.Errorf("empty order id")
 	}
@@ -28,17 +30,45 @@ func (r *Repository) UpdateStatus(ctx context.Context, orderId string, status pr
 	}()
 
 	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
-		Isolation: sql.LevelReadCommitted,
+		Isolation: sql.LevelRepeatableRead,
 		ReadOnly:  false,
 	})
 	if err != nil {
 		return fmt.Errorf("creating transaction: %w", err)
 	}
 
+	var currentStatus primitive.TransactionStatus
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT status FROM transaction_log WHERE order_id = ?`,
+		orderId,
+	).Scan(&currentStatus)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrNotFound
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	// Refuse any jump that the transaction status flow doesn't allow
+	if !currentStatus.CanTransitionTo(status) {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return &repository.InvalidTransitionError{From: currentStatus, To: status}
+	}
+
 	_, err = tx.ExecContext(
 		ctx,
-		`UPDATE transaction_log SET status = ? WHERE order_id = ?`,
+		`UPDATE transaction_log SET status = ?, updated_at = ? WHERE order_id = ?`,
 		status,
+		time.Now(),
 		orderId,
 	)
 	if err != nil {
@@ -49,6 +79,35 @@ func (r *Repository) UpdateStatus(ctx context.Context, orderId string, status pr
 		return fmt.Errorf("executing update statement: %w", err)
 	}
 
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			transaction_status_history
+			(
+				 order_id,
+				 from_status,
+				 to_status,
+				 source,
+				 actor,
+				 created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?)`,
+		orderId,
+		currentStatus,
+		status,
+		source,
+		actor,
+		time.Now(),
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing insert statement: %w", err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
@@ -2,6 +2,7 @@ package transaction_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -17,7 +18,7 @@ func TestRepository_UpdateStatus(t *testing.T) {
 	}
 
 	t.Run("Empty Order ID", func(t *testing.T) {
-		err := transactionRepository.UpdateStatus(context.Background(), "", primitive.TransactionStatusDenied)
+		err := transactionRepository.UpdateStatus(context.Background(), "", primitive.TransactionStatusDeny, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
 		if err == nil {
 			t.Errorf("expecting an error, got nil instead")
 		}
@@ -42,9 +43,47 @@ func TestRepository_UpdateStatus(t *testing.T) {
 			t.Fatalf("creating an entry: %s", err.Error())
 		}
 
-		err = transactionRepository.UpdateStatus(ctx, "d41d8cd98f00b204e9800998ecf8427e", primitive.TransactionStatusSettled)
+		err = transactionRepository.UpdateStatus(ctx, "d41d8cd98f00b204e9800998ecf8427e", primitive.TransactionStatusSettlement, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
 		if err != nil {
 			t.Errorf("unexpected error: %s", err.Error())
 		}
 	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := transactionRepository.UpdateStatus(context.Background(), "not-exists", primitive.TransactionStatusSettlement, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Invalid Transition", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		defer cancel()
+
+		err := transactionRepository.Create(ctx, repository.CreateTransactionParam{
+			OrderID:     "0cc175b9c0f1b6a831c399e269772661",
+			Amount:      100_000,
+			PaymentType: primitive.PaymentTypeVirtualAccountBCA,
+			Status:      primitive.TransactionStatusPending,
+			ExpiredAt:   time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("creating an entry: %s", err.Error())
+		}
+
+		err = transactionRepository.UpdateStatus(ctx, "0cc175b9c0f1b6a831c399e269772661", primitive.TransactionStatusExpire, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		err = transactionRepository.UpdateStatus(ctx, "0cc175b9c0f1b6a831c399e269772661", primitive.TransactionStatusSettlement, primitive.StatusChangeSourceAPI, primitive.StatusChangeActorMerchant)
+		var invalidTransitionError *repository.InvalidTransitionError
+		if !errors.As(err, &invalidTransitionError) {
+			t.Fatalf("expecting an error of *repository.InvalidTransitionError, instead got %v", err)
+		}
+
+		if invalidTransitionError.From != primitive.TransactionStatusExpire || invalidTransitionError.To != primitive.TransactionStatusSettlement {
+			t.Errorf("expecting transition from expire to settlement, instead got %s to %s", invalidTransitionError.From, invalidTransitionError.To)
+		}
+	})
 }
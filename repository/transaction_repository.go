@@ -13,13 +13,35 @@ type TransactionRepository interface {
 	// Create creates a new entry of transaction. If OrderId already exists,
 	// it will return ErrDuplicate
 	Create(ctx context.Context, params CreateTransactionParam) error
-	// UpdateStatus will update the status. If the transaction has expired, it
-	// will return ErrExpired. If the transaction was not found, it will return
-	// ErrNotFound.
-	UpdateStatus(ctx context.Context, orderId string, status primitive.TransactionStatus) error
+	// UpdateStatus will update the status, and record the change in the status history
+	// along with its source and actor. If the current status can't move to the new
+	// status, it will return an *InvalidTransitionError. If the transaction was not
+	// found, it will return ErrNotFound.
+	UpdateStatus(ctx context.Context, orderId string, status primitive.TransactionStatus, source primitive.StatusChangeSource, actor primitive.StatusChangeActor) error
 	// GetByOrderId will get a transaction based on the order ID. It will return
 	// ErrNotFound if the transaction can't be found.
 	GetByOrderId(ctx context.Context, orderId string) (primitive.Transaction, error)
+	// ExpireOverdue moves at most limit pending transactions that are past their expiry
+	// time to the expire status in a single transaction, and records the changes in the
+	// status history. It returns the transactions it has expired, so every transaction is
+	// only ever returned once, no matter how many times it is called. The expired
+	// transactions are also kept by ListUnreleasedExpired until MarkExpiryReleased.
+	ExpireOverdue(ctx context.Context, limit int) ([]primitive.Transaction, error)
+	// ListUnreleasedExpired will get at most limit transactions that were expired by
+	// ExpireOverdue, and whose charge has not been released and notified yet.
+	ListUnreleasedExpired(ctx context.Context, limit int) ([]primitive.Transaction, error)
+	// MarkExpiryReleased tells that the charge of an expired transaction has been released
+	// and notified. It will return ErrNotFound if the transaction is not waiting for it.
+	MarkExpiryReleased(ctx context.Context, orderId string) error
+	// ListPending will get every pending transaction that was created at or before
+	// createdBefore and is not overdue yet, from the oldest one.
+	ListPending(ctx context.Context, createdBefore time.Time) ([]primitive.Transaction, error)
+	// GetStatusHistory will get every status change of a transaction, from the oldest
+	// one. It will return ErrNotFound if the transaction has no status history.
+	GetStatusHistory(ctx context.Context, orderId string) ([]primitive.TransactionStatusChange, error)
+	// GetNotificationURLs will get the notification URLs the transaction was created with.
+	// It returns an empty NotificationURLs if the transaction did not set any.
+	GetNotificationURLs(ctx context.Context, orderId string) (primitive.NotificationURLs, error)
 }
 
 type CreateTransactionParam struct {
@@ -28,4 +50,9 @@ type CreateTransactionParam struct {
 	PaymentType primitive.PaymentType
 	Status      primitive.TransactionStatus
 	ExpiredAt   time.Time
+	// Source and Actor are recorded as the first entry of the status history.
+	Source primitive.StatusChangeSource
+	Actor  primitive.StatusChangeActor
+	// NotificationURLs are the webhook receivers of the transaction, it may be empty.
+	NotificationURLs primitive.NotificationURLs
 }
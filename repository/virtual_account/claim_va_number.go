@@ -0,0 +1,111 @@
+package virtual_account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/repository"
+)
+
+// ClaimVirtualAccountNumber registers the virtualAccountNumber as the one of customerUniqueField,
+// replacing the number that the customer had before. It returns ErrDuplicate if the number
+// is held by another customer.
+func (r *Repository) ClaimVirtualAccountNumber(ctx context.Context, customerUniqueField string, virtualAccountNumber string) error {
+	if customerUniqueField == "" {
+		return fmt.Errorf("customerUniqueField is empty")
+	}
+
+	if virtualAccountNumber == "" {
+		return fmt.Errorf("virtualAccountNumber is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var holder string
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT unique_identifier FROM virtual_accounts WHERE virtual_account_number = ?`,
+		virtualAccountNumber,
+	).Scan(&holder)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	if err == nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(e, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		if holder != customerUniqueField {
+			return repository.ErrDuplicate
+		}
+
+		// The customer already holds the number
+		return nil
+	}
+
+	// The charge on the previous number of the customer can't be paid with the new one
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			virtual_accounts
+			(
+			 	unique_identifier,
+			 	virtual_account_number,
+			 	current_order_id,
+			 	created_at,
+			 	updated_at
+			)
+		VALUES
+			(?, ?, NULL, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (unique_identifier) DO UPDATE SET
+			virtual_account_number = excluded.virtual_account_number,
+			current_order_id = NULL,
+			updated_at = CURRENT_TIMESTAMP`,
+		customerUniqueField,
+		virtualAccountNumber,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return nil
+}
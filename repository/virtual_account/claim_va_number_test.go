@@ -0,0 +1,104 @@
+package virtual_account_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/virtual_account"
+)
+
+func TestRepository_ClaimVirtualAccountNumber(t *testing.T) {
+	virtualAccountRepository, err := virtual_account.NewVirtualAccountRepository(db)
+	if err != nil {
+		t.Fatalf("creating virtual account repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Unique ID", func(t *testing.T) {
+		err := virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "", "8800123456")
+		if err == nil {
+			t.Fatalf("expecting an error, got nil")
+		}
+
+		if err.Error() != "customerUniqueField is empty" {
+			t.Errorf("expecting error to be 'customerUniqueField is empty', instead got '%s'", err.Error())
+		}
+	})
+
+	t.Run("Empty Virtual Account Number", func(t *testing.T) {
+		err := virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "claim@example.com", "")
+		if err == nil {
+			t.Fatalf("expecting an error, got nil")
+		}
+
+		if err.Error() != "virtualAccountNumber is empty" {
+			t.Errorf("expecting error to be 'virtualAccountNumber is empty', instead got '%s'", err.Error())
+		}
+	})
+
+	t.Run("New Customer", func(t *testing.T) {
+		err := virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "claim-new@example.com", "8800000001")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		number, err := virtualAccountRepository.CreateOrGetVirtualAccountNumber(ctx, "claim-new@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if number != "8800000001" {
+			t.Errorf("expecting virtual account number to be 8800000001, instead got %s", number)
+		}
+
+		// Claiming it again for the same customer is fine
+		err = virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "claim-new@example.com", "8800000001")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("Replace Existing Number", func(t *testing.T) {
+		previous, err := virtualAccountRepository.CreateOrGetVirtualAccountNumber(ctx, "claim-existing@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "claim-existing@example.com", "8800000002")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		number, err := virtualAccountRepository.CreateOrGetVirtualAccountNumber(ctx, "claim-existing@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if number != "8800000002" {
+			t.Errorf("expecting virtual account number to be 8800000002, instead got %s", number)
+		}
+
+		// The previous number is free to be claimed by someone else
+		err = virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "claim-other@example.com", previous)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("Held By Another Customer", func(t *testing.T) {
+		err := virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "claim-first@example.com", "8800000003")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		err = virtualAccountRepository.ClaimVirtualAccountNumber(ctx, "claim-second@example.com", "8800000003")
+		if !errors.Is(err, repository.ErrDuplicate) {
+			t.Errorf("expecting error to be ErrDuplicate, instead got %v", err)
+		}
+	})
+}
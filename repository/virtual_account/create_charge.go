@@ -51,7 +51,7 @@ func (r *Repository) CreateCharge(ctx context.Context, virtualAccountNumber stri
 		orderId,
 		virtualAccountNumber,
 		amount,
-		expiresAt,
+		expiresAt.UTC(),
 		time.Now(),
 		time.Now(),
 	)
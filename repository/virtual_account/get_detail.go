@@ -0,0 +1,80 @@
+package virtual_account
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) GetDetail(ctx context.Context, orderId string) (primitive.VirtualAccountDetail, error) {
+	if orderId == "" {
+		return primitive.VirtualAccountDetail{}, fmt.Errorf("orderId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.VirtualAccountDetail{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return primitive.VirtualAccountDetail{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var recipientName, freeText string
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT
+			recipient_name,
+			free_text
+		FROM
+			virtual_account_details
+		WHERE
+			order_id = ?`,
+		orderId,
+	).Scan(
+		&recipientName,
+		&freeText,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.VirtualAccountDetail{}, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return primitive.VirtualAccountDetail{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.VirtualAccountDetail{}, fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return primitive.VirtualAccountDetail{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	detail := primitive.VirtualAccountDetail{RecipientName: recipientName}
+	if freeText != "" {
+		err = json.Unmarshal([]byte(freeText), &detail.FreeText)
+		if err != nil {
+			return primitive.VirtualAccountDetail{}, fmt.Errorf("unmarshaling free text: %w", err)
+		}
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,72 @@
+package virtual_account_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/virtual_account"
+)
+
+func TestRepository_GetDetail(t *testing.T) {
+	virtualAccountRepository, err := virtual_account.NewVirtualAccountRepository(db)
+	if err != nil {
+		t.Fatalf("creating virtual account repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Order ID", func(t *testing.T) {
+		_, err := virtualAccountRepository.GetDetail(ctx, "")
+		if err == nil {
+			t.Fatalf("expecting an error, got nil")
+		}
+
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting error to be 'orderId is empty', instead got '%s'", err.Error())
+		}
+	})
+
+	t.Run("Not Set", func(t *testing.T) {
+		detail, err := virtualAccountRepository.GetDetail(ctx, uuid.NewString())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(detail, primitive.VirtualAccountDetail{}) {
+			t.Errorf("expecting an empty detail, instead got %v", detail)
+		}
+	})
+
+	t.Run("Happy Case", func(t *testing.T) {
+		expected := primitive.VirtualAccountDetail{
+			RecipientName: "SUDARSONO",
+			FreeText: primitive.FreeText{
+				Inquiry: []primitive.FreeTextLine{
+					{Indonesian: "Tagihan bulan ini", English: "This month's bill"},
+					{Indonesian: "Jatuh tempo tanggal 10", English: "Due on the 10th"},
+				},
+				Payment: []primitive.FreeTextLine{{Indonesian: "Terima kasih", English: "Thank you"}},
+			},
+		}
+
+		orderId := uuid.NewString()
+		err := virtualAccountRepository.SaveDetail(ctx, orderId, expected)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		detail, err := virtualAccountRepository.GetDetail(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(detail, expected) {
+			t.Errorf("expecting detail to be %v, instead got %v", expected, detail)
+		}
+	})
+}
@@ -35,7 +35,7 @@ func (r *Repository) GetByVirtualAccountNumber(ctx context.Context, virtualAccou
 		return repository.Entry{}, fmt.Errorf("creating transaction: %w", err)
 	}
 
-	var currentOrderId string
+	var currentOrderId sql.NullString
 	err = tx.QueryRowContext(
 		ctx,
 		`SELECT current_order_id FROM virtual_accounts WHERE virtual_account_number = ?`,
@@ -53,6 +53,15 @@ func (r *Repository) GetByVirtualAccountNumber(ctx context.Context, virtualAccou
 		return repository.Entry{}, fmt.Errorf("executing query: %w", err)
 	}
 
+	// The virtual account number is not charged for any order
+	if !currentOrderId.Valid {
+		if e := tx.Rollback(); e != nil && !errors.Is(e, sql.ErrTxDone) {
+			return repository.Entry{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return repository.Entry{}, repository.ErrNotFound
+	}
+
 	var entry repository.Entry
 	err = tx.QueryRowContext(
 		ctx,
@@ -65,7 +74,7 @@ func (r *Repository) GetByVirtualAccountNumber(ctx context.Context, virtualAccou
 		    virtual_account_entries
 		WHERE
 		    order_id = ?`,
-		currentOrderId,
+		currentOrderId.String,
 	).Scan(
 		&entry.OrderId,
 		&entry.VirtualAccountNumber,
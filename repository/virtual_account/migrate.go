@@ -91,6 +91,23 @@ func (r *Repository) Migrate(ctx context.Context) error {
 		return fmt.Errorf("executing query: %w", err)
 	}
 
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS virtual_account_details (
+			order_id TEXT PRIMARY KEY,
+			recipient_name TEXT NOT NULL,
+			free_text TEXT NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
@@ -0,0 +1,96 @@
+package virtual_account_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/virtual_account"
+)
+
+func TestRepository_ReleaseCharge(t *testing.T) {
+	virtualAccountRepository, err := virtual_account.NewVirtualAccountRepository(db)
+	if err != nil {
+		t.Fatalf("creating virtual account repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		err := virtualAccountRepository.ReleaseCharge(ctx, "")
+		if err == nil {
+			t.Errorf("expecting an error, got nil")
+		}
+
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', got %s instead", err.Error())
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		err := virtualAccountRepository.ReleaseCharge(ctx, uuid.NewString())
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	})
+
+	t.Run("Happy Integration", func(t *testing.T) {
+		vaNumber, err := virtualAccountRepository.CreateOrGetVirtualAccountNumber(ctx, "releasedoe@example.com")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		orderId := uuid.NewString()
+		_, err = virtualAccountRepository.CreateCharge(ctx, vaNumber, orderId, 50000, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		err = virtualAccountRepository.ReleaseCharge(ctx, orderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		_, err = virtualAccountRepository.GetByVirtualAccountNumber(ctx, vaNumber)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting error to be repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Charged For Another Order", func(t *testing.T) {
+		vaNumber, err := virtualAccountRepository.CreateOrGetVirtualAccountNumber(ctx, "rechargedoe@example.com")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		previousOrderId := uuid.NewString()
+		_, err = virtualAccountRepository.CreateCharge(ctx, vaNumber, previousOrderId, 50000, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		currentOrderId := uuid.NewString()
+		_, err = virtualAccountRepository.CreateCharge(ctx, vaNumber, currentOrderId, 75000, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		err = virtualAccountRepository.ReleaseCharge(ctx, previousOrderId)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		entry, err := virtualAccountRepository.GetByVirtualAccountNumber(ctx, vaNumber)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if entry.OrderId != currentOrderId {
+			t.Errorf("expecting orderId to be %s, instead got %s", currentOrderId, entry.OrderId)
+		}
+	})
+}
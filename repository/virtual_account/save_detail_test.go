@@ -0,0 +1,61 @@
+package virtual_account_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository/virtual_account"
+)
+
+func TestRepository_SaveDetail(t *testing.T) {
+	virtualAccountRepository, err := virtual_account.NewVirtualAccountRepository(db)
+	if err != nil {
+		t.Fatalf("creating virtual account repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Order ID", func(t *testing.T) {
+		err := virtualAccountRepository.SaveDetail(ctx, "", primitive.VirtualAccountDetail{})
+		if err == nil {
+			t.Fatalf("expecting an error, got nil")
+		}
+
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting error to be 'orderId is empty', instead got '%s'", err.Error())
+		}
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		orderId := uuid.NewString()
+		err := virtualAccountRepository.SaveDetail(ctx, orderId, primitive.VirtualAccountDetail{RecipientName: "OLD NAME"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		expected := primitive.VirtualAccountDetail{
+			RecipientName: "NEW NAME",
+			FreeText: primitive.FreeText{
+				Payment: []primitive.FreeTextLine{{Indonesian: "Terima kasih", English: "Thank you"}},
+			},
+		}
+		err = virtualAccountRepository.SaveDetail(ctx, orderId, expected)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		detail, err := virtualAccountRepository.GetDetail(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(detail, expected) {
+			t.Errorf("expecting detail to be %v, instead got %v", expected, detail)
+		}
+	})
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"time"
+
+	"mock-payment-provider/primitive"
 )
 
 type VirtualAccountRepository interface {
@@ -15,6 +17,11 @@ type VirtualAccountRepository interface {
 	// retrieve the virtual account number directly that's supposed to be exists.
 	CreateOrGetVirtualAccountNumber(ctx context.Context, customerUniqueField string) (string, error)
 
+	// ClaimVirtualAccountNumber registers the virtualAccountNumber (usually given by the merchant) as the
+	// one of customerUniqueField, replacing the number that the customer had before. It returns
+	// ErrDuplicate if the number is held by another customer.
+	ClaimVirtualAccountNumber(ctx context.Context, customerUniqueField string, virtualAccountNumber string) error
+
 	// CreateCharge create (or replace) the charged amount of the virtual account number.
 	// If such virtual account number does not exist, it will create a new one.
 	CreateCharge(ctx context.Context, virtualAccountNumber string, orderId string, amount int64, expiresAt time.Time) (account string, err error)
@@ -34,4 +41,17 @@ type VirtualAccountRepository interface {
 	// DeductCharge will free the virtual account number out of all charges. In other word,
 	// it reset the charged amount to zero.
 	DeductCharge(ctx context.Context, virtualAccountNumber string) error
+
+	// ReleaseCharge frees the virtual account number out of the charge of the order id,
+	// so the customer can no longer pay for it. It does nothing if the virtual account
+	// number has been charged for another order since.
+	ReleaseCharge(ctx context.Context, orderId string) error
+
+	// SaveDetail stores what the merchant wants the bank to show to the customer for the charge
+	// of the order id, replacing the previous one.
+	SaveDetail(ctx context.Context, orderId string, detail primitive.VirtualAccountDetail) error
+
+	// GetDetail acquires the detail of the charge of the order id. It returns an empty detail
+	// if none was saved.
+	GetDetail(ctx context.Context, orderId string) (primitive.VirtualAccountDetail, error)
 }
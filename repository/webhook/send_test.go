@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -11,7 +12,7 @@ import (
 )
 
 func TestClient_Send(t *testing.T) {
-	webhookClient, err := webhook.NewWebhookClient(mockServerAddress)
+	webhookClient, err := webhook.NewWebhookClient(webhook.Config{TargetURL: mockServerAddress})
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err.Error())
 	}
@@ -21,17 +22,153 @@ func TestClient_Send(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 		defer cancel()
 
-		err := webhookClient.Send(ctx, payload)
+		response, err := webhookClient.Send(ctx, "", payload)
 		if err != nil {
 			t.Errorf("unexpected error: %s", err.Error())
 		}
 
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("expecting StatusCode to equal 200, instead got %d", response.StatusCode)
+		}
+
 		if !bytes.Equal(incomingRequests.lastRequest.Body, payload) {
 			t.Errorf("expecting lastRequest.Body to equal payload, instead got %s vs %s", string(incomingRequests.lastRequest.Body), string(payload))
 		}
 
+		if response.Body != "OK" {
+			t.Errorf("expecting Body to equal OK, instead got %s", response.Body)
+		}
+
+		if response.TargetURL != mockServerAddress {
+			t.Errorf("expecting TargetURL to equal %s, instead got %s", mockServerAddress, response.TargetURL)
+		}
+
 		if incomingRequests.lastRequest.Method != http.MethodPost {
 			t.Errorf("expecting lastRequest.Method to equal POST, instead got %s", incomingRequests.lastRequest.Method)
 		}
 	})
+
+	t.Run("Explicit Target", func(t *testing.T) {
+		unreachableClient, err := webhook.NewWebhookClient(webhook.Config{TargetURL: "http://127.0.0.1:1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		payload := []byte("Hello explicit target")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		response, err := unreachableClient.Send(ctx, mockServerAddress, payload)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("expecting StatusCode to equal 200, instead got %d", response.StatusCode)
+		}
+
+		if !bytes.Equal(incomingRequests.lastRequest.Body, payload) {
+			t.Errorf("expecting lastRequest.Body to equal payload, instead got %s vs %s", string(incomingRequests.lastRequest.Body), string(payload))
+		}
+	})
+
+	t.Run("Unreachable Target", func(t *testing.T) {
+		unreachableClient, err := webhook.NewWebhookClient(webhook.Config{TargetURL: "http://127.0.0.1:1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		response, err := unreachableClient.Send(ctx, "", []byte("Hello world"))
+		if err == nil {
+			t.Errorf("expecting an error, instead got nil")
+		}
+
+		if response.StatusCode != 0 {
+			t.Errorf("expecting StatusCode to equal 0, instead got %d", response.StatusCode)
+		}
+
+		if response.TargetURL != "http://127.0.0.1:1" {
+			t.Errorf("expecting TargetURL to equal the default target, instead got %s", response.TargetURL)
+		}
+	})
+
+	t.Run("Follows Temporary and Permanent Redirects", func(t *testing.T) {
+		payload := []byte("Hello redirect")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		response, err := webhookClient.Send(ctx, mockServerAddress+"/permanent-redirect", payload)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("expecting StatusCode to equal 200, instead got %d", response.StatusCode)
+		}
+
+		if !bytes.Equal(incomingRequests.lastRequest.Body, payload) {
+			t.Errorf("expecting lastRequest.Body to equal payload, instead got %s vs %s", string(incomingRequests.lastRequest.Body), string(payload))
+		}
+
+		if incomingRequests.lastRequest.Method != http.MethodPost {
+			t.Errorf("expecting lastRequest.Method to equal POST, instead got %s", incomingRequests.lastRequest.Method)
+		}
+	})
+
+	t.Run("Stops On Other Redirects", func(t *testing.T) {
+		requestCount := len(incomingRequests.requests)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		response, err := webhookClient.Send(ctx, mockServerAddress+"/found", []byte("Hello found"))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if response.StatusCode != http.StatusFound {
+			t.Errorf("expecting StatusCode to equal 302, instead got %d", response.StatusCode)
+		}
+
+		if len(incomingRequests.requests) != requestCount {
+			t.Errorf("expecting the redirect to not be followed")
+		}
+	})
+
+	t.Run("Too Many Redirects", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		response, err := webhookClient.Send(ctx, mockServerAddress+"/redirect-loop", []byte("Hello loop"))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+
+		if response.StatusCode != http.StatusTemporaryRedirect {
+			t.Errorf("expecting StatusCode to equal 307, instead got %d", response.StatusCode)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Millisecond * 500)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer slowServer.Close()
+
+		slowClient, err := webhook.NewWebhookClient(webhook.Config{
+			TargetURL: slowServer.URL,
+			Timeout:   time.Millisecond * 50,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		_, err = slowClient.Send(context.Background(), "", []byte("Hello slow"))
+		if err == nil {
+			t.Errorf("expecting an error, instead got nil")
+		}
+	})
 }
@@ -1,9 +1,68 @@
 package webhook
 
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maximumRedirects is how many 307/308 redirects Midtrans follows for a single attempt.
+const maximumRedirects = 5
+
+type Config struct {
+	// TargetURL is the default webhook target, for notifications without one of their own.
+	TargetURL string
+	// HTTPClient defaults to a new http.Client. It is copied, and its redirect policy is
+	// replaced with the one of Midtrans.
+	HTTPClient *http.Client
+	// Timeout bounds a single attempt, including the redirects it follows. Defaults to the
+	// timeout of HTTPClient, or 30 seconds if it has none.
+	Timeout time.Duration
+}
+
 type Client struct {
-	targetUrl string
+	targetUrl  string
+	httpClient *http.Client
 }
 
-func NewWebhookClient(targetUrl string) (*Client, error) {
-	return &Client{targetUrl: targetUrl}, nil
+func NewWebhookClient(config Config) (*Client, error) {
+	if config.Timeout < 0 {
+		return nil, fmt.Errorf("timeout must not be negative")
+	}
+
+	httpClient := &http.Client{}
+	if config.HTTPClient != nil {
+		copied := *config.HTTPClient
+		httpClient = &copied
+	}
+
+	if config.Timeout > 0 {
+		httpClient.Timeout = config.Timeout
+	} else if httpClient.Timeout == 0 {
+		// A single attempt should never hold a delivery worker for too long
+		httpClient.Timeout = time.Second * 30
+	}
+
+	httpClient.CheckRedirect = checkRedirect
+
+	return &Client{
+		targetUrl:  config.TargetURL,
+		httpClient: httpClient,
+	}, nil
+}
+
+// checkRedirect follows 307 and 308 redirects with the same POST body, up to
+// maximumRedirects times. Any other redirect, or one too many, ends the attempt with
+// the redirect response itself.
+func checkRedirect(request *http.Request, via []*http.Request) error {
+	switch request.Response.StatusCode {
+	case http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		if len(via) > maximumRedirects {
+			return http.ErrUseLastResponse
+		}
+
+		return nil
+	default:
+		return http.ErrUseLastResponse
+	}
 }
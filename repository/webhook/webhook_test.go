@@ -26,6 +26,22 @@ type requestResult struct {
 func MockWebhookTargetServer(incomingRequests *MockRequest) *httptest.Server {
 	mux := http.NewServeMux()
 
+	mux.HandleFunc("/temporary-redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
+	})
+
+	mux.HandleFunc("/permanent-redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/temporary-redirect", http.StatusPermanentRedirect)
+	})
+
+	mux.HandleFunc("/found", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+
+	mux.HandleFunc("/redirect-loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/redirect-loop", http.StatusTemporaryRedirect)
+	})
+
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		var requestBody []byte = nil
 		if r.Body != nil {
@@ -47,6 +63,7 @@ func MockWebhookTargetServer(incomingRequests *MockRequest) *httptest.Server {
 		incomingRequests.requests = append(incomingRequests.requests, result)
 		incomingRequests.lastRequest = result
 		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
 	})
 
 	return httptest.NewServer(mux)
@@ -1,7 +1,24 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type WebhookClient interface {
-	Send(ctx context.Context, payload []byte) error
+	// Send makes a single delivery attempt of the payload to targetUrl, or to the default
+	// webhook target if targetUrl is empty, and returns the response of the merchant. It
+	// only returns an error if no response was received at all, retrying is up to the
+	// caller. The response is returned along with the error, so the attempt can be recorded.
+	Send(ctx context.Context, targetUrl string, payload []byte) (WebhookResponse, error)
+}
+
+type WebhookResponse struct {
+	// TargetURL is the URL the payload was sent to, after falling back to the default target.
+	TargetURL string
+	// StatusCode is zero if no response was received.
+	StatusCode int
+	// Body is cut off after the first kilobyte.
+	Body    string
+	Latency time.Duration
 }
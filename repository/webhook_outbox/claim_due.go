@@ -0,0 +1,158 @@
+package webhook_outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]primitive.WebhookNotification, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than zero")
+	}
+
+	if lease <= 0 {
+		return nil, fmt.Errorf("lease must be greater than zero")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	// Claiming must be serializable, otherwise two workers could claim the same notification
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+			id,
+			order_id,
+			target_url,
+			payload,
+			status,
+			attempts,
+			next_attempt_at,
+			last_status_code,
+			last_error,
+			created_at,
+			updated_at
+		FROM
+			webhook_outbox
+		WHERE
+			status = ?
+			AND next_attempt_at <= ?
+		ORDER BY
+			next_attempt_at ASC
+		LIMIT ?`,
+		primitive.WebhookStatusPending,
+		now,
+		limit,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	var notifications []primitive.WebhookNotification
+	for rows.Next() {
+		var notification primitive.WebhookNotification
+		err := rows.Scan(
+			&notification.Id,
+			&notification.OrderId,
+			&notification.TargetURL,
+			&notification.Payload,
+			&notification.Status,
+			&notification.Attempts,
+			&notification.NextAttemptAt,
+			&notification.LastStatusCode,
+			&notification.LastError,
+			&notification.CreatedAt,
+			&notification.UpdatedAt,
+		)
+		if err != nil {
+			_ = rows.Close()
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		_ = rows.Close()
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("closing rows: %w", err)
+	}
+
+	leasedUntil := now.Add(lease)
+	for i := range notifications {
+		_, err := tx.ExecContext(
+			ctx,
+			`UPDATE webhook_outbox SET next_attempt_at = ?, updated_at = ? WHERE id = ?`,
+			leasedUntil,
+			now,
+			notifications[i].Id,
+		)
+		if err != nil {
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("executing query: %w", err)
+		}
+
+		notifications[i].NextAttemptAt = leasedUntil
+		notifications[i].UpdatedAt = now
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return notifications, nil
+}
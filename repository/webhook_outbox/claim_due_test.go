@@ -0,0 +1,103 @@
+package webhook_outbox_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+func TestRepository_ClaimDue(t *testing.T) {
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		t.Fatalf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		_, err := webhookOutboxRepository.ClaimDue(ctx, 0, time.Minute)
+		if err.Error() != "limit must be greater than zero" {
+			t.Errorf("expecting an error of 'limit must be greater than zero', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Invalid Lease", func(t *testing.T) {
+		_, err := webhookOutboxRepository.ClaimDue(ctx, 10, 0)
+		if err.Error() != "lease must be greater than zero" {
+			t.Errorf("expecting an error of 'lease must be greater than zero', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy Integration", func(t *testing.T) {
+		payload := []byte(`{"transaction_status":"settlement"}`)
+
+		due, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+			OrderId:   uuid.NewString(),
+			TargetURL: "https://merchant.example.com/notify",
+			Payload:   payload,
+		})
+		if err != nil {
+			t.Fatalf("enqueueing due notification: %s", err.Error())
+		}
+
+		notYetDue, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+			OrderId:       uuid.NewString(),
+			Payload:       payload,
+			NextAttemptAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("enqueueing not yet due notification: %s", err.Error())
+		}
+
+		claimed, err := webhookOutboxRepository.ClaimDue(ctx, 100, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		var claimedDue *primitive.WebhookNotification
+		for i := range claimed {
+			if claimed[i].Id == notYetDue.Id {
+				t.Errorf("expecting notification that is not yet due to not be claimed")
+			}
+
+			if claimed[i].Id == due.Id {
+				claimedDue = &claimed[i]
+			}
+		}
+
+		if claimedDue == nil {
+			t.Fatalf("expecting due notification to be claimed")
+		}
+
+		if !bytes.Equal(claimedDue.Payload, payload) {
+			t.Errorf("expecting payload to be %s, instead got %s", string(payload), string(claimedDue.Payload))
+		}
+
+		if claimedDue.TargetURL != "https://merchant.example.com/notify" {
+			t.Errorf("expecting target url to be https://merchant.example.com/notify, instead got %s", claimedDue.TargetURL)
+		}
+
+		if claimedDue.NextAttemptAt.Before(time.Now()) {
+			t.Errorf("expecting next attempt to be pushed back by the lease, instead got %s", claimedDue.NextAttemptAt)
+		}
+
+		// The lease keeps it from being claimed again
+		claimed, err = webhookOutboxRepository.ClaimDue(ctx, 100, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		for _, notification := range claimed {
+			if notification.Id == due.Id {
+				t.Errorf("expecting leased notification to not be claimed again")
+			}
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package webhook_outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) Enqueue(ctx context.Context, params repository.EnqueueWebhookParam) (primitive.WebhookNotification, error) {
+	if params.OrderId == "" {
+		return primitive.WebhookNotification{}, fmt.Errorf("orderId is empty")
+	}
+
+	if len(params.Payload) == 0 {
+		return primitive.WebhookNotification{}, fmt.Errorf("payload is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.WebhookNotification{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return primitive.WebhookNotification{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	// Times are stored in UTC, so they can be compared as they are within the query
+	now := time.Now().UTC()
+	nextAttemptAt := params.NextAttemptAt.UTC()
+	if params.NextAttemptAt.IsZero() {
+		nextAttemptAt = now
+	}
+
+	notification := primitive.WebhookNotification{
+		Id:            uuid.NewString(),
+		OrderId:       params.OrderId,
+		TargetURL:     params.TargetURL,
+		Payload:       params.Payload,
+		Status:        primitive.WebhookStatusPending,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			webhook_outbox
+			(
+				id,
+				order_id,
+				target_url,
+				payload,
+				status,
+				attempts,
+				next_attempt_at,
+				last_status_code,
+				last_error,
+				created_at,
+				updated_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, 0, ?, 0, '', ?, ?)`,
+		notification.Id,
+		notification.OrderId,
+		notification.TargetURL,
+		notification.Payload,
+		notification.Status,
+		notification.NextAttemptAt,
+		notification.CreatedAt,
+		notification.UpdatedAt,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.WebhookNotification{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.WebhookNotification{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.WebhookNotification{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.WebhookNotification{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return notification, nil
+}
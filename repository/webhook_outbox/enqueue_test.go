@@ -0,0 +1,59 @@
+package webhook_outbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+func TestRepository_Enqueue(t *testing.T) {
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		t.Fatalf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{Payload: []byte("{}")})
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Empty Payload", func(t *testing.T) {
+		_, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{OrderId: "a"})
+		if err.Error() != "payload is empty" {
+			t.Errorf("expecting an error of 'payload is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Happy", func(t *testing.T) {
+		notification, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+			OrderId:       uuid.NewString(),
+			Payload:       []byte(`{"transaction_status":"pending"}`),
+			NextAttemptAt: time.Now().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if notification.Id == "" {
+			t.Errorf("expecting id to be not empty")
+		}
+
+		if notification.Status != primitive.WebhookStatusPending {
+			t.Errorf("expecting status to be %s, instead got %s", primitive.WebhookStatusPending, notification.Status)
+		}
+
+		if notification.Attempts != 0 {
+			t.Errorf("expecting attempts to be 0, instead got %d", notification.Attempts)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package webhook_outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) GetAttempt(ctx context.Context, id string) (primitive.WebhookAttempt, error) {
+	if id == "" {
+		return primitive.WebhookAttempt{}, fmt.Errorf("id is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return primitive.WebhookAttempt{}, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return primitive.WebhookAttempt{}, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	var attempt primitive.WebhookAttempt
+	var latencyMs int64
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT
+			webhook_attempt.id,
+			webhook_attempt.webhook_id,
+			webhook_outbox.order_id,
+			webhook_attempt.target_url,
+			webhook_outbox.payload,
+			webhook_attempt.status,
+			webhook_attempt.status_code,
+			webhook_attempt.response_body,
+			webhook_attempt.latency_ms,
+			webhook_attempt.error,
+			webhook_attempt.created_at
+		FROM
+			webhook_attempt
+			INNER JOIN webhook_outbox ON webhook_outbox.id = webhook_attempt.webhook_id
+		WHERE
+			webhook_attempt.id = ?`,
+		id,
+	).Scan(
+		&attempt.Id,
+		&attempt.WebhookId,
+		&attempt.OrderId,
+		&attempt.TargetURL,
+		&attempt.Payload,
+		&attempt.Status,
+		&attempt.StatusCode,
+		&attempt.ResponseBody,
+		&latencyMs,
+		&attempt.Error,
+		&attempt.AttemptedAt,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.WebhookAttempt{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return primitive.WebhookAttempt{}, repository.ErrNotFound
+		}
+
+		return primitive.WebhookAttempt{}, fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return primitive.WebhookAttempt{}, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return primitive.WebhookAttempt{}, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	attempt.Latency = time.Duration(latencyMs) * time.Millisecond
+
+	return attempt, nil
+}
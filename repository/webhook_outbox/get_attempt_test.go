@@ -0,0 +1,80 @@
+package webhook_outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+func TestRepository_GetAttempt(t *testing.T) {
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		t.Fatalf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Id", func(t *testing.T) {
+		_, err := webhookOutboxRepository.GetAttempt(ctx, "")
+		if err.Error() != "id is empty" {
+			t.Errorf("expecting an error of 'id is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := webhookOutboxRepository.GetAttempt(ctx, uuid.NewString())
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Happy Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+		notification, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+			OrderId: orderId,
+			Payload: []byte(`{"transaction_status":"pending"}`),
+		})
+		if err != nil {
+			t.Fatalf("enqueueing notification: %s", err.Error())
+		}
+
+		err = webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{
+			Id:        notification.Id,
+			Status:    primitive.WebhookStatusFailed,
+			TargetURL: "http://127.0.0.1:1",
+			Error:     "connection refused",
+		})
+		if err != nil {
+			t.Fatalf("recording attempt: %s", err.Error())
+		}
+
+		attempts, err := webhookOutboxRepository.ListAttempts(ctx, orderId)
+		if err != nil || len(attempts) != 1 {
+			t.Fatalf("expecting a single attempt, instead got %d (%v)", len(attempts), err)
+		}
+
+		attempt, err := webhookOutboxRepository.GetAttempt(ctx, attempts[0].Id)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if attempt.TargetURL != "http://127.0.0.1:1" {
+			t.Errorf("expecting target url to be http://127.0.0.1:1, instead got %s", attempt.TargetURL)
+		}
+
+		if attempt.Error != "connection refused" {
+			t.Errorf("expecting error to be 'connection refused', instead got %s", attempt.Error)
+		}
+
+		if attempt.StatusCode != 0 {
+			t.Errorf("expecting status code to be 0, instead got %d", attempt.StatusCode)
+		}
+	})
+}
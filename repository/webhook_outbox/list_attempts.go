@@ -0,0 +1,129 @@
+package webhook_outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+)
+
+func (r *Repository) ListAttempts(ctx context.Context, orderId string) ([]primitive.WebhookAttempt, error) {
+	if orderId == "" {
+		return nil, fmt.Errorf("orderId is empty")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating transaction: %w", err)
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT
+			webhook_attempt.id,
+			webhook_attempt.webhook_id,
+			webhook_outbox.order_id,
+			webhook_attempt.target_url,
+			webhook_outbox.payload,
+			webhook_attempt.status,
+			webhook_attempt.status_code,
+			webhook_attempt.response_body,
+			webhook_attempt.latency_ms,
+			webhook_attempt.error,
+			webhook_attempt.created_at
+		FROM
+			webhook_attempt
+			INNER JOIN webhook_outbox ON webhook_outbox.id = webhook_attempt.webhook_id
+		WHERE
+			webhook_outbox.order_id = ?
+		ORDER BY
+			webhook_attempt.created_at ASC`,
+		orderId,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("executing query: %w", err)
+	}
+
+	attempts := []primitive.WebhookAttempt{}
+	for rows.Next() {
+		var attempt primitive.WebhookAttempt
+		var latencyMs int64
+		err := rows.Scan(
+			&attempt.Id,
+			&attempt.WebhookId,
+			&attempt.OrderId,
+			&attempt.TargetURL,
+			&attempt.Payload,
+			&attempt.Status,
+			&attempt.StatusCode,
+			&attempt.ResponseBody,
+			&latencyMs,
+			&attempt.Error,
+			&attempt.AttemptedAt,
+		)
+		if err != nil {
+			_ = rows.Close()
+			if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+				return nil, fmt.Errorf("rolling back transaction: %w", e)
+			}
+
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+
+		attempt.Latency = time.Duration(latencyMs) * time.Millisecond
+		attempts = append(attempts, attempt)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		_ = rows.Close()
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("closing rows: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return nil, fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return nil, fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return attempts, nil
+}
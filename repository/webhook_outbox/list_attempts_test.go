@@ -0,0 +1,117 @@
+package webhook_outbox_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+func TestRepository_ListAttempts(t *testing.T) {
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		t.Fatalf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty OrderId", func(t *testing.T) {
+		_, err := webhookOutboxRepository.ListAttempts(ctx, "")
+		if err.Error() != "orderId is empty" {
+			t.Errorf("expecting an error of 'orderId is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Nothing Attempted", func(t *testing.T) {
+		attempts, err := webhookOutboxRepository.ListAttempts(ctx, uuid.NewString())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(attempts) != 0 {
+			t.Errorf("expecting no attempts, instead got %d", len(attempts))
+		}
+	})
+
+	t.Run("Happy Integration", func(t *testing.T) {
+		orderId := uuid.NewString()
+		payload := []byte(`{"transaction_status":"settlement"}`)
+
+		notification, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+			OrderId: orderId,
+			Payload: payload,
+		})
+		if err != nil {
+			t.Fatalf("enqueueing notification: %s", err.Error())
+		}
+
+		err = webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{
+			Id:            notification.Id,
+			Status:        primitive.WebhookStatusPending,
+			TargetURL:     "https://merchant.example.com/notify",
+			StatusCode:    500,
+			ResponseBody:  "internal server error",
+			Latency:       time.Millisecond * 150,
+			Error:         "unexpected status code 500",
+			NextAttemptAt: time.Now().Add(time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("recording first attempt: %s", err.Error())
+		}
+
+		err = webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{
+			Id:         notification.Id,
+			Status:     primitive.WebhookStatusDelivered,
+			TargetURL:  "https://merchant.example.com/notify",
+			StatusCode: 200,
+			Latency:    time.Millisecond * 20,
+		})
+		if err != nil {
+			t.Fatalf("recording second attempt: %s", err.Error())
+		}
+
+		attempts, err := webhookOutboxRepository.ListAttempts(ctx, orderId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		if len(attempts) != 2 {
+			t.Fatalf("expecting 2 attempts, instead got %d", len(attempts))
+		}
+
+		first := attempts[0]
+		if first.WebhookId != notification.Id {
+			t.Errorf("expecting webhook id to be %s, instead got %s", notification.Id, first.WebhookId)
+		}
+
+		if first.OrderId != orderId {
+			t.Errorf("expecting order id to be %s, instead got %s", orderId, first.OrderId)
+		}
+
+		if !bytes.Equal(first.Payload, payload) {
+			t.Errorf("expecting payload to be %s, instead got %s", string(payload), string(first.Payload))
+		}
+
+		if first.Status != primitive.WebhookStatusPending || first.StatusCode != 500 {
+			t.Errorf("expecting the first attempt to be a pending 500, instead got %s %d", first.Status, first.StatusCode)
+		}
+
+		if first.ResponseBody != "internal server error" {
+			t.Errorf("expecting response body to be 'internal server error', instead got %s", first.ResponseBody)
+		}
+
+		if first.Latency != time.Millisecond*150 {
+			t.Errorf("expecting latency to be 150ms, instead got %s", first.Latency)
+		}
+
+		if attempts[1].Status != primitive.WebhookStatusDelivered {
+			t.Errorf("expecting the second attempt to be delivered, instead got %s", attempts[1].Status)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package webhook_outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+func (r *Repository) Migrate(ctx context.Context) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS webhook_outbox (
+			id TEXT PRIMARY KEY,
+			order_id TEXT NOT NULL,
+			target_url TEXT NOT NULL,
+			payload BLOB NOT NULL,
+			status INTEGER NOT NULL,
+			attempts INTEGER NOT NULL,
+			next_attempt_at DATETIME NOT NULL,
+			last_status_code INTEGER NOT NULL,
+			last_error TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_outbox_status_next_attempt_at ON webhook_outbox (status, next_attempt_at)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create index webhook outbox: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS webhook_attempt (
+			id TEXT PRIMARY KEY,
+			webhook_id TEXT NOT NULL,
+			target_url TEXT NOT NULL,
+			status INTEGER NOT NULL,
+			status_code INTEGER NOT NULL,
+			response_body TEXT NOT NULL,
+			latency_ms INTEGER NOT NULL,
+			error TEXT NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create table webhook attempt: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_attempt_webhook_id ON webhook_attempt (webhook_id)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create index webhook attempt: %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_outbox_order_id ON webhook_outbox (order_id)`,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("executing create index webhook outbox: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", err)
+		}
+
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return nil
+}
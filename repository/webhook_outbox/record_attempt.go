@@ -0,0 +1,144 @@
+package webhook_outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+)
+
+func (r *Repository) RecordAttempt(ctx context.Context, params repository.RecordWebhookAttemptParam) error {
+	if params.Id == "" {
+		return fmt.Errorf("id is empty")
+	}
+
+	if params.Status == primitive.WebhookStatusUnspecified {
+		return fmt.Errorf("status is unspecified")
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection from pool: %w", err)
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil && !errors.Is(err, sql.ErrConnDone) {
+			log := zerolog.Ctx(ctx)
+			log.Err(err).Msg("returning connection back to pool")
+		}
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	})
+	if err != nil {
+		return fmt.Errorf("creating transaction: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	// Delivered and failed notifications are never claimed again, their next attempt
+	// is kept as the time of their last attempt.
+	nextAttemptAt := now
+	if params.Status == primitive.WebhookStatusPending && !params.NextAttemptAt.IsZero() {
+		nextAttemptAt = params.NextAttemptAt.UTC()
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE
+			webhook_outbox
+		SET
+			status = ?,
+			attempts = attempts + 1,
+			next_attempt_at = ?,
+			last_status_code = ?,
+			last_error = ?,
+			updated_at = ?
+		WHERE
+			id = ?`,
+		params.Status,
+		nextAttemptAt,
+		params.StatusCode,
+		params.Error,
+		now,
+		params.Id,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("acquiring affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return repository.ErrNotFound
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO
+			webhook_attempt
+			(
+				id,
+				webhook_id,
+				target_url,
+				status,
+				status_code,
+				response_body,
+				latency_ms,
+				error,
+				created_at
+			)
+		VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		uuid.NewString(),
+		params.Id,
+		params.TargetURL,
+		params.Status,
+		params.StatusCode,
+		params.ResponseBody,
+		params.Latency.Milliseconds(),
+		params.Error,
+		now,
+	)
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("executing query: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		if e := tx.Rollback(); e != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("rolling back transaction: %w", e)
+		}
+
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+
+	return nil
+}
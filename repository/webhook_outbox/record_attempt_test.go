@@ -0,0 +1,118 @@
+package webhook_outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"mock-payment-provider/primitive"
+	"mock-payment-provider/repository"
+	"mock-payment-provider/repository/webhook_outbox"
+)
+
+func TestRepository_RecordAttempt(t *testing.T) {
+	webhookOutboxRepository, err := webhook_outbox.NewWebhookOutboxRepository(db)
+	if err != nil {
+		t.Fatalf("creating webhook outbox repository: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Run("Empty Id", func(t *testing.T) {
+		err := webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{Status: primitive.WebhookStatusDelivered})
+		if err.Error() != "id is empty" {
+			t.Errorf("expecting an error of 'id is empty', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Unspecified Status", func(t *testing.T) {
+		err := webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{Id: "a"})
+		if err.Error() != "status is unspecified" {
+			t.Errorf("expecting an error of 'status is unspecified', instead got %s", err.Error())
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		err := webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{
+			Id:     uuid.NewString(),
+			Status: primitive.WebhookStatusDelivered,
+		})
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("expecting an error of repository.ErrNotFound, instead got %v", err)
+		}
+	})
+
+	t.Run("Happy Integration", func(t *testing.T) {
+		notification, err := webhookOutboxRepository.Enqueue(ctx, repository.EnqueueWebhookParam{
+			OrderId: uuid.NewString(),
+			Payload: []byte(`{"transaction_status":"expire"}`),
+		})
+		if err != nil {
+			t.Fatalf("enqueueing notification: %s", err.Error())
+		}
+
+		// A failed attempt that is retried right away makes it due again
+		err = webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{
+			Id:            notification.Id,
+			Status:        primitive.WebhookStatusPending,
+			StatusCode:    503,
+			Error:         "service unavailable",
+			NextAttemptAt: time.Now().Add(-time.Second),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		claimed, err := webhookOutboxRepository.ClaimDue(ctx, 100, time.Minute)
+		if err != nil {
+			t.Fatalf("claiming due notifications: %s", err.Error())
+		}
+
+		var retried *primitive.WebhookNotification
+		for i := range claimed {
+			if claimed[i].Id == notification.Id {
+				retried = &claimed[i]
+			}
+		}
+
+		if retried == nil {
+			t.Fatalf("expecting retried notification to be claimed")
+		}
+
+		if retried.Attempts != 1 {
+			t.Errorf("expecting attempts to be 1, instead got %d", retried.Attempts)
+		}
+
+		if retried.LastStatusCode != 503 {
+			t.Errorf("expecting last status code to be 503, instead got %d", retried.LastStatusCode)
+		}
+
+		if retried.LastError != "service unavailable" {
+			t.Errorf("expecting last error to be 'service unavailable', instead got %s", retried.LastError)
+		}
+
+		// Delivered notifications are never claimed again
+		err = webhookOutboxRepository.RecordAttempt(ctx, repository.RecordWebhookAttemptParam{
+			Id:         notification.Id,
+			Status:     primitive.WebhookStatusDelivered,
+			StatusCode: 200,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+
+		claimed, err = webhookOutboxRepository.ClaimDue(ctx, 100, time.Hour)
+		if err != nil {
+			t.Fatalf("claiming due notifications: %s", err.Error())
+		}
+
+		for _, n := range claimed {
+			if n.Id == notification.Id {
+				t.Errorf("expecting delivered notification to not be claimed again")
+			}
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"mock-payment-provider/primitive"
+)
+
+type WebhookOutboxRepository interface {
+	// Migrate the database
+	Migrate(ctx context.Context) error
+
+	// Enqueue persists a new pending notification, that is due for delivery at
+	// params.NextAttemptAt. A zero NextAttemptAt makes it due right away.
+	Enqueue(ctx context.Context, params EnqueueWebhookParam) (primitive.WebhookNotification, error)
+
+	// ClaimDue acquires at most limit pending notifications that are due, ordered from the
+	// one that has been waiting the longest. Claimed notifications are pushed back by
+	// lease, so they are not claimed again while they are being delivered, and they
+	// become due again if the delivery never gets recorded.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]primitive.WebhookNotification, error)
+
+	// RecordAttempt stores the result of a delivery attempt in the attempt log, and
+	// increments the attempt counter. It returns ErrNotFound if the notification was
+	// not found.
+	RecordAttempt(ctx context.Context, params RecordWebhookAttemptParam) error
+
+	// ListAttempts returns every recorded delivery attempt of the notifications of an
+	// order, from the oldest one. It returns an empty slice if nothing was attempted yet.
+	ListAttempts(ctx context.Context, orderId string) ([]primitive.WebhookAttempt, error)
+
+	// GetAttempt returns a single delivery attempt. It returns ErrNotFound if the attempt
+	// was not found.
+	GetAttempt(ctx context.Context, id string) (primitive.WebhookAttempt, error)
+}
+
+type EnqueueWebhookParam struct {
+	OrderId string
+	// TargetURL is left empty to deliver the notification to the default webhook target.
+	TargetURL     string
+	Payload       []byte
+	NextAttemptAt time.Time
+}
+
+type RecordWebhookAttemptParam struct {
+	Id string
+	// Status is WebhookStatusPending if the notification will be retried at NextAttemptAt.
+	Status        primitive.WebhookStatus
+	TargetURL     string
+	StatusCode    int
+	ResponseBody  string
+	Latency       time.Duration
+	Error         string
+	NextAttemptAt time.Time
+}